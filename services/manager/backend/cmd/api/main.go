@@ -2,31 +2,52 @@ package main
 
 import (
 	"context"
+	"crypto/rand"
+	"fmt"
+	"log/slog"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/labstack/echo/v4"
 	echomiddleware "github.com/labstack/echo/v4/middleware"
 
+	"github.com/sky0621/techcv/manager/backend/internal/infrastructure/analytics"
 	authinfra "github.com/sky0621/techcv/manager/backend/internal/infrastructure/auth"
 	"github.com/sky0621/techcv/manager/backend/internal/infrastructure/clock"
 	"github.com/sky0621/techcv/manager/backend/internal/infrastructure/email"
+	"github.com/sky0621/techcv/manager/backend/internal/infrastructure/geoip"
 	"github.com/sky0621/techcv/manager/backend/internal/infrastructure/logger"
 	"github.com/sky0621/techcv/manager/backend/internal/infrastructure/mysql"
-	"github.com/sky0621/techcv/manager/backend/internal/infrastructure/persistence/memory"
+	"github.com/sky0621/techcv/manager/backend/internal/infrastructure/qrcode"
+	"github.com/sky0621/techcv/manager/backend/internal/infrastructure/ratelimit"
 	"github.com/sky0621/techcv/manager/backend/internal/infrastructure/server"
+	"github.com/sky0621/techcv/manager/backend/internal/infrastructure/skillseed"
 	"github.com/sky0621/techcv/manager/backend/internal/infrastructure/transaction"
 	handler "github.com/sky0621/techcv/manager/backend/internal/interface/http/handler"
 	httpmiddleware "github.com/sky0621/techcv/manager/backend/internal/interface/http/middleware"
 	"github.com/sky0621/techcv/manager/backend/internal/usecase/auth"
+	"github.com/sky0621/techcv/manager/backend/internal/usecase/gitimport"
 	"github.com/sky0621/techcv/manager/backend/internal/usecase/health"
+	"github.com/sky0621/techcv/manager/backend/internal/usecase/publicurl"
+	"github.com/sky0621/techcv/manager/backend/internal/usecase/skillcatalog"
 )
 
 const (
-	requestTimeout         = 30 * time.Second
-	defaultVerificationTTL = 24 * time.Hour
+	apiBasePath             = "/techcv/api/v1"
+	requestTimeout          = 30 * time.Second
+	defaultVerificationTTL  = 24 * time.Hour
+	authSessionTTL          = 7 * 24 * time.Hour
+	publicURLReminderLead   = 24 * time.Hour
+	defaultSlugRedirect     = 90 * 24 * time.Hour
+	sweepInterval           = 15 * time.Minute
+	viewerPassTTL           = time.Hour
+	passphraseAttemptLimit  = 10
+	passphraseAttemptWindow = 15 * time.Minute
+	randomSecretBytes       = 32
+	qrCodeCacheSize         = 512
 )
 
 func main() {
@@ -58,6 +79,9 @@ func main() {
 	e := echo.New()
 	e.HideBanner = true
 	e.HidePort = true
+	// Only trust X-Forwarded-For entries added by proxies on private networks, so that
+	// clients cannot pick the address their passphrase attempts are counted against.
+	e.IPExtractor = echo.ExtractIPFromXFFHeader()
 
 	errorHandler := httpmiddleware.NewErrorHandler(log)
 	e.HTTPErrorHandler = errorHandler.Handle
@@ -66,15 +90,16 @@ func main() {
 	e.Use(echomiddleware.Recover())
 	e.Use(httpmiddleware.Timeout(requestTimeout))
 	e.Use(httpmiddleware.RequestLogger(log))
-
 	healthRepo := mysql.NewHealthRepository(db)
 	healthUsecase := health.New(healthRepo)
 	clockProvider := clock.NewSystemClock()
-	userRepo := memory.NewUserRepository()
-	verificationRepo := memory.NewVerificationTokenRepository()
+	userRepo := mysql.NewUserRepository(db)
+	verificationRepo := mysql.NewVerificationTokenRepository(db)
 	mailer := email.NewLogMailer(log)
-	txManager := transaction.NewNoopManager()
-	tokenIssuer := authinfra.NewUUIDTokenIssuer()
+	txManager := transaction.NewSQLManager(db)
+	tokenIssuer := authinfra.NewSessionTokenIssuer(mysql.NewSessionRepository(db), clockProvider, authSessionTTL)
+
+	e.Use(httpmiddleware.Authenticate(tokenIssuer))
 
 	registerConfig := auth.RegisterConfig{
 		VerificationURLBase: getEnv("VERIFICATION_URL_BASE", "http://localhost:5173/auth/verify"),
@@ -83,10 +108,76 @@ func main() {
 
 	registerUsecase := auth.NewRegisterUsecase(userRepo, verificationRepo, mailer, clockProvider, registerConfig)
 	verifyUsecase := auth.NewVerifyUsecase(userRepo, verificationRepo, txManager, clockProvider, tokenIssuer)
-	apiHandler := handler.NewHandler(healthUsecase, registerUsecase, verifyUsecase)
+	seedCatalog, err := skillseed.Catalog()
+	if err != nil {
+		log.Error("failed to load skill seed", "error", err)
+		os.Exit(1)
+	}
+	skillCatalogRepo := mysql.NewSkillCatalogRepository(db)
+	var seeded bool
+	if err := txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		seeded, err = skillCatalogRepo.Seed(ctx, seedCatalog)
+		return err
+	}); err != nil {
+		log.Error("failed to seed skill catalog", "error", err)
+		os.Exit(1)
+	}
+	if seeded {
+		log.Info("seeded skill catalog", "skills", len(seedCatalog.Skills()))
+	}
+	skillUsageRepo := mysql.NewSkillUsageRepository(db)
+	gitImportUsecase := gitimport.New(skillCatalogRepo, skillUsageRepo, userRepo, txManager, clockProvider)
+	skillCatalogUsecase := skillcatalog.New(skillCatalogRepo, skillUsageRepo, txManager, clockProvider)
+	publicURLRepo := mysql.NewPublicURLRepository(db)
+	slugRedirectPeriod, err := getDurationEnv("PUBLIC_URL_SLUG_REDIRECT_PERIOD", defaultSlugRedirect)
+	if err != nil {
+		log.Error("invalid PUBLIC_URL_SLUG_REDIRECT_PERIOD", "error", err)
+		os.Exit(1)
+	}
+	publicURLConfig := publicurl.Config{
+		PublisherBase:      getEnv("PUBLIC_URL_BASE", "http://localhost:5174/cv"),
+		ReminderLead:       publicURLReminderLead,
+		SlugRedirectPeriod: slugRedirectPeriod,
+	}
+	viewerSecret, err := secretFromEnv(log, "VIEWER_TOKEN_SECRET")
+	if err != nil {
+		log.Error("failed to prepare viewer token secret", "error", err)
+		os.Exit(1)
+	}
+	viewerTokens, err := authinfra.NewViewerTokenSigner(viewerSecret, clockProvider, viewerPassTTL)
+	if err != nil {
+		log.Error("failed to create viewer token signer", "error", err)
+		os.Exit(1)
+	}
+	passphraseAttempts := ratelimit.NewAttemptLimiter(mysql.NewRateLimitRepository(db), clockProvider, passphraseAttemptLimit, passphraseAttemptWindow)
+	visitorSecret, err := secretFromEnv(log, "VISITOR_HASH_SECRET")
+	if err != nil {
+		log.Error("failed to prepare visitor hash secret", "error", err)
+		os.Exit(1)
+	}
+	visitorHasher, err := analytics.NewVisitorHasher(visitorSecret)
+	if err != nil {
+		log.Error("failed to create visitor hasher", "error", err)
+		os.Exit(1)
+	}
+	geoDB := &geoip.Database{}
+	if path := os.Getenv("GEOIP_DATABASE_PATH"); path != "" {
+		if geoDB, err = geoip.Open(path); err != nil {
+			log.Error("failed to load GeoIP database", "error", err)
+			os.Exit(1)
+		}
+	}
+	qrCodes := qrcode.NewRenderer(qrCodeCacheSize)
+	publicURLUsecase := publicurl.New(
+		publicURLRepo, txManager, clockProvider, mailer, viewerTokens, passphraseAttempts, visitorHasher, geoDB, qrCodes,
+		publicURLConfig,
+	)
+	go runPublicURLSweeps(ctx, log, publicURLUsecase)
+
+	apiHandler := handler.NewHandler(healthUsecase, registerUsecase, verifyUsecase, gitImportUsecase, skillCatalogUsecase, publicURLUsecase)
 
-	apiGroup := e.Group("/techcv/api/v1")
-	apiHandler.Register(apiGroup)
+	apiGroup := e.Group(apiBasePath)
+	apiHandler.Register(apiGroup, httpmiddleware.AdminAuth(os.Getenv("ADMIN_API_TOKEN")))
 
 	srv := server.New(e, log)
 
@@ -99,9 +190,96 @@ func main() {
 	}
 }
 
+// runPublicURLSweeps records the start and expiry of public URLs' viewing windows and sweeps
+// for ones nearing expiry or viewed for the first time until ctx is cancelled.
+func runPublicURLSweeps(ctx context.Context, log *slog.Logger, uc *publicurl.Usecase) {
+	ticker := time.NewTicker(sweepInterval)
+	defer ticker.Stop()
+
+	for {
+		recorded, err := uc.RecordStarts(ctx)
+		if err != nil {
+			log.Error("failed to record public URL starts", "error", err)
+		} else if recorded > 0 {
+			log.Info("recorded public URL starts", "count", recorded)
+		}
+
+		recorded, err = uc.RecordExpiries(ctx)
+		if err != nil {
+			log.Error("failed to record public URL expiries", "error", err)
+		} else if recorded > 0 {
+			log.Info("recorded public URL expiries", "count", recorded)
+		}
+
+		sent, err := uc.SendExpiryReminders(ctx)
+		if err != nil {
+			log.Error("failed to send public URL expiry reminders", "error", err)
+		} else if sent > 0 {
+			log.Info("sent public URL expiry reminders", "count", sent)
+		}
+
+		sent, err = uc.SendFirstViewNotifications(ctx)
+		if err != nil {
+			log.Error("failed to send public URL first view notifications", "error", err)
+		} else if sent > 0 {
+			log.Info("sent public URL first view notifications", "count", sent)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// secretFromEnv returns the key held in the environment variable. In local development a
+// missing key is replaced by a random one; anywhere else it is an error, because a random
+// key does not survive a restart and is not shared between instances.
+func secretFromEnv(log *slog.Logger, key string) ([]byte, error) {
+	if secret := os.Getenv(key); secret != "" {
+		return []byte(secret), nil
+	}
+	if !isLocalDevelopment() {
+		return nil, fmt.Errorf("%s must be set outside local development", key)
+	}
+
+	log.Warn(key + " is not set; using a random key")
+	secret := make([]byte, randomSecretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
+
+// isLocalDevelopment reports whether APP_ENV marks a developer machine.
+func isLocalDevelopment() bool {
+	switch strings.ToLower(os.Getenv("APP_ENV")) {
+	case "development", "dev":
+		return true
+	default:
+		return false
+	}
+}
+
 func getEnv(key, fallback string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
 	}
 	return fallback
 }
+
+func getDurationEnv(key string, fallback time.Duration) (time.Duration, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("%s must not be negative: %s", key, value)
+	}
+	return d, nil
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/sky0621/techcv/manager/backend/internal/domain/gitstats"
+	"github.com/sky0621/techcv/manager/backend/internal/infrastructure/persistence/memory"
+	"github.com/sky0621/techcv/manager/backend/internal/infrastructure/skillseed"
+	"github.com/sky0621/techcv/manager/backend/internal/usecase/gitimport"
+)
+
+const usage = `usage: importer <command> [flags]
+
+commands:
+  git    derive per-technology activity from a git repository or a git log --numstat dump`
+
+type authorList []string
+
+func (a *authorList) String() string {
+	return strings.Join(*a, ",")
+}
+
+func (a *authorList) Set(value string) error {
+	for _, email := range strings.Split(value, ",") {
+		if trimmed := strings.TrimSpace(email); trimmed != "" {
+			*a = append(*a, trimmed)
+		}
+	}
+	return nil
+}
+
+type technologyActivity struct {
+	Technology   string    `json:"technology"`
+	FirstUsedAt  time.Time `json:"first_used_at"`
+	LastUsedAt   time.Time `json:"last_used_at"`
+	Commits      int       `json:"commits"`
+	LinesAdded   int       `json:"lines_added"`
+	LinesDeleted int       `json:"lines_deleted"`
+}
+
+type gitReport struct {
+	TotalCommits      int                  `json:"total_commits"`
+	AttributedCommits int                  `json:"attributed_commits"`
+	Technologies      []technologyActivity `json:"technologies"`
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		fatalf("%s", usage)
+	}
+
+	ctx := context.Background()
+
+	switch os.Args[1] {
+	case "git":
+		if err := runGit(ctx, os.Args[2:]); err != nil {
+			fatalf("git: %v", err)
+		}
+	default:
+		fatalf("unknown command %q\n\n%s", os.Args[1], usage)
+	}
+}
+
+func runGit(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("git", flag.ExitOnError)
+	repoPath := fs.String("repo", "", "path to a local git repository")
+	numstatPath := fs.String("numstat", "", "path to a `git log --numstat` dump (use - for stdin)")
+	var authors authorList
+	fs.Var(&authors, "author", "author email to attribute commits to (repeatable or comma separated)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if (*repoPath == "") == (*numstatPath == "") {
+		return fmt.Errorf("exactly one of -repo or -numstat must be provided")
+	}
+
+	catalog, err := skillseed.Catalog()
+	if err != nil {
+		return err
+	}
+
+	input, wait, err := openNumstat(ctx, *repoPath, *numstatPath)
+	if err != nil {
+		return err
+	}
+
+	report, err := gitimport.New(memory.NewSkillCatalogRepository(catalog), nil, nil, nil, nil).Analyze(ctx, gitimport.AnalyzeInput{
+		Numstat:      input,
+		AuthorEmails: authors,
+	})
+	if waitErr := wait(); waitErr != nil && err == nil {
+		err = waitErr
+	}
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(toGitReport(report))
+}
+
+// openNumstat returns a reader over numstat output and a function that releases it.
+func openNumstat(ctx context.Context, repoPath, numstatPath string) (io.Reader, func() error, error) {
+	if numstatPath == "-" {
+		return os.Stdin, func() error { return nil }, nil
+	}
+	if numstatPath != "" {
+		file, err := os.Open(numstatPath) // #nosec G304 -- path supplied by the operator running the CLI
+		if err != nil {
+			return nil, nil, fmt.Errorf("open numstat dump: %w", err)
+		}
+		return file, file.Close, nil
+	}
+
+	cmd := exec.CommandContext(ctx, "git", "-C", repoPath, "log", "--numstat", "--no-merges", "--date=iso-strict") // #nosec G204 -- fixed git arguments
+	cmd.Stderr = os.Stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, nil, fmt.Errorf("pipe git log output: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, nil, fmt.Errorf("start git log: %w", err)
+	}
+	return stdout, func() error {
+		// Drain whatever the parser did not consume so git can exit.
+		_, _ = io.Copy(io.Discard, stdout)
+		if err := cmd.Wait(); err != nil {
+			return fmt.Errorf("git log: %w", err)
+		}
+		return nil
+	}, nil
+}
+
+func toGitReport(report gitstats.Report) gitReport {
+	technologies := make([]technologyActivity, 0, len(report.Technologies))
+	for _, t := range report.Technologies {
+		technologies = append(technologies, technologyActivity{
+			Technology:   t.Technology,
+			FirstUsedAt:  t.FirstUsedAt,
+			LastUsedAt:   t.LastUsedAt,
+			Commits:      t.Commits,
+			LinesAdded:   t.LinesAdded,
+			LinesDeleted: t.LinesDeleted,
+		})
+	}
+	return gitReport{
+		TotalCommits:      report.TotalCommits,
+		AttributedCommits: report.AttributedCommits,
+		Technologies:      technologies,
+	}
+}
+
+func fatalf(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+	os.Exit(1)
+}
@@ -67,10 +67,11 @@ type pathItem struct {
 }
 
 type operation struct {
-	OperationID string               `json:"operationId"`
-	Summary     string               `json:"summary"`
-	Description string               `json:"description"`
-	Responses   map[string]*response `json:"responses"`
+	OperationID string                `json:"operationId"`
+	Summary     string                `json:"summary"`
+	Description string                `json:"description"`
+	Security    []map[string][]string `json:"security"`
+	Responses   map[string]*response  `json:"responses"`
 }
 
 type response struct {
@@ -389,6 +390,7 @@ func (g *generator) generateInterface() {
 		Method      string
 		Path        string
 		OperationID string
+		Security    []string
 	}
 
 	var endpoints []endpoint
@@ -402,19 +404,19 @@ func (g *generator) generateInterface() {
 	for _, path := range pathKeys {
 		item := g.doc.Paths[path]
 		if item.Get != nil {
-			endpoints = append(endpoints, endpoint{Method: "GET", Path: path, OperationID: g.operationID("Get", path, item.Get)})
+			endpoints = append(endpoints, endpoint{Method: "GET", Path: path, OperationID: g.operationID("Get", path, item.Get), Security: securitySchemes(item.Get)})
 		}
 		if item.Post != nil {
-			endpoints = append(endpoints, endpoint{Method: "POST", Path: path, OperationID: g.operationID("Post", path, item.Post)})
+			endpoints = append(endpoints, endpoint{Method: "POST", Path: path, OperationID: g.operationID("Post", path, item.Post), Security: securitySchemes(item.Post)})
 		}
 		if item.Put != nil {
-			endpoints = append(endpoints, endpoint{Method: "PUT", Path: path, OperationID: g.operationID("Put", path, item.Put)})
+			endpoints = append(endpoints, endpoint{Method: "PUT", Path: path, OperationID: g.operationID("Put", path, item.Put), Security: securitySchemes(item.Put)})
 		}
 		if item.Patch != nil {
-			endpoints = append(endpoints, endpoint{Method: "PATCH", Path: path, OperationID: g.operationID("Patch", path, item.Patch)})
+			endpoints = append(endpoints, endpoint{Method: "PATCH", Path: path, OperationID: g.operationID("Patch", path, item.Patch), Security: securitySchemes(item.Patch)})
 		}
 		if item.Delete != nil {
-			endpoints = append(endpoints, endpoint{Method: "DELETE", Path: path, OperationID: g.operationID("Delete", path, item.Delete)})
+			endpoints = append(endpoints, endpoint{Method: "DELETE", Path: path, OperationID: g.operationID("Delete", path, item.Delete), Security: securitySchemes(item.Delete)})
 		}
 	}
 
@@ -445,20 +447,75 @@ func (g *generator) generateInterface() {
 	}
 	g.interfaceBuf.WriteString("}\n\n")
 
-	g.interfaceBuf.WriteString("func RegisterHandlers(g *echo.Group, si ServerInterface) {\n")
+	g.interfaceBuf.WriteString("// SecurityMiddlewares maps each security scheme of the spec to the middleware enforcing it.\n")
+	g.interfaceBuf.WriteString("type SecurityMiddlewares map[string]echo.MiddlewareFunc\n\n")
+	g.interfaceBuf.WriteString("// RegisterHandlers registers the routes on g, each behind the middlewares of its security\n")
+	g.interfaceBuf.WriteString("// schemes. It panics when a scheme has no middleware, so that no route is left open.\n")
+	g.interfaceBuf.WriteString("func RegisterHandlers(g *echo.Group, si ServerInterface, security SecurityMiddlewares) {\n")
 	g.interfaceBuf.WriteString("\tif g == nil {\n")
 	g.interfaceBuf.WriteString("\t\tpanic(\"nil echo group\")\n")
 	g.interfaceBuf.WriteString("\t}\n")
 	g.interfaceBuf.WriteString("\tif si == nil {\n")
 	g.interfaceBuf.WriteString("\t\tpanic(\"nil server implementation\")\n")
-	g.interfaceBuf.WriteString("\t}\n\n")
+	g.interfaceBuf.WriteString("\t}\n")
+	secure := false
+	for _, ep := range endpoints {
+		secure = secure || len(ep.Security) > 0
+	}
+	if secure {
+		g.writeSecured()
+	}
+	g.interfaceBuf.WriteString("\n")
 	for _, ep := range endpoints {
 		echoMethod := strings.ToUpper(ep.Method)
-		g.interfaceBuf.WriteString(fmt.Sprintf("\tg.%s(\"%s\", si.%s)\n", echoMethod, toEchoPath(ep.Path), ep.OperationID))
+		route := fmt.Sprintf("\tg.%s(\"%s\", si.%s", echoMethod, toEchoPath(ep.Path), ep.OperationID)
+		if len(ep.Security) > 0 {
+			schemes := make([]string, 0, len(ep.Security))
+			for _, scheme := range ep.Security {
+				schemes = append(schemes, fmt.Sprintf("%q", scheme))
+			}
+			route += fmt.Sprintf(", secured(%s)...", strings.Join(schemes, ", "))
+		}
+		g.interfaceBuf.WriteString(route + ")\n")
 	}
 	g.interfaceBuf.WriteString("}\n")
 }
 
+// writeSecured writes the closure RegisterHandlers uses to look up the middlewares of a
+// route's security schemes.
+func (g *generator) writeSecured() {
+	g.interfaceBuf.WriteString("\tsecured := func(schemes ...string) []echo.MiddlewareFunc {\n")
+	g.interfaceBuf.WriteString("\t\tmiddlewares := make([]echo.MiddlewareFunc, 0, len(schemes))\n")
+	g.interfaceBuf.WriteString("\t\tfor _, scheme := range schemes {\n")
+	g.interfaceBuf.WriteString("\t\t\tmiddleware, ok := security[scheme]\n")
+	g.interfaceBuf.WriteString("\t\t\tif !ok || middleware == nil {\n")
+	g.interfaceBuf.WriteString("\t\t\t\tpanic(\"no middleware for security scheme \" + scheme)\n")
+	g.interfaceBuf.WriteString("\t\t\t}\n")
+	g.interfaceBuf.WriteString("\t\t\tmiddlewares = append(middlewares, middleware)\n")
+	g.interfaceBuf.WriteString("\t\t}\n")
+	g.interfaceBuf.WriteString("\t\treturn middlewares\n")
+	g.interfaceBuf.WriteString("\t}\n")
+}
+
+// securitySchemes returns the security schemes an operation requires, all of which must be
+// satisfied. Alternative requirements are not supported, as they cannot be expressed as a
+// chain of middlewares.
+func securitySchemes(op *operation) []string {
+	switch len(op.Security) {
+	case 0:
+		return nil
+	case 1:
+	default:
+		fatalf("operation %s: alternative security requirements are not supported", op.OperationID)
+	}
+	schemes := make([]string, 0, len(op.Security[0]))
+	for scheme := range op.Security[0] {
+		schemes = append(schemes, scheme)
+	}
+	sort.Strings(schemes)
+	return schemes
+}
+
 func (g *generator) operationID(prefix, path string, op *operation) string {
 	if op.OperationID != "" {
 		return toExported(op.OperationID)
@@ -481,7 +538,7 @@ func toCamel(value string) string {
 
 func toExported(value string) string {
 	delimiters := func(r rune) bool {
-		return r == '_' || r == '-' || r == ' ' || r == '/' || r == ':'
+		return r == '_' || r == '-' || r == ' ' || r == '/' || r == ':' || r == '{' || r == '}'
 	}
 	parts := strings.FieldsFunc(value, delimiters)
 	for i, part := range parts {
@@ -0,0 +1,28 @@
+-- name: CreateEmailVerificationToken :exec
+INSERT INTO email_verification_tokens (
+  id,
+  user_email,
+  token,
+  password_hash,
+  expires_at,
+  created_at
+) VALUES (?, ?, ?, ?, ?, ?);
+
+-- name: GetEmailVerificationTokenByToken :one
+SELECT
+  id,
+  user_email,
+  token,
+  password_hash,
+  expires_at,
+  created_at
+FROM email_verification_tokens
+WHERE token = ?;
+
+-- name: DeleteEmailVerificationTokenByToken :exec
+DELETE FROM email_verification_tokens
+WHERE token = ?;
+
+-- name: DeleteEmailVerificationTokensByEmail :exec
+DELETE FROM email_verification_tokens
+WHERE user_email = ?;
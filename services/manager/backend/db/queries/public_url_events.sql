@@ -0,0 +1,56 @@
+-- name: CreatePublicURLEvent :exec
+INSERT INTO public_url_events (
+  public_url_id,
+  reason,
+  active,
+  actor,
+  actor_user_id,
+  note,
+  occurred_at
+) VALUES (?, ?, ?, ?, ?, ?, ?);
+
+-- name: ListPublicURLEvents :many
+SELECT
+  id,
+  public_url_id,
+  reason,
+  active,
+  actor,
+  actor_user_id,
+  note,
+  occurred_at
+FROM public_url_events
+WHERE public_url_id = ?
+ORDER BY occurred_at, id;
+
+-- name: ListUnrecordedPublicURLExpiries :many
+SELECT
+  p.id,
+  p.expires_at
+FROM public_urls p
+WHERE p.is_active = TRUE
+  AND p.expires_at <= sqlc.arg(now)
+  AND NOT EXISTS (
+    SELECT 1
+    FROM public_url_events e
+    WHERE e.public_url_id = p.id
+      AND e.occurred_at = p.expires_at
+      AND e.reason = 'expired'
+  )
+ORDER BY p.expires_at;
+
+-- name: ListUnrecordedPublicURLStarts :many
+SELECT
+  p.id,
+  p.activates_at
+FROM public_urls p
+WHERE p.is_active = TRUE
+  AND p.activates_at <= sqlc.arg(now)
+  AND NOT EXISTS (
+    SELECT 1
+    FROM public_url_events e
+    WHERE e.public_url_id = p.id
+      AND e.occurred_at >= p.activates_at
+      AND e.reason <> 'expired'
+  )
+ORDER BY p.activates_at;
@@ -0,0 +1,41 @@
+-- name: ClaimPublicURLSlug :exec
+INSERT INTO public_url_slugs (
+  slug,
+  public_url_id
+) VALUES (?, ?);
+
+-- name: GetPublicURLBySlug :one
+SELECT
+  p.id,
+  p.user_id,
+  p.url_key,
+  cur.slug,
+  p.label,
+  p.cv_variant,
+  p.passphrase_hash,
+  p.is_active,
+  p.activates_at,
+  p.expires_at,
+  p.notify_first_view,
+  p.first_viewed_at,
+  p.created_at,
+  p.updated_at,
+  s.redirect_until
+FROM public_url_slugs s
+JOIN public_urls p ON p.id = s.public_url_id
+LEFT JOIN public_url_slugs cur
+  ON cur.public_url_id = p.id
+  AND cur.redirect_until IS NULL
+WHERE s.slug = ?
+  AND (s.redirect_until IS NULL OR s.redirect_until > sqlc.arg(now));
+
+-- name: ReleasePublicURLSlug :exec
+DELETE FROM public_url_slugs
+WHERE slug = ?
+  AND (public_url_id = ? OR redirect_until <= sqlc.arg(now));
+
+-- name: RetirePublicURLSlug :exec
+UPDATE public_url_slugs
+SET redirect_until = ?
+WHERE public_url_id = ?
+  AND redirect_until IS NULL;
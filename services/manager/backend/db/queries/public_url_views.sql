@@ -0,0 +1,32 @@
+-- name: CreatePublicURLView :exec
+INSERT INTO public_url_views (
+  public_url_id,
+  viewed_at,
+  referrer_host,
+  user_agent_class,
+  country,
+  visitor_hash
+) VALUES (?, ?, ?, ?, ?, ?);
+
+-- name: ListPublicURLDailyViews :many
+SELECT
+  CAST(viewed_at AS DATE) AS day,
+  COUNT(*) AS views,
+  COUNT(DISTINCT visitor_hash) AS unique_visitors
+FROM public_url_views
+WHERE public_url_id = ?
+  AND viewed_at >= sqlc.arg(since)
+  AND user_agent_class <> 'bot'
+GROUP BY day
+ORDER BY day;
+
+-- name: ListRecentPublicURLViews :many
+SELECT
+  viewed_at,
+  referrer_host,
+  user_agent_class,
+  country
+FROM public_url_views
+WHERE public_url_id = ?
+ORDER BY viewed_at DESC, id DESC
+LIMIT ?;
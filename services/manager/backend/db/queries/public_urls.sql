@@ -1,31 +1,215 @@
 -- name: CreatePublicURL :execresult
-INSERT INTO public_urls (url_key)
-VALUES (?);
+INSERT INTO public_urls (
+  user_id,
+  url_key,
+  label,
+  cv_variant,
+  passphrase_hash,
+  activates_at,
+  expires_at,
+  notify_first_view
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?);
 
--- name: GetActivePublicURL :one
+-- name: GetPublicURL :one
 SELECT
-  id,
-  url_key,
-  is_active,
-  created_at,
-  updated_at
-FROM public_urls
-WHERE is_active = TRUE
-ORDER BY updated_at DESC
-LIMIT 1;
+  p.id,
+  p.user_id,
+  p.url_key,
+  s.slug,
+  p.label,
+  p.cv_variant,
+  p.passphrase_hash,
+  p.is_active,
+  p.activates_at,
+  p.expires_at,
+  p.notify_first_view,
+  p.first_viewed_at,
+  p.created_at,
+  p.updated_at
+FROM public_urls p
+LEFT JOIN public_url_slugs s
+  ON s.public_url_id = p.id
+  AND s.redirect_until IS NULL
+WHERE p.id = ?
+  AND p.user_id = ?;
+
+-- name: GetPublicURLByKey :one
+SELECT
+  p.id,
+  p.user_id,
+  p.url_key,
+  s.slug,
+  p.label,
+  p.cv_variant,
+  p.passphrase_hash,
+  p.is_active,
+  p.activates_at,
+  p.expires_at,
+  p.notify_first_view,
+  p.first_viewed_at,
+  p.created_at,
+  p.updated_at
+FROM public_urls p
+LEFT JOIN public_url_slugs s
+  ON s.public_url_id = p.id
+  AND s.redirect_until IS NULL
+WHERE p.url_key = ?;
 
 -- name: ListPublicURLs :many
 SELECT
-  id,
-  url_key,
-  is_active,
-  created_at,
-  updated_at
-FROM public_urls
-ORDER BY updated_at DESC;
+  p.id,
+  p.user_id,
+  p.url_key,
+  s.slug,
+  p.label,
+  p.cv_variant,
+  p.passphrase_hash,
+  p.is_active,
+  p.activates_at,
+  p.expires_at,
+  p.notify_first_view,
+  p.first_viewed_at,
+  p.created_at,
+  p.updated_at
+FROM public_urls p
+LEFT JOIN public_url_slugs s
+  ON s.public_url_id = p.id
+  AND s.redirect_until IS NULL
+WHERE p.user_id = ?
+ORDER BY p.updated_at DESC;
+
+-- name: LockPublicURLOwner :one
+SELECT id
+FROM users
+WHERE id = ?
+FOR UPDATE;
+
+-- name: SetPublicURLActive :exec
+UPDATE public_urls
+SET is_active = ?,
+    updated_at = CURRENT_TIMESTAMP(6)
+WHERE id = ?
+  AND user_id = ?;
 
--- name: DeactivatePublicURL :exec
+-- name: DeactivateUserPublicURLs :exec
 UPDATE public_urls
 SET is_active = FALSE,
     updated_at = CURRENT_TIMESTAMP(6)
+WHERE user_id = ?
+  AND is_active = TRUE;
+
+-- name: UpdatePublicURLDetails :exec
+UPDATE public_urls
+SET label = ?,
+    cv_variant = ?,
+    notify_first_view = ?,
+    updated_at = CURRENT_TIMESTAMP(6)
+WHERE id = ?
+  AND user_id = ?;
+
+-- name: SetPublicURLPassphrase :exec
+UPDATE public_urls
+SET passphrase_hash = ?,
+    updated_at = CURRENT_TIMESTAMP(6)
+WHERE id = ?
+  AND user_id = ?;
+
+-- name: SetPublicURLSchedule :exec
+UPDATE public_urls
+SET activates_at = ?,
+    expires_at = ?,
+    expiry_reminded_at = NULL,
+    updated_at = CURRENT_TIMESTAMP(6)
+WHERE id = ?
+  AND user_id = ?;
+
+-- name: ListExpiringPublicURLs :many
+SELECT
+  p.id,
+  p.user_id,
+  p.url_key,
+  s.slug,
+  p.label,
+  p.cv_variant,
+  p.passphrase_hash,
+  p.is_active,
+  p.activates_at,
+  p.expires_at,
+  p.notify_first_view,
+  p.first_viewed_at,
+  p.created_at,
+  p.updated_at,
+  u.email
+FROM public_urls p
+JOIN users u ON u.id = p.user_id
+LEFT JOIN public_url_slugs s
+  ON s.public_url_id = p.id
+  AND s.redirect_until IS NULL
+WHERE p.is_active = TRUE
+  AND p.expiry_reminded_at IS NULL
+  AND p.expires_at > sqlc.arg(now)
+  AND p.expires_at <= sqlc.arg(until)
+  AND u.deleted_at IS NULL
+ORDER BY p.expires_at;
+
+-- name: ClaimPublicURLExpiryReminder :execrows
+UPDATE public_urls
+SET expiry_reminded_at = ?,
+    updated_at = updated_at
+WHERE id = ?
+  AND expires_at = ?
+  AND expiry_reminded_at IS NULL;
+
+-- name: ReleasePublicURLExpiryReminder :exec
+UPDATE public_urls
+SET expiry_reminded_at = NULL,
+    updated_at = updated_at
+WHERE id = ?;
+
+-- name: MarkPublicURLFirstViewed :exec
+UPDATE public_urls
+SET first_viewed_at = ?,
+    updated_at = updated_at
+WHERE id = ?
+  AND first_viewed_at IS NULL;
+
+-- name: ListFirstViewedPublicURLs :many
+SELECT
+  p.id,
+  p.user_id,
+  p.url_key,
+  s.slug,
+  p.label,
+  p.cv_variant,
+  p.passphrase_hash,
+  p.is_active,
+  p.activates_at,
+  p.expires_at,
+  p.notify_first_view,
+  p.first_viewed_at,
+  p.created_at,
+  p.updated_at,
+  u.email
+FROM public_urls p
+JOIN users u ON u.id = p.user_id
+LEFT JOIN public_url_slugs s
+  ON s.public_url_id = p.id
+  AND s.redirect_until IS NULL
+WHERE p.notify_first_view = TRUE
+  AND p.first_viewed_at IS NOT NULL
+  AND p.first_view_notified_at IS NULL
+  AND u.deleted_at IS NULL
+ORDER BY p.first_viewed_at;
+
+-- name: ClaimPublicURLFirstViewNotification :execrows
+UPDATE public_urls
+SET first_view_notified_at = ?,
+    updated_at = updated_at
+WHERE id = ?
+  AND first_view_notified_at IS NULL;
+
+-- name: ReleasePublicURLFirstViewNotification :exec
+UPDATE public_urls
+SET first_view_notified_at = NULL,
+    updated_at = updated_at
 WHERE id = ?;
@@ -0,0 +1,18 @@
+-- name: HitRateLimitWindow :exec
+INSERT INTO rate_limit_windows (
+  limiter_key,
+  attempts,
+  window_ends_at
+) VALUES (sqlc.arg(limiter_key), 1, sqlc.arg(next_window_ends_at))
+ON DUPLICATE KEY UPDATE
+  attempts = IF(window_ends_at <= sqlc.arg(now), 1, attempts + 1),
+  window_ends_at = IF(window_ends_at <= sqlc.arg(now), VALUES(window_ends_at), window_ends_at);
+
+-- name: GetRateLimitAttempts :one
+SELECT attempts
+FROM rate_limit_windows
+WHERE limiter_key = ?;
+
+-- name: DeleteExpiredRateLimitWindows :exec
+DELETE FROM rate_limit_windows
+WHERE window_ends_at <= ?;
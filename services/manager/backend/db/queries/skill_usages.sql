@@ -0,0 +1,30 @@
+-- name: ListSkillUsageCounts :many
+SELECT
+  skill_id,
+  COUNT(*) AS users
+FROM skill_usages
+GROUP BY skill_id;
+
+-- name: CreateSkillUsage :exec
+INSERT IGNORE INTO skill_usages (
+  skill_id,
+  user_id,
+  first_used_at
+) VALUES (?, ?, ?);
+
+-- name: CopySkillUsages :exec
+INSERT IGNORE INTO skill_usages (
+  skill_id,
+  user_id,
+  first_used_at
+)
+SELECT
+  ?,
+  su.user_id,
+  su.first_used_at
+FROM skill_usages AS su
+WHERE su.skill_id = ?;
+
+-- name: DeleteSkillUsages :exec
+DELETE FROM skill_usages
+WHERE skill_id = ?;
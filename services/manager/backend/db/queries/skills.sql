@@ -0,0 +1,64 @@
+-- name: CreateSkillCatalogState :exec
+INSERT INTO skill_catalog_state (
+  id,
+  revision
+) VALUES (1, 0);
+
+-- name: GetSkillCatalogRevision :one
+SELECT revision
+FROM skill_catalog_state
+WHERE id = 1;
+
+-- name: LockSkillCatalog :one
+SELECT revision
+FROM skill_catalog_state
+WHERE id = 1
+FOR UPDATE;
+
+-- name: IncrementSkillCatalogRevision :exec
+UPDATE skill_catalog_state
+SET revision = revision + 1
+WHERE id = 1;
+
+-- name: ListSkills :many
+SELECT
+  id,
+  name,
+  category,
+  parent_id
+FROM skills
+ORDER BY id;
+
+-- name: ListSkillAliases :many
+SELECT
+  skill_id,
+  alias
+FROM skill_aliases
+ORDER BY skill_id, position;
+
+-- name: UpsertSkill :exec
+INSERT INTO skills (
+  id,
+  name,
+  category,
+  parent_id
+) VALUES (?, ?, ?, ?)
+ON DUPLICATE KEY UPDATE
+  name = VALUES(name),
+  category = VALUES(category),
+  parent_id = VALUES(parent_id);
+
+-- name: DeleteSkill :exec
+DELETE FROM skills
+WHERE id = ?;
+
+-- name: CreateSkillAlias :exec
+INSERT INTO skill_aliases (
+  skill_id,
+  position,
+  alias
+) VALUES (?, ?, ?);
+
+-- name: DeleteSkillAliases :exec
+DELETE FROM skill_aliases
+WHERE skill_id = ?;
@@ -0,0 +1,17 @@
+-- name: CreateUserSession :exec
+INSERT INTO user_sessions (
+  token_hash,
+  user_id,
+  expires_at,
+  created_at
+) VALUES (?, ?, ?, ?);
+
+-- name: GetUserSessionUserID :one
+SELECT user_id
+FROM user_sessions
+WHERE token_hash = ?
+  AND expires_at > ?;
+
+-- name: DeleteExpiredUserSessions :exec
+DELETE FROM user_sessions
+WHERE expires_at <= ?;
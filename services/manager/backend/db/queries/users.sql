@@ -0,0 +1,60 @@
+-- name: ExistsUserByEmail :one
+SELECT EXISTS(
+  SELECT 1
+  FROM users
+  WHERE email = ?
+    AND deleted_at IS NULL
+) AS user_exists;
+
+-- name: CreateUser :exec
+INSERT INTO users (
+  id,
+  email,
+  password_hash,
+  name,
+  bio,
+  is_active,
+  email_verified_at,
+  last_login_at,
+  created_at,
+  updated_at
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: GetUserByEmail :one
+SELECT
+  id,
+  email,
+  password_hash,
+  name,
+  bio,
+  is_active,
+  email_verified_at,
+  last_login_at,
+  created_at,
+  updated_at
+FROM users
+WHERE email = ?
+  AND deleted_at IS NULL;
+
+-- name: GetUserEmailByID :one
+SELECT email
+FROM users
+WHERE id = ?
+  AND deleted_at IS NULL;
+
+-- name: ListUserGitAuthorEmails :many
+SELECT email
+FROM user_git_author_emails
+WHERE user_id = ?
+ORDER BY created_at, email;
+
+-- name: DeleteUserGitAuthorEmails :exec
+DELETE FROM user_git_author_emails
+WHERE user_id = ?;
+
+-- name: CreateUserGitAuthorEmail :exec
+INSERT INTO user_git_author_emails (
+  user_id,
+  email,
+  created_at
+) VALUES (?, ?, ?);
@@ -1,9 +1,186 @@
+CREATE TABLE users (
+  id BINARY(16) NOT NULL COMMENT 'ユーザーID（UUID v7）',
+  email VARCHAR(255) NOT NULL COMMENT 'メールアドレス',
+  password_hash VARCHAR(255) COMMENT 'パスワードハッシュ（ソーシャルログインの場合はNULL）',
+  google_id VARCHAR(255) COMMENT 'GoogleユーザーID（sub）',
+  name VARCHAR(100) COMMENT 'ユーザー名',
+  profile_image VARCHAR(500) COMMENT 'プロフィール画像URL',
+  bio TEXT COMMENT '自己紹介',
+  is_active TINYINT(1) NOT NULL DEFAULT 1 COMMENT 'アクティブ状態',
+  email_verified_at DATETIME(6) COMMENT 'メール確認日時',
+  last_login_at DATETIME(6) COMMENT '最終ログイン日時',
+  created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6) COMMENT '作成日時',
+  updated_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6) ON UPDATE CURRENT_TIMESTAMP(6) COMMENT '更新日時',
+  deleted_at DATETIME(6) COMMENT '削除日時',
+  PRIMARY KEY (id),
+  UNIQUE KEY uq_users_email (email),
+  UNIQUE KEY uq_users_google_id (google_id),
+  KEY idx_users_deleted_at (deleted_at),
+  KEY idx_users_created_at (created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='ユーザー情報';
+
+CREATE TABLE user_git_author_emails (
+  user_id BINARY(16) NOT NULL COMMENT 'ユーザーID',
+  email VARCHAR(255) NOT NULL COMMENT 'コミットに使うメールアドレス（アカウントのメールアドレス以外）',
+  created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6) COMMENT '作成日時',
+  PRIMARY KEY (user_id, email),
+  CONSTRAINT fk_user_git_author_emails_user_id FOREIGN KEY (user_id) REFERENCES users (id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='gitの作成者メールアドレス';
+
+CREATE TABLE email_verification_tokens (
+  id BINARY(16) NOT NULL COMMENT 'トークンID（UUID v7）',
+  user_email VARCHAR(255) NOT NULL COMMENT '確認対象のメールアドレス',
+  token VARCHAR(255) NOT NULL COMMENT '確認トークン（UUID v7）',
+  password_hash VARCHAR(255) NOT NULL COMMENT '登録時に入力されたパスワードのハッシュ',
+  expires_at DATETIME(6) NOT NULL COMMENT '有効期限',
+  created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6) COMMENT '作成日時',
+  PRIMARY KEY (id),
+  UNIQUE KEY uq_email_verification_tokens_token (token),
+  KEY idx_email_verification_tokens_user_email (user_email),
+  KEY idx_email_verification_tokens_expires_at (expires_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='メール確認トークン';
+
+CREATE TABLE user_sessions (
+  token_hash BINARY(32) NOT NULL COMMENT 'セッショントークンのSHA-256ハッシュ',
+  user_id BINARY(16) NOT NULL COMMENT 'ユーザーID',
+  expires_at DATETIME(6) NOT NULL COMMENT '有効期限',
+  created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6) COMMENT '作成日時',
+  PRIMARY KEY (token_hash),
+  KEY idx_user_sessions_user_id (user_id),
+  KEY idx_user_sessions_expires_at (expires_at),
+  CONSTRAINT fk_user_sessions_user_id FOREIGN KEY (user_id) REFERENCES users (id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='ログインセッション';
+
 CREATE TABLE public_urls (
   id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+  user_id BINARY(16) NOT NULL,
   url_key VARCHAR(64) NOT NULL,
+  -- Who the link was shared with, e.g. a company or an agent.
+  label VARCHAR(100) NOT NULL DEFAULT '',
+  -- CV variant shown through the link; NULL shows the default CV.
+  cv_variant VARCHAR(64),
+  -- bcrypt hash of the passphrase viewers must enter; NULL leaves the link open.
+  passphrase_hash VARCHAR(255),
   is_active TINYINT(1) NOT NULL DEFAULT 1,
+  -- Optional viewing window; NULL leaves that side open.
+  activates_at DATETIME(6),
+  expires_at DATETIME(6),
+  -- Set once the owner has been reminded of the current expires_at.
+  expiry_reminded_at DATETIME(6),
+  -- Whether the owner is emailed when the link is first viewed.
+  notify_first_view TINYINT(1) NOT NULL DEFAULT 0,
+  -- Time of the first view by a visitor that is not a bot.
+  first_viewed_at DATETIME(6),
+  -- Set once the owner has been emailed about the first view.
+  first_view_notified_at DATETIME(6),
+  created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+  updated_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6) ON UPDATE CURRENT_TIMESTAMP(6),
+  PRIMARY KEY (id),
+  UNIQUE KEY idx_public_urls_url_key (url_key),
+  KEY idx_public_urls_user_id_updated_at (user_id, updated_at),
+  KEY idx_public_urls_expires_at (expires_at),
+  CONSTRAINT fk_public_urls_user_id FOREIGN KEY (user_id) REFERENCES users (id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE public_url_slugs (
+  -- Lowercase vanity slug of a public URL.
+  slug VARCHAR(30) NOT NULL,
+  public_url_id BIGINT UNSIGNED NOT NULL,
+  -- NULL for the link's current slug. A replaced slug keeps redirecting to the link, and
+  -- stays reserved for it, until this time.
+  redirect_until DATETIME(6),
+  created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+  PRIMARY KEY (slug),
+  KEY idx_public_url_slugs_public_url_id (public_url_id),
+  CONSTRAINT fk_public_url_slugs_public_url_id FOREIGN KEY (public_url_id) REFERENCES public_urls (id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE public_url_views (
+  id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+  public_url_id BIGINT UNSIGNED NOT NULL,
+  viewed_at DATETIME(6) NOT NULL,
+  -- Host of the referring page only; paths and queries may identify the viewer.
+  referrer_host VARCHAR(255),
+  -- desktop, mobile, tablet, bot or other.
+  user_agent_class VARCHAR(16) NOT NULL,
+  -- ISO 3166-1 alpha-2 country of the viewer's IP address; NULL when unknown.
+  country CHAR(2),
+  -- Keyed hash of the viewer's IP address and user agent. The key changes daily, so the hash
+  -- only tells visitors apart within a day and raw IP addresses are never stored.
+  visitor_hash BINARY(32) NOT NULL,
+  PRIMARY KEY (id),
+  KEY idx_public_url_views_public_url_id_viewed_at (public_url_id, viewed_at),
+  CONSTRAINT fk_public_url_views_public_url_id FOREIGN KEY (public_url_id) REFERENCES public_urls (id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE public_url_events (
+  id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+  public_url_id BIGINT UNSIGNED NOT NULL,
+  -- created, activated, revoked, regenerated, rescheduled, started, expired, admin_takedown
+  -- or takedown_lifted.
+  reason VARCHAR(32) NOT NULL,
+  -- Whether the link could be viewed right after the event.
+  active BOOLEAN NOT NULL,
+  -- owner, admin or system.
+  actor VARCHAR(16) NOT NULL,
+  -- The user who acted as the owner; NULL for admins and the system.
+  actor_user_id BINARY(16),
+  -- Explanation given by an admin for a takedown.
+  note VARCHAR(255),
+  occurred_at DATETIME(6) NOT NULL,
+  PRIMARY KEY (id),
+  -- Also stops instances sweeping at the same time from recording an expiry twice.
+  UNIQUE KEY idx_public_url_events_public_url_id_occurred_at_reason (public_url_id, occurred_at, reason),
+  CONSTRAINT fk_public_url_events_public_url_id FOREIGN KEY (public_url_id) REFERENCES public_urls (id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE skill_catalog_state (
+  -- Always 1; the single row is locked to serialize catalog changes across instances.
+  id TINYINT UNSIGNED NOT NULL,
+  -- Incremented on every change so instances can tell that their copy of the catalog is stale.
+  revision BIGINT UNSIGNED NOT NULL DEFAULT 0,
+  PRIMARY KEY (id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE skills (
+  id BINARY(16) NOT NULL,
+  -- Canonical display name.
+  name VARCHAR(100) NOT NULL,
+  category VARCHAR(32) NOT NULL,
+  parent_id BINARY(16),
   created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
   updated_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6) ON UPDATE CURRENT_TIMESTAMP(6),
   PRIMARY KEY (id),
-  UNIQUE KEY idx_public_urls_url_key (url_key)
+  KEY idx_skills_parent_id (parent_id),
+  CONSTRAINT fk_skills_parent_id FOREIGN KEY (parent_id) REFERENCES skills (id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE skill_aliases (
+  skill_id BINARY(16) NOT NULL,
+  -- Order of the alias among the skill's aliases, from 0.
+  position INT UNSIGNED NOT NULL,
+  alias VARCHAR(100) NOT NULL,
+  PRIMARY KEY (skill_id, position),
+  CONSTRAINT fk_skill_aliases_skill_id FOREIGN KEY (skill_id) REFERENCES skills (id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE skill_usages (
+  skill_id BINARY(16) NOT NULL,
+  -- Each user counts once per skill however often they import.
+  user_id BINARY(16) NOT NULL,
+  first_used_at DATETIME(6) NOT NULL,
+  PRIMARY KEY (skill_id, user_id),
+  KEY idx_skill_usages_user_id (user_id),
+  CONSTRAINT fk_skill_usages_skill_id FOREIGN KEY (skill_id) REFERENCES skills (id),
+  CONSTRAINT fk_skill_usages_user_id FOREIGN KEY (user_id) REFERENCES users (id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE rate_limit_windows (
+  -- What is limited, e.g. passphrase attempts on one link from one client address.
+  limiter_key VARCHAR(255) NOT NULL,
+  attempts INT UNSIGNED NOT NULL,
+  -- End of the current fixed window; the first attempt after it starts a new one.
+  window_ends_at DATETIME(6) NOT NULL,
+  PRIMARY KEY (limiter_key),
+  KEY idx_rate_limit_windows_window_ends_at (window_ends_at)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -25,4 +25,27 @@ const (
 	ErrorCodeVerificationURLError     = "VERIFICATION_URL_ERROR"
 	ErrorCodeVerificationURLMissing   = "VERIFICATION_URL_MISSING"
 	ErrorCodeEmailSendFailed          = "EMAIL_SEND_FAILED"
+	ErrorCodeInvalidNumstat           = "INVALID_NUMSTAT"
+	ErrorCodeAuthorEmailRequired      = "AUTHOR_EMAIL_REQUIRED"
+	ErrorCodeTooManyAuthorEmails      = "TOO_MANY_AUTHOR_EMAILS"
+	ErrorCodeAuthorEmailFetchFailed   = "AUTHOR_EMAIL_FETCH_FAILED"
+	ErrorCodeAuthorEmailSaveFailed    = "AUTHOR_EMAIL_SAVE_FAILED"
+	ErrorCodeInvalidSkillName         = "INVALID_SKILL_NAME"
+	ErrorCodeInvalidSkillCategory     = "INVALID_SKILL_CATEGORY"
+	ErrorCodeInvalidSkillCatalog      = "INVALID_SKILL_CATALOG"
+	ErrorCodeSkillAliasConflict       = "SKILL_ALIAS_CONFLICT"
+	ErrorCodeSkillParentNotFound      = "SKILL_PARENT_NOT_FOUND"
+	ErrorCodeSkillParentCycle         = "SKILL_PARENT_CYCLE"
+	ErrorCodeSkillMergeSelf           = "SKILL_MERGE_SELF"
+	ErrorCodeSkillNotFound            = "SKILL_NOT_FOUND"
+	ErrorCodeSkillCatalogLoadFailed   = "SKILL_CATALOG_LOAD_FAILED"
+	ErrorCodeSkillCatalogSaveFailed   = "SKILL_CATALOG_SAVE_FAILED"
+	ErrorCodeSkillUsageFetchFailed    = "SKILL_USAGE_FETCH_FAILED"
+	ErrorCodeSkillUsageUpdateFailed   = "SKILL_USAGE_UPDATE_FAILED"
+	ErrorCodeInvalidSuggestLimit      = "INVALID_SUGGEST_LIMIT"
+	ErrorCodeInvalidExperiencePeriod  = "INVALID_EXPERIENCE_PERIOD"
+	ErrorCodeAdminUnauthorized        = "ADMIN_UNAUTHORIZED"
+	ErrorCodeUnauthenticated          = "UNAUTHENTICATED"
+	ErrorCodeSessionLookupFailed      = "SESSION_LOOKUP_FAILED"
+	ErrorCodeInvalidPublicURLID       = "INVALID_PUBLIC_URL_ID"
 )
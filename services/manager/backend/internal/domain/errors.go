@@ -67,6 +67,24 @@ func NewValidation(code, message string) *AppError {
 	}
 }
 
+// NewUnauthorized returns a new unauthorized error.
+func NewUnauthorized(code, message string) *AppError {
+	return &AppError{
+		Code:       code,
+		Message:    message,
+		StatusCode: http.StatusUnauthorized,
+	}
+}
+
+// NewTooManyRequests returns a new error for callers that exceeded a rate limit.
+func NewTooManyRequests(code, message string) *AppError {
+	return &AppError{
+		Code:       code,
+		Message:    message,
+		StatusCode: http.StatusTooManyRequests,
+	}
+}
+
 // NewInternal returns a new internal server error.
 func NewInternal(code, message string, err error) *AppError {
 	return &AppError{
@@ -0,0 +1,211 @@
+// Package experience computes years of experience from dated work history and projects.
+package experience
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sky0621/techcv/manager/backend/internal/domain"
+	"github.com/sky0621/techcv/manager/backend/internal/domain/skill"
+)
+
+// Clock abstracts the source of current time; it decides how far "current" entries reach.
+type Clock interface {
+	Now() time.Time
+}
+
+// SourceKind tells what a dated entry of a CV represents.
+type SourceKind string
+
+// Supported source kinds.
+const (
+	SourceWork    SourceKind = "work"
+	SourceProject SourceKind = "project"
+)
+
+// Source is a dated entry contributing experience. A nil EndedOn marks an ongoing entry.
+type Source struct {
+	ID        string
+	Kind      SourceKind
+	Title     string
+	StartedOn time.Time
+	EndedOn   *time.Time
+	Skills    []string
+}
+
+// Contribution is how a single source counts before overlaps are merged.
+type Contribution struct {
+	SourceID string
+	Kind     SourceKind
+	Title    string
+	Start    Month
+	End      Month
+	Current  bool
+	// Months is zero for entries that start after the current month.
+	Months int
+}
+
+// Interval is a run of consecutive months covered by one or more sources.
+type Interval struct {
+	Start     Month
+	End       Month
+	Months    int
+	SourceIDs []string
+}
+
+// Breakdown explains a computed duration: the merged intervals that make it up and the
+// months dropped because sources overlapped.
+type Breakdown struct {
+	// Skill is the skill name as first written, or empty for the overall breakdown.
+	Skill         string
+	Months        int
+	OverlapMonths int
+	Intervals     []Interval
+	Contributions []Contribution
+}
+
+// Years returns the duration in years rounded to one decimal place.
+func (b Breakdown) Years() float64 {
+	const precision = 10
+	return math.Round(float64(b.Months)/monthsPerYear*precision) / precision
+}
+
+// Summary is the overall experience and the experience per skill, longest first.
+type Summary struct {
+	Overall Breakdown
+	Skills  []Breakdown
+}
+
+// Calculator merges overlapping periods so concurrent jobs and projects are counted once.
+type Calculator struct {
+	clock    Clock
+	location *time.Location
+}
+
+// NewCalculator constructs a calculator that resolves ongoing entries with the clock. The
+// current month and the months of source dates are all read in location, so an entry
+// starting today counts from this month wherever the dates were created.
+func NewCalculator(clock Clock, location *time.Location) *Calculator {
+	return &Calculator{clock: clock, location: location}
+}
+
+// Calculate computes the overall experience across every source and the experience per
+// skill across the sources mentioning it. Skill names are expected to be catalog names;
+// they are grouped after normalization so case and width differences do not split them.
+func (c *Calculator) Calculate(sources []Source) (Summary, error) {
+	current := c.monthOf(c.clock.Now())
+
+	overall := make([]Contribution, 0, len(sources))
+	bySkill := make(map[string][]Contribution)
+	var skillNames []string
+	labels := make(map[string]string)
+
+	for i, src := range sources {
+		contribution, err := c.contributionOf(i, src, current)
+		if err != nil {
+			return Summary{}, err
+		}
+		overall = append(overall, contribution)
+
+		seen := make(map[string]struct{})
+		for _, name := range src.Skills {
+			key := skill.Normalize(name)
+			if key == "" {
+				continue
+			}
+			if _, dup := seen[key]; dup {
+				continue
+			}
+			seen[key] = struct{}{}
+			if _, known := labels[key]; !known {
+				labels[key] = strings.TrimSpace(name)
+				skillNames = append(skillNames, key)
+			}
+			bySkill[key] = append(bySkill[key], contribution)
+		}
+	}
+
+	summary := Summary{Overall: breakdownOf("", overall)}
+	summary.Skills = make([]Breakdown, 0, len(skillNames))
+	for _, key := range skillNames {
+		summary.Skills = append(summary.Skills, breakdownOf(labels[key], bySkill[key]))
+	}
+	sort.SliceStable(summary.Skills, func(i, j int) bool {
+		return summary.Skills[i].Months > summary.Skills[j].Months
+	})
+
+	return summary, nil
+}
+
+func (c *Calculator) contributionOf(index int, src Source, current Month) (Contribution, error) {
+	start := c.monthOf(src.StartedOn)
+	end := current
+	if src.EndedOn != nil {
+		end = c.monthOf(*src.EndedOn)
+		if end < start {
+			detail := domain.ErrorDetail{
+				Field:   fmt.Sprintf("sources[%d].ended_on", index),
+				Code:    domain.ErrorCodeInvalidExperiencePeriod,
+				Message: "終了月は開始月以降を指定してください",
+			}
+			return Contribution{}, domain.NewValidation(domain.ErrorCodeInvalidExperiencePeriod, "期間の指定が正しくありません").WithDetails(detail)
+		}
+	}
+
+	contribution := Contribution{
+		SourceID: src.ID,
+		Kind:     src.Kind,
+		Title:    src.Title,
+		Start:    start,
+		End:      end,
+		Current:  src.EndedOn == nil,
+	}
+	// Months that have not happened yet do not count as experience.
+	if counted := min(end, current); counted >= start {
+		contribution.Months = int(counted-start) + 1
+	}
+	return contribution, nil
+}
+
+func (c *Calculator) monthOf(t time.Time) Month {
+	return MonthOf(t.In(c.location))
+}
+
+func breakdownOf(label string, contributions []Contribution) Breakdown {
+	b := Breakdown{Skill: label, Contributions: contributions}
+
+	counted := make([]Contribution, 0, len(contributions))
+	raw := 0
+	for _, c := range contributions {
+		if c.Months > 0 {
+			counted = append(counted, c)
+			raw += c.Months
+		}
+	}
+	sort.SliceStable(counted, func(i, j int) bool {
+		return counted[i].Start < counted[j].Start
+	})
+
+	for _, c := range counted {
+		end := c.Start + Month(c.Months-1)
+		last := len(b.Intervals) - 1
+		// Adjacent months join the same interval so a job change reads as one career run.
+		if last >= 0 && c.Start <= b.Intervals[last].End+1 {
+			interval := &b.Intervals[last]
+			interval.End = max(interval.End, end)
+			interval.SourceIDs = append(interval.SourceIDs, c.SourceID)
+			continue
+		}
+		b.Intervals = append(b.Intervals, Interval{Start: c.Start, End: end, SourceIDs: []string{c.SourceID}})
+	}
+
+	for i := range b.Intervals {
+		b.Intervals[i].Months = int(b.Intervals[i].End-b.Intervals[i].Start) + 1
+		b.Months += b.Intervals[i].Months
+	}
+	b.OverlapMonths = raw - b.Months
+	return b
+}
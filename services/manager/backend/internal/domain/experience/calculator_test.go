@@ -0,0 +1,136 @@
+package experience
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sky0621/techcv/manager/backend/internal/domain"
+)
+
+type fixedClock struct {
+	now time.Time
+}
+
+func (c fixedClock) Now() time.Time {
+	return c.now
+}
+
+func date(year int, month time.Month) time.Time {
+	return time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+}
+
+func ptr(t time.Time) *time.Time {
+	return &t
+}
+
+func TestCalculateMergesOverlaps(t *testing.T) {
+	calculator := NewCalculator(fixedClock{now: date(2024, time.June).Add(15 * 24 * time.Hour)}, time.UTC)
+
+	summary, err := calculator.Calculate([]Source{
+		{ID: "job1", Kind: SourceWork, Title: "A社", StartedOn: date(2018, time.April), EndedOn: ptr(date(2021, time.March)), Skills: []string{"Go", "MySQL"}},
+		// A side job overlapping the first job by a year.
+		{ID: "side", Kind: SourceWork, Title: "副業", StartedOn: date(2020, time.April), EndedOn: ptr(date(2022, time.March)), Skills: []string{"ＧＯ"}},
+		{ID: "job2", Kind: SourceWork, Title: "B社", StartedOn: date(2021, time.April), Skills: []string{"Go", "TypeScript"}},
+		{ID: "oss", Kind: SourceProject, Title: "OSS", StartedOn: date(2015, time.January), EndedOn: ptr(date(2015, time.June)), Skills: []string{"Go言語"}},
+	})
+	if err != nil {
+		t.Fatalf("Calculate() error = %v", err)
+	}
+
+	overall := summary.Overall
+	// 2015-01..2015-06 (6) + 2018-04..2024-06 (75).
+	if overall.Months != 81 || overall.OverlapMonths != 24 {
+		t.Fatalf("unexpected overall months: %d (overlap %d)", overall.Months, overall.OverlapMonths)
+	}
+	if len(overall.Intervals) != 2 {
+		t.Fatalf("unexpected intervals: %+v", overall.Intervals)
+	}
+	career := overall.Intervals[1]
+	if career.Start.String() != "2018-04" || career.End.String() != "2024-06" || len(career.SourceIDs) != 3 {
+		t.Fatalf("unexpected career interval: %+v", career)
+	}
+	if overall.Years() != 6.8 {
+		t.Fatalf("unexpected overall years: %v", overall.Years())
+	}
+
+	golang := summary.Skills[0]
+	if golang.Skill != "Go" || golang.Months != 81 || len(golang.Contributions) != 4 {
+		t.Fatalf("expected Go and its spellings to be grouped, got %+v", golang)
+	}
+
+	var current Contribution
+	for _, c := range golang.Contributions {
+		if c.SourceID == "job2" {
+			current = c
+		}
+	}
+	if !current.Current || current.End.String() != "2024-06" || current.Months != 39 {
+		t.Fatalf("unexpected current contribution: %+v", current)
+	}
+
+	names := make([]string, 0, len(summary.Skills))
+	for _, s := range summary.Skills {
+		names = append(names, s.Skill)
+	}
+	if len(names) != 3 || names[1] != "TypeScript" || names[2] != "MySQL" {
+		t.Fatalf("unexpected skill order: %v", names)
+	}
+}
+
+func TestCalculateIgnoresFutureMonths(t *testing.T) {
+	calculator := NewCalculator(fixedClock{now: date(2024, time.June)}, time.UTC)
+
+	summary, err := calculator.Calculate([]Source{
+		{ID: "planned", Kind: SourceProject, StartedOn: date(2024, time.September)},
+		{ID: "contract", Kind: SourceWork, StartedOn: date(2024, time.January), EndedOn: ptr(date(2024, time.December))},
+	})
+	if err != nil {
+		t.Fatalf("Calculate() error = %v", err)
+	}
+
+	if summary.Overall.Months != 6 || summary.Overall.OverlapMonths != 0 {
+		t.Fatalf("unexpected months: %+v", summary.Overall)
+	}
+	if len(summary.Overall.Contributions) != 2 || summary.Overall.Contributions[0].Months != 0 {
+		t.Fatalf("expected planned entry to be listed without months: %+v", summary.Overall.Contributions)
+	}
+}
+
+func TestCalculateRejectsInvertedPeriod(t *testing.T) {
+	calculator := NewCalculator(fixedClock{now: date(2024, time.June)}, time.UTC)
+
+	_, err := calculator.Calculate([]Source{
+		{ID: "ok", StartedOn: date(2020, time.January), EndedOn: ptr(date(2020, time.January))},
+		{ID: "bad", StartedOn: date(2022, time.May), EndedOn: ptr(date(2022, time.April))},
+	})
+
+	var appErr *domain.AppError
+	if !errors.As(err, &appErr) || appErr.Code != domain.ErrorCodeInvalidExperiencePeriod {
+		t.Fatalf("expected invalid period error, got %v", err)
+	}
+	if len(appErr.Details) != 1 || appErr.Details[0].Field != "sources[1].ended_on" {
+		t.Fatalf("unexpected details: %+v", appErr.Details)
+	}
+}
+
+func TestCalculateReadsMonthsInOneLocation(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*60*60)
+	// Already June in Tokyo, still May in UTC.
+	calculator := NewCalculator(fixedClock{now: time.Date(2024, time.May, 31, 16, 0, 0, 0, time.UTC)}, tokyo)
+
+	summary, err := calculator.Calculate([]Source{
+		{ID: "local", Kind: SourceWork, Title: "A社", StartedOn: time.Date(2024, time.June, 1, 0, 0, 0, 0, tokyo)},
+		// The same start as an instant in UTC.
+		{ID: "utc", Kind: SourceProject, Title: "OSS", StartedOn: time.Date(2024, time.May, 31, 15, 0, 0, 0, time.UTC)},
+	})
+	if err != nil {
+		t.Fatalf("Calculate() error = %v", err)
+	}
+
+	for _, c := range summary.Overall.Contributions {
+		if c.Start.String() != "2024-06" || c.End.String() != "2024-06" || c.Months != 1 {
+			t.Fatalf("expected %s to count June, got %+v", c.SourceID, c)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package experience
+
+import (
+	"fmt"
+	"time"
+)
+
+const monthsPerYear = 12
+
+// Month identifies a calendar month. Experience is counted in whole months, inclusive of
+// both the first and the last month of a period.
+type Month int
+
+// MonthOf returns the calendar month containing t, in t's location.
+func MonthOf(t time.Time) Month {
+	return Month(t.Year()*monthsPerYear + int(t.Month()) - 1)
+}
+
+// Year returns the calendar year of the month.
+func (m Month) Year() int {
+	return int(m) / monthsPerYear
+}
+
+// Month returns the month of the year.
+func (m Month) Month() time.Month {
+	return time.Month(int(m)%monthsPerYear + 1)
+}
+
+// String formats the month as YYYY-MM.
+func (m Month) String() string {
+	return fmt.Sprintf("%04d-%02d", m.Year(), int(m.Month()))
+}
@@ -0,0 +1,96 @@
+package gitstats
+
+import (
+	"path"
+	"sort"
+	"strings"
+)
+
+// languagesByExtension maps lower-cased file extensions to technology names.
+var languagesByExtension = map[string]string{
+	".go":      "Go",
+	".ts":      "TypeScript",
+	".tsx":     "TypeScript",
+	".mts":     "TypeScript",
+	".cts":     "TypeScript",
+	".js":      "JavaScript",
+	".jsx":     "JavaScript",
+	".mjs":     "JavaScript",
+	".cjs":     "JavaScript",
+	".py":      "Python",
+	".rb":      "Ruby",
+	".java":    "Java",
+	".kt":      "Kotlin",
+	".kts":     "Kotlin",
+	".scala":   "Scala",
+	".rs":      "Rust",
+	".php":     "PHP",
+	".c":       "C",
+	".h":       "C",
+	".cc":      "C++",
+	".cpp":     "C++",
+	".cxx":     "C++",
+	".hpp":     "C++",
+	".cs":      "C#",
+	".swift":   "Swift",
+	".m":       "Objective-C",
+	".dart":    "Dart",
+	".ex":      "Elixir",
+	".exs":     "Elixir",
+	".erl":     "Erlang",
+	".hs":      "Haskell",
+	".lua":     "Lua",
+	".pl":      "Perl",
+	".r":       "R",
+	".sh":      "Shell",
+	".bash":    "Shell",
+	".zsh":     "Shell",
+	".sql":     "SQL",
+	".html":    "HTML",
+	".css":     "CSS",
+	".scss":    "Sass",
+	".sass":    "Sass",
+	".vue":     "Vue",
+	".svelte":  "Svelte",
+	".tf":      "Terraform",
+	".proto":   "Protocol Buffers",
+	".graphql": "GraphQL",
+	".gql":     "GraphQL",
+}
+
+// languagesByFilename maps well-known extensionless file names to technology names.
+var languagesByFilename = map[string]string{
+	"dockerfile":  "Docker",
+	"makefile":    "Make",
+	"gnumakefile": "Make",
+}
+
+// LanguageForPath infers the technology of a file from its name or extension.
+func LanguageForPath(filePath string) (string, bool) {
+	base := strings.ToLower(path.Base(filePath))
+	if language, ok := languagesByFilename[base]; ok {
+		return language, true
+	}
+	if strings.HasPrefix(base, "dockerfile.") || strings.HasSuffix(base, ".dockerfile") {
+		return languagesByFilename["dockerfile"], true
+	}
+	language, ok := languagesByExtension[path.Ext(base)]
+	return language, ok
+}
+
+// Languages returns every technology name LanguageForPath can infer, sorted.
+func Languages() []string {
+	seen := make(map[string]struct{})
+	for _, language := range languagesByExtension {
+		seen[language] = struct{}{}
+	}
+	for _, language := range languagesByFilename {
+		seen[language] = struct{}{}
+	}
+	result := make([]string, 0, len(seen))
+	for language := range seen {
+		result = append(result, language)
+	}
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,193 @@
+// Package gitstats derives per-technology activity from git history.
+package gitstats
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sky0621/techcv/manager/backend/internal/domain"
+)
+
+const (
+	invalidNumstatMessage = "git log --numstat の形式が正しくありません"
+	maxNumstatLineBytes   = 1024 * 1024
+	numstatFieldCount     = 3
+	renameSeparator       = " => "
+)
+
+// dateLayouts covers the default, --date=iso and --date=iso-strict formats of git log.
+var dateLayouts = []string{
+	"Mon Jan 2 15:04:05 2006 -0700",
+	"2006-01-02 15:04:05 -0700",
+	time.RFC3339,
+}
+
+// Commit is a single commit read from git log output.
+type Commit struct {
+	Hash        string
+	AuthorEmail string
+	AuthoredAt  time.Time
+	Files       []FileChange
+}
+
+// FileChange is a numstat line of a commit. Binary files have zero line counts.
+type FileChange struct {
+	Path    string
+	Added   int
+	Deleted int
+}
+
+// ParseNumstat reads the output of `git log --numstat` and returns the commits it contains.
+func ParseNumstat(r io.Reader) ([]Commit, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxNumstatLineBytes)
+
+	p := &numstatParser{}
+	for scanner.Scan() {
+		p.lineNo++
+		if err := p.parseLine(scanner.Text()); err != nil {
+			return nil, err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, invalidNumstat(p.lineNo, err.Error())
+	}
+	if err := p.flush(); err != nil {
+		return nil, err
+	}
+
+	return p.commits, nil
+}
+
+type numstatParser struct {
+	commits []Commit
+	current *Commit
+	lineNo  int
+}
+
+func (p *numstatParser) parseLine(line string) error {
+	switch {
+	case strings.HasPrefix(line, "commit "):
+		if err := p.flush(); err != nil {
+			return err
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			return invalidNumstat(p.lineNo, fmt.Sprintf("%d行目のコミットハッシュがありません", p.lineNo))
+		}
+		p.current = &Commit{Hash: fields[1]}
+	case strings.TrimSpace(line) == "" || strings.HasPrefix(line, "    "):
+		// Blank separators and indented commit messages.
+	case p.current == nil:
+		return invalidNumstat(p.lineNo, fmt.Sprintf("%d行目がコミットの外にあります", p.lineNo))
+	case strings.HasPrefix(line, "Author:"):
+		email, ok := parseAuthorEmail(line)
+		if !ok {
+			return invalidNumstat(p.lineNo, fmt.Sprintf("%d行目の作成者を解析できません", p.lineNo))
+		}
+		p.current.AuthorEmail = email
+	case strings.HasPrefix(line, "Date:"):
+		authoredAt, ok := parseDate(strings.TrimSpace(strings.TrimPrefix(line, "Date:")))
+		if !ok {
+			return invalidNumstat(p.lineNo, fmt.Sprintf("%d行目の日時を解析できません", p.lineNo))
+		}
+		p.current.AuthoredAt = authoredAt
+	default:
+		// Other header lines such as "Merge:" carry nothing we need.
+		if change, ok := parseFileChange(line); ok {
+			p.current.Files = append(p.current.Files, change)
+		}
+	}
+	return nil
+}
+
+func (p *numstatParser) flush() error {
+	if p.current == nil {
+		return nil
+	}
+	if p.current.AuthorEmail == "" || p.current.AuthoredAt.IsZero() {
+		return invalidNumstat(p.lineNo, fmt.Sprintf("コミット %s の作成者または日時がありません", p.current.Hash))
+	}
+	p.commits = append(p.commits, *p.current)
+	p.current = nil
+	return nil
+}
+
+func invalidNumstat(lineNo int, message string) *domain.AppError {
+	detail := domain.ErrorDetail{Field: "numstat", Code: domain.ErrorCodeInvalidNumstat, Message: message}
+	appErr := domain.NewValidation(domain.ErrorCodeInvalidNumstat, invalidNumstatMessage).WithDetails(detail)
+	appErr.Err = fmt.Errorf("parse numstat line %d: %s", lineNo, message)
+	return appErr
+}
+
+func parseAuthorEmail(line string) (string, bool) {
+	start := strings.LastIndex(line, "<")
+	end := strings.LastIndex(line, ">")
+	if start < 0 || end <= start+1 {
+		return "", false
+	}
+	return strings.ToLower(strings.TrimSpace(line[start+1 : end])), true
+}
+
+func parseDate(value string) (time.Time, bool) {
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t.UTC(), true
+		}
+	}
+	return time.Time{}, false
+}
+
+func parseFileChange(line string) (FileChange, bool) {
+	fields := strings.SplitN(line, "\t", numstatFieldCount)
+	if len(fields) != numstatFieldCount {
+		return FileChange{}, false
+	}
+
+	added, ok := parseLineCount(fields[0])
+	if !ok {
+		return FileChange{}, false
+	}
+	deleted, ok := parseLineCount(fields[1])
+	if !ok {
+		return FileChange{}, false
+	}
+
+	return FileChange{
+		Path:    renamedPath(fields[2]),
+		Added:   added,
+		Deleted: deleted,
+	}, true
+}
+
+func parseLineCount(value string) (int, bool) {
+	if value == "-" {
+		return 0, true
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return 0, false
+	}
+	return n, true
+}
+
+// renamedPath resolves the destination of "old => new" and "dir/{old => new}/file" notations.
+func renamedPath(path string) string {
+	open := strings.Index(path, "{")
+	closing := strings.Index(path, "}")
+	if open >= 0 && closing > open {
+		inner := path[open+1 : closing]
+		if i := strings.Index(inner, renameSeparator); i >= 0 {
+			joined := path[:open] + inner[i+len(renameSeparator):] + path[closing+1:]
+			return strings.ReplaceAll(joined, "//", "/")
+		}
+	}
+	if i := strings.Index(path, renameSeparator); i >= 0 {
+		return path[i+len(renameSeparator):]
+	}
+	return path
+}
@@ -0,0 +1,131 @@
+package gitstats
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sky0621/techcv/manager/backend/internal/domain"
+)
+
+const sampleNumstat = `commit 1f2e3d4c5b6a79880f1e2d3c4b5a69788f1e2d3c (HEAD -> main)
+Author: Taro Yamada <Taro@example.com>
+Date:   Mon Jan 2 15:04:05 2023 +0900
+
+    Add handler
+
+    Longer description.
+
+12	3	internal/handler.go
+-	-	docs/diagram.png
+4	0	web/{src => app}/index.tsx
+
+commit 0a1b2c3d4e5f60718293a4b5c6d7e8f90a1b2c3d
+Merge: 1111111 2222222
+Author: Hanako <hanako@example.com>
+Date:   2022-12-31T23:00:00Z
+
+    Merge branch
+
+1	1	old.py => new.py
+`
+
+func TestParseNumstat(t *testing.T) {
+	commits, err := ParseNumstat(strings.NewReader(sampleNumstat))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(commits) != 2 {
+		t.Fatalf("unexpected commit count: got %d, want 2", len(commits))
+	}
+
+	first := commits[0]
+	if first.Hash != "1f2e3d4c5b6a79880f1e2d3c4b5a69788f1e2d3c" {
+		t.Fatalf("unexpected hash: %s", first.Hash)
+	}
+	if first.AuthorEmail != "taro@example.com" {
+		t.Fatalf("expected normalized author email, got %s", first.AuthorEmail)
+	}
+	wantDate := time.Date(2023, time.January, 2, 6, 4, 5, 0, time.UTC)
+	if !first.AuthoredAt.Equal(wantDate) || first.AuthoredAt.Location() != time.UTC {
+		t.Fatalf("unexpected authoredAt: %v", first.AuthoredAt)
+	}
+
+	wantFiles := []FileChange{
+		{Path: "internal/handler.go", Added: 12, Deleted: 3},
+		{Path: "docs/diagram.png"},
+		{Path: "web/app/index.tsx", Added: 4},
+	}
+	if len(first.Files) != len(wantFiles) {
+		t.Fatalf("unexpected files: %+v", first.Files)
+	}
+	for i, want := range wantFiles {
+		if first.Files[i] != want {
+			t.Fatalf("unexpected file at %d: got %+v, want %+v", i, first.Files[i], want)
+		}
+	}
+
+	second := commits[1]
+	if len(second.Files) != 1 || second.Files[0].Path != "new.py" {
+		t.Fatalf("expected rename to resolve to new path, got %+v", second.Files)
+	}
+}
+
+func TestParseNumstatErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{
+			name:  "content before commit",
+			input: "1\t2\tmain.go\n",
+		},
+		{
+			name:  "missing date",
+			input: "commit abc\nAuthor: A <a@example.com>\n\n1\t2\tmain.go\n",
+		},
+		{
+			name:  "unparseable date",
+			input: "commit abc\nAuthor: A <a@example.com>\nDate:   yesterday\n",
+		},
+		{
+			name:  "author without email",
+			input: "commit abc\nAuthor: A\nDate:   2023-01-01T00:00:00Z\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseNumstat(strings.NewReader(tt.input))
+			if err == nil {
+				t.Fatalf("expected error, got nil")
+			}
+			var appErr *domain.AppError
+			if !errors.As(err, &appErr) || appErr.Code != domain.ErrorCodeInvalidNumstat {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(appErr.Details) != 1 || appErr.Details[0].Field != "numstat" {
+				t.Fatalf("unexpected details: %+v", appErr.Details)
+			}
+		})
+	}
+}
+
+func TestRenamedPath(t *testing.T) {
+	tests := map[string]string{
+		"main.go":                    "main.go",
+		"a.go => b.go":               "b.go",
+		"src/{old => new}/file.go":   "src/new/file.go",
+		"src/{ => nested}/file.go":   "src/nested/file.go",
+		"src/{nested => }/file.go":   "src/file.go",
+		"{web => app}/src/index.tsx": "app/src/index.tsx",
+	}
+
+	for input, want := range tests {
+		if got := renamedPath(input); got != want {
+			t.Fatalf("renamedPath(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
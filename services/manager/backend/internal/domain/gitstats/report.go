@@ -0,0 +1,103 @@
+package gitstats
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// TechnologyActivity summarizes how an author used a single technology.
+type TechnologyActivity struct {
+	Technology   string
+	FirstUsedAt  time.Time
+	LastUsedAt   time.Time
+	Commits      int
+	LinesAdded   int
+	LinesDeleted int
+	// SuggestedName is the catalog name the technology is probably meant to be, when it only
+	// resembles one; empty otherwise.
+	SuggestedName string
+}
+
+// Report is the result of attributing commits to an author.
+type Report struct {
+	TotalCommits      int
+	AttributedCommits int
+	Technologies      []TechnologyActivity
+}
+
+// Canonicalizer maps an inferred technology name to the name reported for it.
+// Technologies sharing a canonical name are aggregated together.
+type Canonicalizer func(technology string) string
+
+// Analyze attributes commits to the given author emails and aggregates activity per technology.
+// Technologies are ordered by commit count, most active first. canonical may be nil to report
+// inferred names as they are.
+func Analyze(commits []Commit, authorEmails []string, canonical Canonicalizer) Report {
+	if canonical == nil {
+		canonical = func(technology string) string { return technology }
+	}
+
+	authors := make(map[string]struct{}, len(authorEmails))
+	for _, email := range authorEmails {
+		authors[strings.ToLower(strings.TrimSpace(email))] = struct{}{}
+	}
+
+	report := Report{TotalCommits: len(commits)}
+	activities := make(map[string]*TechnologyActivity)
+
+	for _, commit := range commits {
+		if _, ok := authors[commit.AuthorEmail]; !ok {
+			continue
+		}
+		report.AttributedCommits++
+
+		touched := make(map[string]struct{})
+		for _, file := range commit.Files {
+			inferred, ok := LanguageForPath(file.Path)
+			if !ok {
+				continue
+			}
+			language := canonical(inferred)
+			activity, exists := activities[language]
+			if !exists {
+				activity = &TechnologyActivity{
+					Technology:  language,
+					FirstUsedAt: commit.AuthoredAt,
+					LastUsedAt:  commit.AuthoredAt,
+				}
+				activities[language] = activity
+			}
+			activity.LinesAdded += file.Added
+			activity.LinesDeleted += file.Deleted
+			if _, counted := touched[language]; !counted {
+				touched[language] = struct{}{}
+				activity.record(commit.AuthoredAt)
+			}
+		}
+	}
+
+	report.Technologies = make([]TechnologyActivity, 0, len(activities))
+	for _, activity := range activities {
+		report.Technologies = append(report.Technologies, *activity)
+	}
+	sort.Slice(report.Technologies, func(i, j int) bool {
+		a, b := report.Technologies[i], report.Technologies[j]
+		if a.Commits != b.Commits {
+			return a.Commits > b.Commits
+		}
+		return a.Technology < b.Technology
+	})
+
+	return report
+}
+
+func (a *TechnologyActivity) record(at time.Time) {
+	a.Commits++
+	if at.Before(a.FirstUsedAt) {
+		a.FirstUsedAt = at
+	}
+	if at.After(a.LastUsedAt) {
+		a.LastUsedAt = at
+	}
+}
@@ -0,0 +1,123 @@
+package gitstats
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAnalyze(t *testing.T) {
+	jan := time.Date(2021, time.January, 10, 0, 0, 0, 0, time.UTC)
+	jun := time.Date(2022, time.June, 1, 0, 0, 0, 0, time.UTC)
+	dec := time.Date(2023, time.December, 24, 0, 0, 0, 0, time.UTC)
+
+	commits := []Commit{
+		{
+			Hash:        "c1",
+			AuthorEmail: "taro@example.com",
+			AuthoredAt:  jun,
+			Files: []FileChange{
+				{Path: "cmd/api/main.go", Added: 10, Deleted: 2},
+				{Path: "internal/handler.go", Added: 5, Deleted: 1},
+				{Path: "README.md", Added: 3},
+			},
+		},
+		{
+			Hash:        "c2",
+			AuthorEmail: "taro@work.example.com",
+			AuthoredAt:  jan,
+			Files: []FileChange{
+				{Path: "go.sum", Added: 100},
+				{Path: "server.go", Added: 1},
+				{Path: "Dockerfile", Added: 7},
+			},
+		},
+		{
+			Hash:        "c3",
+			AuthorEmail: "someone@example.com",
+			AuthoredAt:  dec,
+			Files:       []FileChange{{Path: "main.go", Added: 50}},
+		},
+	}
+
+	report := Analyze(commits, []string{" Taro@Example.com ", "taro@work.example.com"}, nil)
+
+	if report.TotalCommits != 3 || report.AttributedCommits != 2 {
+		t.Fatalf("unexpected commit counts: %+v", report)
+	}
+
+	if len(report.Technologies) != 2 {
+		t.Fatalf("unexpected technologies: %+v", report.Technologies)
+	}
+
+	golang := report.Technologies[0]
+	if golang.Technology != "Go" {
+		t.Fatalf("expected Go to rank first, got %+v", report.Technologies)
+	}
+	if golang.Commits != 2 {
+		t.Fatalf("expected a commit to count once per technology, got %d", golang.Commits)
+	}
+	if golang.LinesAdded != 16 || golang.LinesDeleted != 3 {
+		t.Fatalf("unexpected line counts: %+v", golang)
+	}
+	if !golang.FirstUsedAt.Equal(jan) || !golang.LastUsedAt.Equal(jun) {
+		t.Fatalf("unexpected usage range: %v - %v", golang.FirstUsedAt, golang.LastUsedAt)
+	}
+
+	if report.Technologies[1].Technology != "Docker" {
+		t.Fatalf("expected Docker from Dockerfile, got %+v", report.Technologies[1])
+	}
+}
+
+func TestAnalyzeCanonicalizesTechnologies(t *testing.T) {
+	at := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	commits := []Commit{
+		{
+			Hash:        "c1",
+			AuthorEmail: "taro@example.com",
+			AuthoredAt:  at,
+			Files: []FileChange{
+				{Path: "app.js", Added: 4},
+				{Path: "app.ts", Added: 6},
+			},
+		},
+	}
+	canonical := func(technology string) string {
+		if technology == "TypeScript" {
+			return "JavaScript"
+		}
+		return technology
+	}
+
+	report := Analyze(commits, []string{"taro@example.com"}, canonical)
+
+	if len(report.Technologies) != 1 {
+		t.Fatalf("expected technologies to merge, got %+v", report.Technologies)
+	}
+	if got := report.Technologies[0]; got.Technology != "JavaScript" || got.Commits != 1 || got.LinesAdded != 10 {
+		t.Fatalf("unexpected merged activity: %+v", got)
+	}
+}
+
+func TestLanguageForPath(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+		ok   bool
+	}{
+		{path: "web/src/App.TSX", want: "TypeScript", ok: true},
+		{path: "deploy/Dockerfile.prod", want: "Docker", ok: true},
+		{path: "Makefile", want: "Make", ok: true},
+		{path: "db/schema.sql", want: "SQL", ok: true},
+		{path: "README.md"},
+		{path: "LICENSE"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, ok := LanguageForPath(tt.path)
+			if got != tt.want || ok != tt.ok {
+				t.Fatalf("LanguageForPath(%q) = (%q, %v), want (%q, %v)", tt.path, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
@@ -1,12 +1,98 @@
 package domain
 
-import "time"
+import (
+	"errors"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	// ErrPublicURLKeyConflict is returned by repositories when a URL key is already taken.
+	ErrPublicURLKeyConflict = errors.New("public URL key already exists")
+	// ErrPublicURLSlugTaken is returned by repositories when a slug is in use or still
+	// redirecting to another URL.
+	ErrPublicURLSlugTaken = errors.New("public URL slug already taken")
+)
 
 // PublicURL represents a sharable URL entry managed by the system.
+// Each user owns their URLs and may keep several active at once, typically one per
+// recruiter, so that each can be revoked on its own.
 type PublicURL struct {
-	ID        uint64    `json:"id"`
-	URLKey    string    `json:"url_key"`
-	IsActive  bool      `json:"is_active"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID     uint64 `json:"id"`
+	UserID string `json:"user_id"`
+	URLKey string `json:"url_key"`
+	// Slug is the optional vanity address chosen by the owner, e.g. "taro-yamada".
+	Slug string `json:"slug"`
+	// Label tells the owner who the link was shared with, e.g. "Company A".
+	Label string `json:"label"`
+	// CVVariant selects the CV shown through the link; empty shows the default CV.
+	CVVariant string `json:"cv_variant"`
+	// PassphraseHash is the bcrypt hash of the passphrase viewers must enter; empty leaves
+	// the link open to anyone who has it.
+	PassphraseHash string `json:"-"`
+	IsActive       bool   `json:"is_active"`
+	// ActivatesAt and ExpiresAt bound when the URL can be viewed; nil leaves that side open.
+	ActivatesAt *time.Time `json:"activates_at"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+	// NotifyFirstView asks for the owner to be emailed when the URL is first viewed.
+	NotifyFirstView bool `json:"notify_first_view"`
+	// FirstViewedAt is when a visitor other than a bot first viewed the URL.
+	FirstViewedAt *time.Time `json:"first_viewed_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// Address returns the path segment the URL is shared under: its slug when set, otherwise its key.
+func (p PublicURL) Address() string {
+	if p.Slug != "" {
+		return p.Slug
+	}
+	return p.URLKey
+}
+
+// LiveAt reports whether the URL is active and inside its viewing window at now.
+func (p PublicURL) LiveAt(now time.Time) bool {
+	if !p.IsActive {
+		return false
+	}
+	if p.ActivatesAt != nil && now.Before(*p.ActivatesAt) {
+		return false
+	}
+	return p.ExpiresAt == nil || now.Before(*p.ExpiresAt)
+}
+
+// Protected reports whether viewers must enter a passphrase.
+func (p PublicURL) Protected() bool {
+	return p.PassphraseHash != ""
+}
+
+// PassphraseMatches reports whether the passphrase unlocks the URL.
+func (p PublicURL) PassphraseMatches(passphrase string) bool {
+	if !p.Protected() {
+		return false
+	}
+	return bcrypt.CompareHashAndPassword([]byte(p.PassphraseHash), []byte(passphrase)) == nil
+}
+
+// HashPublicURLPassphrase returns the bcrypt hash stored for a link passphrase.
+func HashPublicURLPassphrase(passphrase string) (string, error) {
+	hashed, err := bcrypt.GenerateFromPassword([]byte(passphrase), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hashed), nil
+}
+
+// ExpiringPublicURL is an active URL nearing expiry together with its owner's address.
+type ExpiringPublicURL struct {
+	PublicURL
+	OwnerEmail string
+}
+
+// FirstViewedPublicURL is a viewed URL whose owner awaits the first view email, together
+// with the owner's address.
+type FirstViewedPublicURL struct {
+	PublicURL
+	OwnerEmail string
 }
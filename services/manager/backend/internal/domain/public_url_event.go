@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrPublicURLEventRecorded is returned by repositories when the same event of a public URL
+// was already recorded, typically by another instance sweeping for starts and expiries.
+var ErrPublicURLEventRecorded = errors.New("public URL event already recorded")
+
+// PublicURLEventReason tells what changed whether a public URL can be viewed.
+type PublicURLEventReason string
+
+// Reasons recorded with public URL events.
+const (
+	// PublicURLEventCreated is recorded when the owner issues the URL.
+	PublicURLEventCreated PublicURLEventReason = "created"
+	// PublicURLEventActivated is recorded when the owner turns the URL back on.
+	PublicURLEventActivated PublicURLEventReason = "activated"
+	// PublicURLEventRevoked is recorded when the owner turns the URL off.
+	PublicURLEventRevoked PublicURLEventReason = "revoked"
+	// PublicURLEventRegenerated is recorded when the owner issues a new URL replacing this one.
+	PublicURLEventRegenerated PublicURLEventReason = "regenerated"
+	// PublicURLEventRescheduled is recorded when the owner moves the start of an active URL's
+	// viewing window or changes its window so that it can or can no longer be viewed.
+	PublicURLEventRescheduled PublicURLEventReason = "rescheduled"
+	// PublicURLEventStarted is recorded at the ActivatesAt of an active URL, when its viewing
+	// window starts.
+	PublicURLEventStarted PublicURLEventReason = "started"
+	// PublicURLEventExpired is recorded at the expiry of an active URL.
+	PublicURLEventExpired PublicURLEventReason = "expired"
+	// PublicURLEventTakenDown is recorded when an admin turns the URL off.
+	PublicURLEventTakenDown PublicURLEventReason = "admin_takedown"
+	// PublicURLEventTakedownLifted is recorded when an admin lets the owner turn a taken down
+	// URL back on. The URL stays off until the owner does.
+	PublicURLEventTakedownLifted PublicURLEventReason = "takedown_lifted"
+)
+
+// PublicURLActor is who caused a public URL event.
+type PublicURLActor string
+
+// Actors recorded with public URL events.
+const (
+	PublicURLActorOwner  PublicURLActor = "owner"
+	PublicURLActorAdmin  PublicURLActor = "admin"
+	PublicURLActorSystem PublicURLActor = "system"
+)
+
+// PublicURLEvent is a change to an active public URL or to whether it can be viewed. A URL
+// is live at a moment when the latest event at or before it is Active.
+type PublicURLEvent struct {
+	ID          uint64
+	PublicURLID uint64
+	Reason      PublicURLEventReason
+	// Active tells whether the URL could be viewed right after the event.
+	Active bool
+	Actor  PublicURLActor
+	// ActorUserID is the user who acted as the owner; empty for admins and the system.
+	ActorUserID string
+	// Note is the explanation an admin gave for a takedown.
+	Note       string
+	OccurredAt time.Time
+}
@@ -0,0 +1,36 @@
+package domain
+
+import "time"
+
+// UserAgentClass is the coarse kind of client a public URL was viewed with.
+type UserAgentClass string
+
+// User agent classes recorded with public URL views.
+const (
+	UserAgentDesktop UserAgentClass = "desktop"
+	UserAgentMobile  UserAgentClass = "mobile"
+	UserAgentTablet  UserAgentClass = "tablet"
+	UserAgentBot     UserAgentClass = "bot"
+	UserAgentOther   UserAgentClass = "other"
+)
+
+// PublicURLView is one view of a public URL. Only coarse facts about the viewer are kept:
+// neither the IP address nor the full user agent or referrer is stored.
+type PublicURLView struct {
+	PublicURLID uint64
+	ViewedAt    time.Time
+	// ReferrerHost is the host of the referring page; empty when unknown.
+	ReferrerHost   string
+	UserAgentClass UserAgentClass
+	// Country is the ISO 3166-1 alpha-2 code of the viewer's location; empty when unknown.
+	Country string
+	// VisitorHash tells visitors apart within a UTC day without identifying them.
+	VisitorHash []byte
+}
+
+// PublicURLDailyViews counts the views of a public URL on one UTC day. Bots are not counted.
+type PublicURLDailyViews struct {
+	Day            time.Time
+	Views          int
+	UniqueVisitors int
+}
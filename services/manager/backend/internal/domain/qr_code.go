@@ -0,0 +1,32 @@
+package domain
+
+// QRCodeFormat is the image format a QR code is rendered in.
+type QRCodeFormat string
+
+// Supported QR code image formats.
+const (
+	QRCodeFormatPNG QRCodeFormat = "png"
+	QRCodeFormatSVG QRCodeFormat = "svg"
+)
+
+// QRCodeLevel is the error correction level of a QR code. Higher levels survive more damage
+// or covering, such as by a logo, at the cost of a denser code.
+type QRCodeLevel string
+
+// QR code error correction levels with the share of codewords each can restore.
+const (
+	QRCodeLevelLow      QRCodeLevel = "L" // about 7%
+	QRCodeLevelMedium   QRCodeLevel = "M" // about 15%
+	QRCodeLevelQuartile QRCodeLevel = "Q" // about 25%
+	QRCodeLevelHigh     QRCodeLevel = "H" // about 30%
+)
+
+// QRCodeOptions describes how a QR code is rendered.
+type QRCodeOptions struct {
+	Format QRCodeFormat
+	// Size is the width and height of the image in pixels, quiet zone included.
+	Size  int
+	Level QRCodeLevel
+	// Logo places the techcv mark in the center of the code.
+	Logo bool
+}
@@ -0,0 +1,191 @@
+package skill
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/sky0621/techcv/manager/backend/internal/domain"
+)
+
+// Catalog is the aggregate of all managed skills. Every canonical name and alias
+// normalizes to a key owned by exactly one skill, and parent relations form a forest.
+type Catalog struct {
+	skills map[string]Skill
+	index  map[string]string
+}
+
+// NewCatalog builds a catalog and validates alias uniqueness and parent relations.
+func NewCatalog(skills []Skill) (*Catalog, error) {
+	c := &Catalog{
+		skills: make(map[string]Skill, len(skills)),
+		index:  make(map[string]string),
+	}
+
+	for _, s := range skills {
+		if _, exists := c.skills[s.id]; exists {
+			return nil, domain.NewInternal(domain.ErrorCodeInvalidSkillCatalog, "スキルIDが重複しています", fmt.Errorf("duplicate skill id %s", s.id))
+		}
+		if err := c.indexSkill(s); err != nil {
+			return nil, err
+		}
+		c.skills[s.id] = s
+	}
+
+	for _, s := range c.skills {
+		if err := c.validateParent(s); err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+// Skills returns every skill ordered by canonical name.
+func (c *Catalog) Skills() []Skill {
+	result := make([]Skill, 0, len(c.skills))
+	for _, s := range c.skills {
+		result = append(result, s)
+	}
+	sortByName(result)
+	return result
+}
+
+// Get returns the skill with the given identifier.
+func (c *Catalog) Get(id string) (Skill, bool) {
+	s, ok := c.skills[id]
+	return s, ok
+}
+
+// Children returns the direct children of a skill ordered by canonical name.
+func (c *Catalog) Children(id string) []Skill {
+	var result []Skill
+	for _, s := range c.skills {
+		if s.parentID == id {
+			result = append(result, s)
+		}
+	}
+	sortByName(result)
+	return result
+}
+
+// Ancestors returns the parents of a skill, nearest first.
+func (c *Catalog) Ancestors(id string) []Skill {
+	var result []Skill
+	current, ok := c.skills[id]
+	for ok && current.parentID != "" && len(result) < len(c.skills) {
+		current, ok = c.skills[current.parentID]
+		if ok {
+			result = append(result, current)
+		}
+	}
+	return result
+}
+
+// Merge folds the source skill into the target. The source's name and aliases become
+// aliases of the target, its children are re-parented to the target and it is removed.
+func (c *Catalog) Merge(sourceID, targetID string) (Skill, error) {
+	if sourceID == targetID {
+		detail := domain.ErrorDetail{Field: "target_id", Code: domain.ErrorCodeSkillMergeSelf, Message: "統合先には別のスキルを指定してください"}
+		return Skill{}, domain.NewValidation(domain.ErrorCodeSkillMergeSelf, "同じスキル同士は統合できません").WithDetails(detail)
+	}
+	source, ok := c.skills[sourceID]
+	if !ok {
+		return Skill{}, skillNotFound("source_id")
+	}
+	target, ok := c.skills[targetID]
+	if !ok {
+		return Skill{}, skillNotFound("target_id")
+	}
+
+	target.addAliases(source.name)
+	target.addAliases(source.aliases...)
+	if c.isDescendant(target.id, source.id) {
+		// Detach the target first so re-parenting the source's children cannot form a cycle.
+		target.parentID = source.parentID
+	}
+
+	delete(c.skills, source.id)
+	c.skills[target.id] = target
+	for id, s := range c.skills {
+		if s.parentID == source.id {
+			s.parentID = target.id
+			c.skills[id] = s
+		}
+	}
+	c.reindex()
+
+	return target, nil
+}
+
+// Clone returns an independent copy of the catalog.
+func (c *Catalog) Clone() *Catalog {
+	clone := &Catalog{
+		skills: make(map[string]Skill, len(c.skills)),
+		index:  make(map[string]string, len(c.index)),
+	}
+	for id, s := range c.skills {
+		s.aliases = s.Aliases()
+		clone.skills[id] = s
+	}
+	for key, id := range c.index {
+		clone.index[key] = id
+	}
+	return clone
+}
+
+func (c *Catalog) indexSkill(s Skill) error {
+	for _, key := range s.keys() {
+		if owner, exists := c.index[key]; exists && owner != s.id {
+			message := fmt.Sprintf("「%s」は既に「%s」の名前または別名として登録されています", s.name, c.skills[owner].name)
+			detail := domain.ErrorDetail{Field: "aliases", Code: domain.ErrorCodeSkillAliasConflict, Message: message}
+			return domain.NewValidation(domain.ErrorCodeSkillAliasConflict, "スキルの名前または別名が重複しています").WithDetails(detail)
+		}
+		c.index[key] = s.id
+	}
+	return nil
+}
+
+func (c *Catalog) reindex() {
+	c.index = make(map[string]string, len(c.index))
+	for _, s := range c.skills {
+		for _, key := range s.keys() {
+			c.index[key] = s.id
+		}
+	}
+}
+
+func (c *Catalog) validateParent(s Skill) error {
+	if s.parentID == "" {
+		return nil
+	}
+	if _, ok := c.skills[s.parentID]; !ok {
+		detail := domain.ErrorDetail{Field: "parent_id", Code: domain.ErrorCodeSkillParentNotFound, Message: "親スキルが見つかりません"}
+		return domain.NewValidation(domain.ErrorCodeSkillParentNotFound, "親スキルが見つかりません").WithDetails(detail)
+	}
+	if c.isDescendant(s.parentID, s.id) {
+		detail := domain.ErrorDetail{Field: "parent_id", Code: domain.ErrorCodeSkillParentCycle, Message: "親子関係が循環しています"}
+		return domain.NewValidation(domain.ErrorCodeSkillParentCycle, "親子関係が循環しています").WithDetails(detail)
+	}
+	return nil
+}
+
+// isDescendant reports whether id has ancestorID somewhere above it.
+func (c *Catalog) isDescendant(id, ancestorID string) bool {
+	for _, ancestor := range c.Ancestors(id) {
+		if ancestor.id == ancestorID {
+			return true
+		}
+	}
+	return false
+}
+
+func skillNotFound(field string) *domain.AppError {
+	detail := domain.ErrorDetail{Field: field, Code: domain.ErrorCodeSkillNotFound, Message: "スキルが見つかりません"}
+	return domain.NewNotFound(domain.ErrorCodeSkillNotFound, "スキルが見つかりません").WithDetails(detail)
+}
+
+func sortByName(skills []Skill) {
+	sort.Slice(skills, func(i, j int) bool {
+		return Normalize(skills[i].name) < Normalize(skills[j].name)
+	})
+}
@@ -0,0 +1,166 @@
+package skill
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sky0621/techcv/manager/backend/internal/domain"
+)
+
+func mustSkill(t *testing.T, id, name string, category Category, aliases []string, parentID string) Skill {
+	t.Helper()
+	s, err := RestoreSkill(id, name, category, aliases, parentID)
+	if err != nil {
+		t.Fatalf("RestoreSkill(%q) error = %v", name, err)
+	}
+	return s
+}
+
+func newTestCatalog(t *testing.T) *Catalog {
+	t.Helper()
+	catalog, err := NewCatalog([]Skill{
+		mustSkill(t, "go", "Go", CategoryLanguage, []string{"golang", "Go言語"}, ""),
+		mustSkill(t, "echo", "Echo", CategoryFramework, nil, "go"),
+		mustSkill(t, "c", "C", CategoryLanguage, nil, ""),
+		mustSkill(t, "cpp", "C++", CategoryLanguage, []string{"cpp"}, ""),
+		mustSkill(t, "ts", "TypeScript", CategoryLanguage, []string{"TS"}, ""),
+		mustSkill(t, "k8s", "Kubernetes", CategoryInfrastructure, []string{"k8s"}, ""),
+	})
+	if err != nil {
+		t.Fatalf("NewCatalog() error = %v", err)
+	}
+	return catalog
+}
+
+func assertCode(t *testing.T, err error, code string) {
+	t.Helper()
+	var appErr *domain.AppError
+	if !errors.As(err, &appErr) || appErr.Code != code {
+		t.Fatalf("expected %s, got %v", code, err)
+	}
+}
+
+func TestNewCatalogValidation(t *testing.T) {
+	golang := mustSkill(t, "go", "Go", CategoryLanguage, []string{"golang"}, "")
+
+	_, err := NewCatalog([]Skill{golang, mustSkill(t, "golang", "Golang", CategoryLanguage, nil, "")})
+	assertCode(t, err, domain.ErrorCodeSkillAliasConflict)
+
+	_, err = NewCatalog([]Skill{golang, mustSkill(t, "echo", "Echo", CategoryFramework, nil, "missing")})
+	assertCode(t, err, domain.ErrorCodeSkillParentNotFound)
+
+	_, err = NewCatalog([]Skill{
+		mustSkill(t, "a", "A1", CategoryTool, nil, "b"),
+		mustSkill(t, "b", "B1", CategoryTool, nil, "a"),
+	})
+	assertCode(t, err, domain.ErrorCodeSkillParentCycle)
+}
+
+func TestResolve(t *testing.T) {
+	catalog := newTestCatalog(t)
+
+	tests := []struct {
+		input  string
+		wantID string
+		match  MatchKind
+		ok     bool
+	}{
+		{input: "go", wantID: "go", match: MatchCanonical, ok: true},
+		{input: "GOLANG", wantID: "go", match: MatchAlias, ok: true},
+		{input: "Go言語", wantID: "go", match: MatchCanonical, ok: true},
+		{input: "c++", wantID: "cpp", match: MatchCanonical, ok: true},
+		{input: "Typescipt", wantID: "ts", match: MatchFuzzy, ok: true},
+		{input: "Kubernets", wantID: "k8s", match: MatchFuzzy, ok: true},
+		// Short keys never match fuzzily.
+		{input: "co"},
+		{input: "Haskell"},
+		{input: "  "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			res, ok := catalog.Resolve(tt.input)
+			if ok != tt.ok {
+				t.Fatalf("Resolve(%q) ok = %v, want %v", tt.input, ok, tt.ok)
+			}
+			if !ok {
+				return
+			}
+			if res.Skill.ID() != tt.wantID || res.Match != tt.match {
+				t.Fatalf("Resolve(%q) = (%s, %s), want (%s, %s)", tt.input, res.Skill.ID(), res.Match, tt.wantID, tt.match)
+			}
+		})
+	}
+}
+
+func TestMerge(t *testing.T) {
+	catalog := newTestCatalog(t)
+	golangDup := mustSkill(t, "golang-dup", "Go Programming", CategoryLanguage, []string{"gopher"}, "")
+	gin := mustSkill(t, "gin", "Gin", CategoryFramework, nil, "golang-dup")
+	catalog, err := NewCatalog(append(catalog.Skills(), golangDup, gin))
+	if err != nil {
+		t.Fatalf("NewCatalog() error = %v", err)
+	}
+
+	merged, err := catalog.Merge("golang-dup", "go")
+	if err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+
+	if merged.ID() != "go" {
+		t.Fatalf("unexpected merged skill: %s", merged.ID())
+	}
+	if _, ok := catalog.Get("golang-dup"); ok {
+		t.Fatalf("expected source skill to be removed")
+	}
+	if res, ok := catalog.Resolve("gopher"); !ok || res.Skill.ID() != "go" {
+		t.Fatalf("expected source alias to resolve to target, got %+v", res)
+	}
+	if res, ok := catalog.Resolve("Go Programming"); !ok || res.Skill.ID() != "go" || res.Match != MatchAlias {
+		t.Fatalf("expected source name to become an alias, got %+v", res)
+	}
+	if children := catalog.Children("go"); len(children) != 2 || children[0].Name() != "Echo" || children[1].Name() != "Gin" {
+		t.Fatalf("unexpected children after merge: %v", children)
+	}
+}
+
+func TestMergeIntoDescendant(t *testing.T) {
+	catalog := newTestCatalog(t)
+
+	merged, err := catalog.Merge("go", "echo")
+	if err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+	if merged.ParentID() != "" {
+		t.Fatalf("expected target to be detached, got parent %q", merged.ParentID())
+	}
+	if res, ok := catalog.Resolve("golang"); !ok || res.Skill.ID() != "echo" {
+		t.Fatalf("unexpected resolution after merge: %+v", res)
+	}
+}
+
+func TestMergeErrors(t *testing.T) {
+	catalog := newTestCatalog(t)
+
+	_, err := catalog.Merge("go", "go")
+	assertCode(t, err, domain.ErrorCodeSkillMergeSelf)
+
+	_, err = catalog.Merge("missing", "go")
+	assertCode(t, err, domain.ErrorCodeSkillNotFound)
+
+	if len(catalog.Skills()) != 6 {
+		t.Fatalf("failed merges must not modify the catalog")
+	}
+}
+
+func TestCloneIsIndependent(t *testing.T) {
+	catalog := newTestCatalog(t)
+	clone := catalog.Clone()
+
+	if _, err := clone.Merge("c", "cpp"); err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+	if _, ok := catalog.Get("c"); !ok {
+		t.Fatalf("merging a clone must not change the original")
+	}
+}
@@ -0,0 +1,95 @@
+package skill
+
+import "strings"
+
+const (
+	hiraganaStart = 'ぁ'
+	hiraganaEnd   = 'ゖ'
+	// kanaOffset is the distance between a hiragana and its katakana counterpart.
+	kanaOffset = 'ァ' - 'ぁ'
+)
+
+// katakanaRomaji spells katakana in Hepburn romaji. Digraphs are listed so they win over
+// their first character.
+var katakanaRomaji = map[string]string{
+	"ア": "a", "イ": "i", "ウ": "u", "エ": "e", "オ": "o",
+	"カ": "ka", "キ": "ki", "ク": "ku", "ケ": "ke", "コ": "ko",
+	"サ": "sa", "シ": "shi", "ス": "su", "セ": "se", "ソ": "so",
+	"タ": "ta", "チ": "chi", "ツ": "tsu", "テ": "te", "ト": "to",
+	"ナ": "na", "ニ": "ni", "ヌ": "nu", "ネ": "ne", "ノ": "no",
+	"ハ": "ha", "ヒ": "hi", "フ": "fu", "ヘ": "he", "ホ": "ho",
+	"マ": "ma", "ミ": "mi", "ム": "mu", "メ": "me", "モ": "mo",
+	"ヤ": "ya", "ユ": "yu", "ヨ": "yo",
+	"ラ": "ra", "リ": "ri", "ル": "ru", "レ": "re", "ロ": "ro",
+	"ワ": "wa", "ヲ": "o", "ン": "n",
+	"ガ": "ga", "ギ": "gi", "グ": "gu", "ゲ": "ge", "ゴ": "go",
+	"ザ": "za", "ジ": "ji", "ズ": "zu", "ゼ": "ze", "ゾ": "zo",
+	"ダ": "da", "ヂ": "ji", "ヅ": "zu", "デ": "de", "ド": "do",
+	"バ": "ba", "ビ": "bi", "ブ": "bu", "ベ": "be", "ボ": "bo",
+	"パ": "pa", "ピ": "pi", "プ": "pu", "ペ": "pe", "ポ": "po",
+	"ヴ": "vu",
+	"ァ": "a", "ィ": "i", "ゥ": "u", "ェ": "e", "ォ": "o",
+	"ャ": "ya", "ュ": "yu", "ョ": "yo",
+	"キャ": "kya", "キュ": "kyu", "キョ": "kyo",
+	"シャ": "sha", "シュ": "shu", "シェ": "she", "ショ": "sho",
+	"チャ": "cha", "チュ": "chu", "チェ": "che", "チョ": "cho",
+	"ニャ": "nya", "ニュ": "nyu", "ニョ": "nyo",
+	"ヒャ": "hya", "ヒュ": "hyu", "ヒョ": "hyo",
+	"ミャ": "mya", "ミュ": "myu", "ミョ": "myo",
+	"リャ": "rya", "リュ": "ryu", "リョ": "ryo",
+	"ギャ": "gya", "ギュ": "gyu", "ギョ": "gyo",
+	"ジャ": "ja", "ジュ": "ju", "ジェ": "je", "ジョ": "jo",
+	"ビャ": "bya", "ビュ": "byu", "ビョ": "byo",
+	"ピャ": "pya", "ピュ": "pyu", "ピョ": "pyo",
+	"ティ": "ti", "ディ": "di", "トゥ": "tu", "ドゥ": "du",
+	"ファ": "fa", "フィ": "fi", "フェ": "fe", "フォ": "fo",
+	"ウィ": "wi", "ウェ": "we", "ウォ": "wo",
+	"ヴァ": "va", "ヴィ": "vi", "ヴェ": "ve", "ヴォ": "vo",
+}
+
+// Romanize folds hiragana and katakana in an already normalized key into romaji so that
+// "ぱいそん", "パイソン" and "paison" compare equal. Long vowel marks are dropped and a
+// small tsu doubles the following consonant. Other characters are kept as they are.
+func Romanize(key string) string {
+	runes := []rune(key)
+	for i, r := range runes {
+		if r >= hiraganaStart && r <= hiraganaEnd {
+			runes[i] = r + kanaOffset
+		}
+	}
+
+	var b strings.Builder
+	doubleNext := false
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch r {
+		case 'ー':
+			continue
+		case 'ッ':
+			doubleNext = true
+			continue
+		}
+
+		romaji, width := "", 1
+		if i+1 < len(runes) {
+			romaji = katakanaRomaji[string(runes[i:i+2])]
+			width = 2
+		}
+		if romaji == "" {
+			romaji, width = katakanaRomaji[string(r)], 1
+		}
+		if romaji == "" {
+			b.WriteRune(r)
+			doubleNext = false
+			continue
+		}
+
+		if doubleNext {
+			b.WriteByte(romaji[0])
+			doubleNext = false
+		}
+		b.WriteString(romaji)
+		i += width - 1
+	}
+	return b.String()
+}
@@ -0,0 +1,24 @@
+package skill
+
+import "testing"
+
+func TestRomanize(t *testing.T) {
+	tests := map[string]string{
+		"パイソン":   "paison",
+		"ぱいそん":   "paison",
+		"ゴー":     "go",
+		"ジャバ":    "jaba",
+		"シェル":    "sheru",
+		"ポスグレ":   "posugure",
+		"ネット":    "netto",
+		"go":     "go",
+		"c++":    "c++",
+		"rubyルビ": "rubyrubi",
+	}
+
+	for input, want := range tests {
+		if got := Romanize(input); got != want {
+			t.Errorf("Romanize(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package skill
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// languageSuffix is stripped so that "Go言語" and "Go" share a key.
+const languageSuffix = "言語"
+
+// Normalize folds a skill name into the key used for matching. Width variants are unified,
+// letters are lower-cased and spaces and separators such as ".", "-" and "_" are dropped,
+// so "Node.js", "node js" and "ＮＯＤＥＪＳ" compare equal. "+" and "#" are kept to tell
+// C, C++ and C# apart.
+func Normalize(name string) string {
+	folded := strings.ToLower(norm.NFKC.String(strings.TrimSpace(name)))
+	folded = strings.TrimSuffix(folded, languageSuffix)
+
+	var b strings.Builder
+	for _, r := range folded {
+		if unicode.IsSpace(r) || isSeparator(r) {
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func isSeparator(r rune) bool {
+	switch r {
+	case '.', '-', '_', '/', '・':
+		return true
+	default:
+		return false
+	}
+}
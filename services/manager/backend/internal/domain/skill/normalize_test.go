@@ -0,0 +1,24 @@
+package skill
+
+import "testing"
+
+func TestNormalize(t *testing.T) {
+	tests := map[string]string{
+		"Go":          "go",
+		"Go言語":        "go",
+		" golang ":    "golang",
+		"Node.js":     "nodejs",
+		"node js":     "nodejs",
+		"ＮＯＤＥＪＳ":      "nodejs",
+		"C++":         "c++",
+		"C#":          "c#",
+		"Objective-C": "objectivec",
+		"言語":          "",
+	}
+
+	for input, want := range tests {
+		if got := Normalize(input); got != want {
+			t.Errorf("Normalize(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
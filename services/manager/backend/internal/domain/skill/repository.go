@@ -0,0 +1,29 @@
+package skill
+
+import (
+	"context"
+	"time"
+)
+
+// CatalogRepository defines persistence operations for the skill catalog aggregate.
+type CatalogRepository interface {
+	Load(ctx context.Context) (*Catalog, error)
+	Save(ctx context.Context, catalog *Catalog) error
+	// Revision changes whenever the catalog is saved, so that callers caching a copy can
+	// tell that it went stale.
+	Revision(ctx context.Context) (uint64, error)
+	// Lock serializes load-modify-save cycles until the surrounding transaction ends.
+	Lock(ctx context.Context) error
+}
+
+// UsageRepository tracks how many users use each skill.
+type UsageRepository interface {
+	// Counts returns the number of users per skill identifier.
+	Counts(ctx context.Context) (map[string]int, error)
+	// Record marks the skills as used by the user. A user counts once per skill however
+	// often they are recorded.
+	Record(ctx context.Context, userID string, skillIDs []string, now time.Time) error
+	// Transfer moves the usage of a merged skill onto the skill that absorbed it. A user of
+	// both skills still counts once.
+	Transfer(ctx context.Context, fromID, toID string) error
+}
@@ -0,0 +1,112 @@
+package skill
+
+import "unicode/utf8"
+
+// MatchKind describes how an input was resolved to a catalog entry.
+type MatchKind string
+
+// Supported match kinds.
+const (
+	MatchCanonical MatchKind = "canonical"
+	MatchAlias     MatchKind = "alias"
+	MatchFuzzy     MatchKind = "fuzzy"
+)
+
+const (
+	// Keys this short are only matched exactly; "Go" must never become "C".
+	exactOnlyMaxLength = 3
+	oneEditMaxLength   = 6
+	maxEditDistance    = 2
+)
+
+// Resolution is the catalog entry an input resolved to.
+type Resolution struct {
+	Skill    Skill
+	Match    MatchKind
+	Distance int
+}
+
+// Resolve maps free text to a catalog entry. Canonical names and aliases match after
+// normalization; otherwise the closest key within a small edit distance wins, provided
+// it is not tied with a key of another skill.
+func (c *Catalog) Resolve(input string) (Resolution, bool) {
+	key := Normalize(input)
+	if key == "" {
+		return Resolution{}, false
+	}
+
+	if id, ok := c.index[key]; ok {
+		s := c.skills[id]
+		match := MatchAlias
+		if Normalize(s.name) == key {
+			match = MatchCanonical
+		}
+		return Resolution{Skill: s, Match: match}, true
+	}
+
+	limit := fuzzyLimit(key)
+	if limit == 0 {
+		return Resolution{}, false
+	}
+
+	bestID := ""
+	bestDistance := limit + 1
+	ambiguous := false
+	for candidate, id := range c.index {
+		distance := editDistance(key, candidate, limit)
+		switch {
+		case distance < bestDistance:
+			bestID, bestDistance, ambiguous = id, distance, false
+		case distance == bestDistance && id != bestID:
+			ambiguous = true
+		}
+	}
+	if bestID == "" || bestDistance > limit || ambiguous {
+		return Resolution{}, false
+	}
+
+	return Resolution{Skill: c.skills[bestID], Match: MatchFuzzy, Distance: bestDistance}, true
+}
+
+func fuzzyLimit(key string) int {
+	switch n := utf8.RuneCountInString(key); {
+	case n <= exactOnlyMaxLength:
+		return 0
+	case n <= oneEditMaxLength:
+		return 1
+	default:
+		return maxEditDistance
+	}
+}
+
+// editDistance returns the Levenshtein distance between a and b, or limit+1 once it is
+// known to exceed limit.
+func editDistance(a, b string, limit int) int {
+	ra, rb := []rune(a), []rune(b)
+	if diff := len(ra) - len(rb); diff > limit || -diff > limit {
+		return limit + 1
+	}
+
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		rowMin := curr[0]
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			rowMin = min(rowMin, curr[j])
+		}
+		if rowMin > limit {
+			return limit + 1
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
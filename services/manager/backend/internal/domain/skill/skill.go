@@ -0,0 +1,128 @@
+// Package skill provides the managed skill catalog and its name resolution rules.
+package skill
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/sky0621/techcv/manager/backend/internal/domain"
+	"github.com/sky0621/techcv/manager/backend/internal/domain/uuidv7"
+)
+
+const maxNameLength = 100
+
+// Category classifies a skill in the catalog.
+type Category string
+
+// Supported skill categories.
+const (
+	CategoryLanguage       Category = "language"
+	CategoryFramework      Category = "framework"
+	CategoryLibrary        Category = "library"
+	CategoryDatabase       Category = "database"
+	CategoryCloud          Category = "cloud"
+	CategoryInfrastructure Category = "infrastructure"
+	CategoryTool           Category = "tool"
+)
+
+// NewCategory validates a raw category value.
+func NewCategory(raw string) (Category, error) {
+	switch c := Category(strings.TrimSpace(raw)); c {
+	case CategoryLanguage, CategoryFramework, CategoryLibrary, CategoryDatabase, CategoryCloud, CategoryInfrastructure, CategoryTool:
+		return c, nil
+	default:
+		detail := domain.ErrorDetail{Field: "category", Code: domain.ErrorCodeInvalidSkillCategory, Message: "スキルのカテゴリが正しくありません"}
+		return "", domain.NewValidation(domain.ErrorCodeInvalidSkillCategory, "スキルのカテゴリが正しくありません").WithDetails(detail)
+	}
+}
+
+// Skill is a catalog entry with a canonical name and the aliases users type for it.
+type Skill struct {
+	id       string
+	name     string
+	category Category
+	aliases  []string
+	parentID string
+}
+
+// NewSkill constructs a catalog entry with a freshly generated identifier.
+// parentID may be empty for top-level skills such as languages.
+func NewSkill(name string, category Category, aliases []string, parentID string) (Skill, error) {
+	id, err := uuidv7.NewString()
+	if err != nil {
+		return Skill{}, domain.NewInternal(domain.ErrorCodeUUIDGenerationFailed, "スキルIDの生成に失敗しました", err)
+	}
+	return RestoreSkill(id, name, category, aliases, parentID)
+}
+
+// RestoreSkill rebuilds a catalog entry from persisted values.
+func RestoreSkill(id, name string, category Category, aliases []string, parentID string) (Skill, error) {
+	trimmed := strings.TrimSpace(name)
+	if trimmed == "" || utf8.RuneCountInString(trimmed) > maxNameLength || Normalize(trimmed) == "" {
+		detail := domain.ErrorDetail{Field: "name", Code: domain.ErrorCodeInvalidSkillName, Message: "スキル名は1文字以上100文字以内で入力してください"}
+		return Skill{}, domain.NewValidation(domain.ErrorCodeInvalidSkillName, "スキル名が正しくありません").WithDetails(detail)
+	}
+
+	s := Skill{
+		id:       id,
+		name:     trimmed,
+		category: category,
+		parentID: parentID,
+	}
+	s.addAliases(aliases...)
+	return s, nil
+}
+
+// ID returns the skill identifier.
+func (s Skill) ID() string {
+	return s.id
+}
+
+// Name returns the canonical display name.
+func (s Skill) Name() string {
+	return s.name
+}
+
+// Category returns the skill category.
+func (s Skill) Category() Category {
+	return s.category
+}
+
+// Aliases returns alternative spellings that resolve to this skill.
+func (s Skill) Aliases() []string {
+	return append([]string(nil), s.aliases...)
+}
+
+// ParentID returns the identifier of the parent skill, or an empty string.
+func (s Skill) ParentID() string {
+	return s.parentID
+}
+
+// keys returns the normalized lookup keys of the canonical name and every alias.
+func (s Skill) keys() []string {
+	keys := []string{Normalize(s.name)}
+	for _, alias := range s.aliases {
+		keys = append(keys, Normalize(alias))
+	}
+	return keys
+}
+
+// addAliases appends aliases that do not normalize to an existing key of the skill.
+func (s *Skill) addAliases(aliases ...string) {
+	seen := make(map[string]struct{})
+	for _, key := range s.keys() {
+		seen[key] = struct{}{}
+	}
+	for _, alias := range aliases {
+		trimmed := strings.TrimSpace(alias)
+		key := Normalize(trimmed)
+		if key == "" {
+			continue
+		}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		s.aliases = append(s.aliases, trimmed)
+	}
+}
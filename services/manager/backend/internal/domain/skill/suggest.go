@@ -0,0 +1,126 @@
+package skill
+
+import (
+	"sort"
+	"strings"
+)
+
+// Suggestion is a catalog entry offered for a partially typed skill name.
+type Suggestion struct {
+	Skill Skill
+	// MatchedAlias is the alias the query matched, or empty when it matched the canonical name.
+	MatchedAlias string
+	Usage        int
+}
+
+// UsageFunc returns how often a skill is used across all users.
+type UsageFunc func(skillID string) int
+
+type suggestEntry struct {
+	key     string
+	skillID string
+	alias   string
+}
+
+// SuggestIndex answers prefix queries over canonical names and aliases. It is built from a
+// catalog snapshot and must be rebuilt when the catalog changes.
+type SuggestIndex struct {
+	entries []suggestEntry
+	skills  map[string]Skill
+}
+
+// NewSuggestIndex builds a prefix index over every name and alias in the catalog. Each
+// name is indexed by its normalized key and, when it contains kana, by its romaji reading.
+func NewSuggestIndex(c *Catalog) *SuggestIndex {
+	idx := &SuggestIndex{skills: make(map[string]Skill, len(c.skills))}
+	for id, s := range c.skills {
+		idx.skills[id] = s
+		idx.add(s.name, id, "")
+		for _, alias := range s.aliases {
+			idx.add(alias, id, alias)
+		}
+	}
+	sort.Slice(idx.entries, func(i, j int) bool {
+		return idx.entries[i].key < idx.entries[j].key
+	})
+	return idx
+}
+
+func (idx *SuggestIndex) add(name, skillID, alias string) {
+	key := Normalize(name)
+	idx.entries = append(idx.entries, suggestEntry{key: key, skillID: skillID, alias: alias})
+	if romaji := Romanize(key); romaji != key {
+		idx.entries = append(idx.entries, suggestEntry{key: romaji, skillID: skillID, alias: alias})
+	}
+}
+
+// Suggest returns up to limit skills whose name or alias starts with the query. Exact
+// matches come first, then skills by descending usage, then by canonical name.
+func (idx *SuggestIndex) Suggest(query string, limit int, usage UsageFunc) []Suggestion {
+	key := Normalize(query)
+	if key == "" || limit <= 0 {
+		return nil
+	}
+
+	type candidate struct {
+		Suggestion
+		exact bool
+	}
+	found := make(map[string]*candidate)
+	for _, prefix := range uniqueKeys(key, Romanize(key)) {
+		start := sort.Search(len(idx.entries), func(i int) bool {
+			return idx.entries[i].key >= prefix
+		})
+		for i := start; i < len(idx.entries) && strings.HasPrefix(idx.entries[i].key, prefix); i++ {
+			e := idx.entries[i]
+			exact := e.key == prefix
+			c, ok := found[e.skillID]
+			if !ok {
+				c = &candidate{Suggestion: Suggestion{Skill: idx.skills[e.skillID], MatchedAlias: e.alias}, exact: exact}
+				found[e.skillID] = c
+			}
+			// Prefer reporting the canonical name, then an exactly matching alias.
+			if e.alias == "" || (exact && !c.exact && c.MatchedAlias != "") {
+				c.MatchedAlias = e.alias
+			}
+			c.exact = c.exact || exact
+		}
+	}
+
+	candidates := make([]*candidate, 0, len(found))
+	for _, c := range found {
+		if usage != nil {
+			c.Usage = usage(c.Skill.id)
+		}
+		candidates = append(candidates, c)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.exact != b.exact {
+			return a.exact
+		}
+		if a.Usage != b.Usage {
+			return a.Usage > b.Usage
+		}
+		return Normalize(a.Skill.name) < Normalize(b.Skill.name)
+	})
+
+	if len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+	result := make([]Suggestion, 0, len(candidates))
+	for _, c := range candidates {
+		result = append(result, c.Suggestion)
+	}
+	return result
+}
+
+func uniqueKeys(keys ...string) []string {
+	result := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if key != "" && (len(result) == 0 || result[len(result)-1] != key) {
+			result = append(result, key)
+		}
+	}
+	return result
+}
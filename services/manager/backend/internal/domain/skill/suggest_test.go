@@ -0,0 +1,75 @@
+package skill
+
+import "testing"
+
+func suggestionNames(suggestions []Suggestion) []string {
+	names := make([]string, 0, len(suggestions))
+	for _, s := range suggestions {
+		names = append(names, s.Skill.Name())
+	}
+	return names
+}
+
+func TestSuggest(t *testing.T) {
+	catalog, err := NewCatalog([]Skill{
+		mustSkill(t, "go", "Go", CategoryLanguage, []string{"golang", "ゴー"}, ""),
+		mustSkill(t, "gin", "Gin", CategoryFramework, nil, "go"),
+		mustSkill(t, "graphql", "GraphQL", CategoryLanguage, nil, ""),
+		mustSkill(t, "py", "Python", CategoryLanguage, []string{"パイソン"}, ""),
+		mustSkill(t, "pg", "PostgreSQL", CategoryDatabase, []string{"ポスグレ"}, ""),
+	})
+	if err != nil {
+		t.Fatalf("NewCatalog() error = %v", err)
+	}
+	index := NewSuggestIndex(catalog)
+	usage := map[string]int{"graphql": 5, "gin": 2}
+	usageFunc := func(id string) int { return usage[id] }
+
+	tests := []struct {
+		query string
+		limit int
+		want  []string
+	}{
+		// Exact match first, then by usage, then by name.
+		{query: "g", limit: 10, want: []string{"GraphQL", "Gin", "Go"}},
+		{query: "go", limit: 10, want: []string{"Go"}},
+		{query: "Ｇ", limit: 2, want: []string{"GraphQL", "Gin"}},
+		{query: "ぱい", limit: 10, want: []string{"Python"}},
+		{query: "paiso", limit: 10, want: []string{"Python"}},
+		{query: "ぽす", limit: 10, want: []string{"PostgreSQL"}},
+		{query: "ご", limit: 10, want: []string{"Go"}},
+		{query: "rust", limit: 10, want: []string{}},
+		{query: " ", limit: 10, want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			got := suggestionNames(index.Suggest(tt.query, tt.limit, usageFunc))
+			if len(got) != len(tt.want) {
+				t.Fatalf("Suggest(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("Suggest(%q) = %v, want %v", tt.query, got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestSuggestReportsMatchedAlias(t *testing.T) {
+	catalog, err := NewCatalog([]Skill{
+		mustSkill(t, "go", "Go", CategoryLanguage, []string{"golang"}, ""),
+	})
+	if err != nil {
+		t.Fatalf("NewCatalog() error = %v", err)
+	}
+	index := NewSuggestIndex(catalog)
+
+	if got := index.Suggest("gol", 1, nil); len(got) != 1 || got[0].MatchedAlias != "golang" {
+		t.Fatalf("expected alias match, got %+v", got)
+	}
+	if got := index.Suggest("go", 1, nil); len(got) != 1 || got[0].MatchedAlias != "" {
+		t.Fatalf("expected canonical match, got %+v", got)
+	}
+}
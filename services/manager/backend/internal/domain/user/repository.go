@@ -1,6 +1,9 @@
 package user
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // UserRepository defines persistence operations for user aggregates.
 type UserRepository interface {
@@ -9,6 +12,15 @@ type UserRepository interface {
 	GetByEmail(ctx context.Context, email Email) (User, error)
 }
 
+// GitAuthorEmailRepository defines persistence operations for the emails a user commits
+// with besides the account email.
+type GitAuthorEmailRepository interface {
+	// GetEmail returns the account email of the user.
+	GetEmail(ctx context.Context, userID string) (Email, error)
+	GitAuthorEmails(ctx context.Context, userID string) ([]Email, error)
+	ReplaceGitAuthorEmails(ctx context.Context, userID string, emails []Email, now time.Time) error
+}
+
 // VerificationTokenRepository defines persistence operations for email verification tokens.
 type VerificationTokenRepository interface {
 	Save(ctx context.Context, token VerificationToken) error
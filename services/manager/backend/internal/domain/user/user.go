@@ -47,6 +47,31 @@ func NewUser(email Email, passwordHash string, now time.Time) (User, error) {
 	}, nil
 }
 
+// RestoreUser rebuilds a user aggregate from persisted values.
+func RestoreUser(
+	id string,
+	email Email,
+	passwordHash string,
+	name, bio *string,
+	isActive bool,
+	emailVerifiedAt time.Time,
+	lastLoginAt *time.Time,
+	createdAt, updatedAt time.Time,
+) User {
+	return User{
+		id:              id,
+		email:           email,
+		passwordHash:    passwordHash,
+		name:            name,
+		bio:             bio,
+		isActive:        isActive,
+		emailVerifiedAt: emailVerifiedAt,
+		lastLoginAt:     lastLoginAt,
+		createdAt:       createdAt,
+		updatedAt:       updatedAt,
+	}
+}
+
 // ID returns the user's identifier.
 func (u User) ID() string {
 	return u.id
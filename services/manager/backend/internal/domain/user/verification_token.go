@@ -46,6 +46,18 @@ func NewVerificationToken(email Email, passwordHash string, now time.Time, ttl t
 	}, nil
 }
 
+// RestoreVerificationToken rebuilds a verification token from persisted values.
+func RestoreVerificationToken(id string, email Email, token, passwordHash string, expiresAt, createdAt time.Time) VerificationToken {
+	return VerificationToken{
+		id:           id,
+		email:        email,
+		token:        token,
+		passwordHash: passwordHash,
+		expiresAt:    expiresAt.UTC(),
+		createdAt:    createdAt.UTC(),
+	}
+}
+
 // ID returns the internal identifier for the token.
 func (t VerificationToken) ID() string {
 	return t.id
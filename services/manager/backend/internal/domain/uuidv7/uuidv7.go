@@ -6,6 +6,7 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -51,8 +52,30 @@ func NewString() (string, error) {
 	uuid[6] = (uuid[6] & versionBitsMask) | versionByteValue
 	uuid[8] = (uuid[8] & variantBitsMask) | variantByteValue
 
+	return format(uuid[:]), nil
+}
+
+// ToBytes converts a canonical UUID string into the 16 bytes stored in BINARY(16) columns.
+func ToBytes(id string) ([]byte, error) {
+	b, err := hex.DecodeString(strings.ReplaceAll(id, "-", ""))
+	// Round-tripping rejects dashes in the wrong places and wrong lengths.
+	if err != nil || len(b) != uuidSize || format(b) != strings.ToLower(id) {
+		return nil, fmt.Errorf("invalid uuid %q", id)
+	}
+	return b, nil
+}
+
+// FromBytes converts 16 bytes read from a BINARY(16) column into a canonical UUID string.
+func FromBytes(b []byte) (string, error) {
+	if len(b) != uuidSize {
+		return "", fmt.Errorf("invalid uuid length %d", len(b))
+	}
+	return format(b), nil
+}
+
+func format(uuid []byte) string {
 	buf := make([]byte, hexBufferLength)
-	hex.Encode(buf, uuid[:])
+	hex.Encode(buf, uuid)
 
 	return fmt.Sprintf("%s-%s-%s-%s-%s",
 		buf[0:uuidSection1End],
@@ -60,5 +83,5 @@ func NewString() (string, error) {
 		buf[uuidSection2End:uuidSection3End],
 		buf[uuidSection3End:uuidSection4End],
 		buf[uuidSection4End:],
-	), nil
+	)
 }
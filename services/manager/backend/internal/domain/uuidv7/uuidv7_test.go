@@ -25,3 +25,37 @@ func TestNewString(t *testing.T) {
 		ids[id] = struct{}{}
 	}
 }
+
+func TestBytesRoundTrip(t *testing.T) {
+	id, err := NewString()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	b, err := ToBytes(id)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(b) != uuidSize {
+		t.Fatalf("unexpected byte length: %d", len(b))
+	}
+
+	restored, err := FromBytes(b)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if restored != id {
+		t.Fatalf("round trip mismatch: got %s, want %s", restored, id)
+	}
+}
+
+func TestToBytesInvalid(t *testing.T) {
+	for _, id := range []string{"", "not-a-uuid", "0190c8a1b2c37d4e8f00112233445566", "0190c8a1-b2c3-7d4e-8f00-11223344556", "0190c8a1b-2c3-7d4e-8f00-112233445566"} {
+		if _, err := ToBytes(id); err == nil {
+			t.Fatalf("expected error for %q", id)
+		}
+	}
+	if _, err := FromBytes([]byte{1, 2, 3}); err == nil {
+		t.Fatalf("expected error for short bytes")
+	}
+}
@@ -0,0 +1,37 @@
+// Package analytics provides helpers for recording how public CVs are viewed.
+package analytics
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"strconv"
+	"time"
+)
+
+// VisitorHasher derives the hashes that estimate unique visitors of a public URL without
+// storing their IP addresses.
+type VisitorHasher struct {
+	secret []byte
+}
+
+// NewVisitorHasher constructs a hasher keyed with secret. Every instance recording views
+// must share the secret for visitors to be counted once.
+func NewVisitorHasher(secret []byte) (*VisitorHasher, error) {
+	if len(secret) == 0 {
+		return nil, errors.New("visitor hash secret is empty")
+	}
+	return &VisitorHasher{secret: secret}, nil
+}
+
+// Hash returns a keyed hash of the visitor's IP address and user agent for one link and
+// UTC day. Including the day makes hashes unlinkable across days, and the key stops anyone
+// without it from recovering an address by hashing every possible one.
+func (h *VisitorHasher) Hash(linkID uint64, day time.Time, ip, userAgent string) []byte {
+	mac := hmac.New(sha256.New, h.secret)
+	for _, part := range []string{strconv.FormatUint(linkID, 10), day.UTC().Format(time.DateOnly), ip, userAgent} {
+		mac.Write([]byte(part))
+		mac.Write([]byte{0})
+	}
+	return mac.Sum(nil)
+}
@@ -0,0 +1,47 @@
+package analytics
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestVisitorHasher(t *testing.T) {
+	hasher, err := NewVisitorHasher([]byte("secret"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	morning := time.Date(2024, time.March, 1, 1, 0, 0, 0, time.UTC)
+	evening := time.Date(2024, time.March, 1, 23, 0, 0, 0, time.UTC)
+	base := hasher.Hash(1, morning, "192.0.2.1", "Mozilla/5.0")
+
+	if len(base) != 32 {
+		t.Fatalf("unexpected hash length: %d", len(base))
+	}
+	if !bytes.Equal(base, hasher.Hash(1, evening, "192.0.2.1", "Mozilla/5.0")) {
+		t.Fatalf("expected the same visitor to hash alike within a day")
+	}
+	for name, other := range map[string][]byte{
+		"next day":   hasher.Hash(1, morning.Add(24*time.Hour), "192.0.2.1", "Mozilla/5.0"),
+		"other link": hasher.Hash(2, morning, "192.0.2.1", "Mozilla/5.0"),
+		"other ip":   hasher.Hash(1, morning, "192.0.2.2", "Mozilla/5.0"),
+		"other ua":   hasher.Hash(1, morning, "192.0.2.1", "curl/8.0"),
+	} {
+		if bytes.Equal(base, other) {
+			t.Errorf("expected a different hash for the %s", name)
+		}
+	}
+
+	otherKey, err := NewVisitorHasher([]byte("another secret"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if bytes.Equal(base, otherKey.Hash(1, morning, "192.0.2.1", "Mozilla/5.0")) {
+		t.Fatalf("expected the hash to depend on the secret")
+	}
+
+	if _, err := NewVisitorHasher(nil); err == nil {
+		t.Fatalf("expected an empty secret to be rejected")
+	}
+}
@@ -0,0 +1,76 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"github.com/sky0621/techcv/manager/backend/internal/domain/user"
+)
+
+const sessionTokenBytes = 32
+
+// Clock abstracts the source of current time for session expiry.
+type Clock interface {
+	Now() time.Time
+}
+
+// SessionStore persists sessions by the SHA-256 hash of their token.
+type SessionStore interface {
+	Create(ctx context.Context, tokenHash []byte, userID string, expiresAt, now time.Time) error
+	FindUserID(ctx context.Context, tokenHash []byte, now time.Time) (string, bool, error)
+	DeleteExpired(ctx context.Context, now time.Time) error
+}
+
+// SessionTokenIssuer issues opaque bearer tokens and remembers which user each belongs to.
+// Only a hash of each token is stored, so sessions are shared by every instance using the
+// same store without the store being able to hand out tokens.
+type SessionTokenIssuer struct {
+	store SessionStore
+	clock Clock
+	ttl   time.Duration
+}
+
+// NewSessionTokenIssuer constructs an issuer whose tokens expire after ttl.
+func NewSessionTokenIssuer(store SessionStore, clock Clock, ttl time.Duration) *SessionTokenIssuer {
+	return &SessionTokenIssuer{
+		store: store,
+		clock: clock,
+		ttl:   ttl,
+	}
+}
+
+// Issue generates a new authentication token for the given user.
+func (s *SessionTokenIssuer) Issue(ctx context.Context, u user.User) (string, error) {
+	buf := make([]byte, sessionTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generate random bytes: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(buf)
+
+	now := s.clock.Now()
+	if err := s.store.DeleteExpired(ctx, now); err != nil {
+		return "", fmt.Errorf("delete expired sessions: %w", err)
+	}
+	if err := s.store.Create(ctx, hashSessionToken(token), u.ID(), now.Add(s.ttl), now); err != nil {
+		return "", fmt.Errorf("create session: %w", err)
+	}
+	return token, nil
+}
+
+// Authenticate returns the user the token was issued to, if it is known and unexpired.
+func (s *SessionTokenIssuer) Authenticate(ctx context.Context, token string) (string, bool, error) {
+	userID, ok, err := s.store.FindUserID(ctx, hashSessionToken(token), s.clock.Now())
+	if err != nil {
+		return "", false, fmt.Errorf("find session: %w", err)
+	}
+	return userID, ok, nil
+}
+
+func hashSessionToken(token string) []byte {
+	sum := sha256.Sum256([]byte(token))
+	return sum[:]
+}
@@ -0,0 +1,92 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sky0621/techcv/manager/backend/internal/domain/user"
+)
+
+type stubClock struct {
+	now time.Time
+}
+
+func (c *stubClock) Now() time.Time {
+	return c.now
+}
+
+type storedSession struct {
+	tokenHash []byte
+	userID    string
+	expiresAt time.Time
+}
+
+type stubSessionStore struct {
+	sessions []storedSession
+}
+
+func (s *stubSessionStore) Create(_ context.Context, tokenHash []byte, userID string, expiresAt, _ time.Time) error {
+	s.sessions = append(s.sessions, storedSession{tokenHash: tokenHash, userID: userID, expiresAt: expiresAt})
+	return nil
+}
+
+func (s *stubSessionStore) FindUserID(_ context.Context, tokenHash []byte, now time.Time) (string, bool, error) {
+	for _, session := range s.sessions {
+		if bytes.Equal(session.tokenHash, tokenHash) && now.Before(session.expiresAt) {
+			return session.userID, true, nil
+		}
+	}
+	return "", false, nil
+}
+
+func (s *stubSessionStore) DeleteExpired(_ context.Context, now time.Time) error {
+	kept := s.sessions[:0]
+	for _, session := range s.sessions {
+		if now.Before(session.expiresAt) {
+			kept = append(kept, session)
+		}
+	}
+	s.sessions = kept
+	return nil
+}
+
+func TestSessionTokenIssuer(t *testing.T) {
+	clock := &stubClock{now: time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)}
+	store := &stubSessionStore{}
+	issuer := NewSessionTokenIssuer(store, clock, time.Hour)
+	ctx := context.Background()
+
+	email, err := user.NewEmail("taro@example.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	u, err := user.NewUser(email, "hash", clock.now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	token, err := issuer.Issue(ctx, u)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(store.sessions) != 1 || bytes.Contains(store.sessions[0].tokenHash, []byte(token)) {
+		t.Fatalf("expected only a hash of the token to be stored, got %+v", store.sessions)
+	}
+
+	userID, ok, err := issuer.Authenticate(ctx, token)
+	if err != nil || !ok || userID != u.ID() {
+		t.Fatalf("expected token to authenticate %s, got %q (%v, %v)", u.ID(), userID, ok, err)
+	}
+
+	if _, ok, _ := issuer.Authenticate(ctx, "unknown"); ok {
+		t.Fatalf("expected unknown token to be rejected")
+	}
+
+	clock.now = clock.now.Add(time.Hour)
+	if _, ok, _ := issuer.Authenticate(ctx, token); ok {
+		t.Fatalf("expected expired token to be rejected")
+	}
+}
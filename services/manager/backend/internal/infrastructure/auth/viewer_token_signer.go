@@ -0,0 +1,64 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sky0621/techcv/manager/backend/internal/domain"
+)
+
+// ViewerTokenSigner issues stateless tokens proving that a viewer entered the passphrase of a
+// public URL. Tokens are HMAC-signed over the link and its current passphrase hash, so changing
+// or removing the passphrase invalidates every token issued before.
+type ViewerTokenSigner struct {
+	secret []byte
+	clock  Clock
+	ttl    time.Duration
+}
+
+// NewViewerTokenSigner constructs a signer whose tokens expire after ttl. Every instance
+// serving the same links must share the secret.
+func NewViewerTokenSigner(secret []byte, clock Clock, ttl time.Duration) (*ViewerTokenSigner, error) {
+	if len(secret) == 0 {
+		return nil, errors.New("viewer token secret is empty")
+	}
+	return &ViewerTokenSigner{secret: secret, clock: clock, ttl: ttl}, nil
+}
+
+// Issue returns a token for the link together with its expiry.
+func (s *ViewerTokenSigner) Issue(link domain.PublicURL) (string, time.Time) {
+	expiresAt := s.clock.Now().Add(s.ttl).Truncate(time.Second)
+	expiry := strconv.FormatInt(expiresAt.Unix(), 10)
+	return expiry + "." + base64.RawURLEncoding.EncodeToString(s.sign(link, expiry)), expiresAt
+}
+
+// Verify reports whether the token was issued for the link as it is now and has not expired.
+func (s *ViewerTokenSigner) Verify(link domain.PublicURL, token string) bool {
+	expiry, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return false
+	}
+	unix, err := strconv.ParseInt(expiry, 10, 64)
+	if err != nil || !s.clock.Now().Before(time.Unix(unix, 0)) {
+		return false
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	return hmac.Equal(mac, s.sign(link, expiry))
+}
+
+func (s *ViewerTokenSigner) sign(link domain.PublicURL, expiry string) []byte {
+	h := hmac.New(sha256.New, s.secret)
+	for _, part := range []string{strconv.FormatUint(link.ID, 10), link.URLKey, link.PassphraseHash, expiry} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return h.Sum(nil)
+}
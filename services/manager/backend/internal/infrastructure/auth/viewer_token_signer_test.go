@@ -0,0 +1,62 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sky0621/techcv/manager/backend/internal/domain"
+)
+
+func TestViewerTokenSigner(t *testing.T) {
+	clock := &stubClock{now: time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)}
+	signer, err := NewViewerTokenSigner([]byte("secret"), clock, time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	link := domain.PublicURL{ID: 7, URLKey: "key", PassphraseHash: "hash"}
+
+	token, expiresAt := signer.Issue(link)
+	if !expiresAt.Equal(clock.now.Add(time.Hour)) {
+		t.Fatalf("unexpected expiry: %s", expiresAt)
+	}
+	if !signer.Verify(link, token) {
+		t.Fatalf("expected token to verify")
+	}
+
+	other := link
+	other.ID = 8
+	if signer.Verify(other, token) {
+		t.Fatalf("expected token to be bound to its link")
+	}
+
+	rotated := link
+	rotated.PassphraseHash = "new-hash"
+	if signer.Verify(rotated, token) {
+		t.Fatalf("expected token to be invalidated by a passphrase change")
+	}
+
+	forged, err := NewViewerTokenSigner([]byte("other"), clock, time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if forgedToken, _ := forged.Issue(link); signer.Verify(link, forgedToken) {
+		t.Fatalf("expected token signed with another secret to be rejected")
+	}
+
+	for _, malformed := range []string{"", "nodot", "abc.def", "1." + token} {
+		if signer.Verify(link, malformed) {
+			t.Fatalf("expected malformed token %q to be rejected", malformed)
+		}
+	}
+
+	clock.now = expiresAt
+	if signer.Verify(link, token) {
+		t.Fatalf("expected expired token to be rejected")
+	}
+}
+
+func TestNewViewerTokenSignerRequiresSecret(t *testing.T) {
+	if _, err := NewViewerTokenSigner(nil, &stubClock{}, time.Hour); err == nil {
+		t.Fatalf("expected error for empty secret")
+	}
+}
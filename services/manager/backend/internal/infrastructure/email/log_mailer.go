@@ -28,3 +28,24 @@ func (m LogMailer) SendVerificationEmail(_ context.Context, email user.Email, ve
 	)
 	return nil
 }
+
+// SendPublicURLExpiryReminder records the public URL expiry reminder in the log.
+func (m LogMailer) SendPublicURLExpiryReminder(_ context.Context, email user.Email, shareURL string, expiresAt time.Time) error {
+	m.logger.Info("public URL expiry reminder dispatched",
+		slog.String("email", email.String()),
+		slog.String("share_url", shareURL),
+		slog.Time("expires_at", expiresAt),
+	)
+	return nil
+}
+
+// SendPublicURLFirstView records the first view notification of a public URL in the log.
+func (m LogMailer) SendPublicURLFirstView(_ context.Context, email user.Email, label, shareURL string, viewedAt time.Time) error {
+	m.logger.Info("public URL first view notification dispatched",
+		slog.String("email", email.String()),
+		slog.String("label", label),
+		slog.String("share_url", shareURL),
+		slog.Time("viewed_at", viewedAt),
+	)
+	return nil
+}
@@ -0,0 +1,112 @@
+// Package geoip looks up the country of IP addresses in a local database file.
+package geoip
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/netip"
+	"os"
+	"sort"
+	"strings"
+)
+
+// unknownCountry marks ranges whose country is not known in DB-IP files.
+const unknownCountry = "ZZ"
+
+type addrRange struct {
+	first   netip.Addr
+	last    netip.Addr
+	country string
+}
+
+// Database maps IP address ranges to countries. The zero value knows no ranges and finds
+// no country for any address.
+type Database struct {
+	ranges []addrRange
+}
+
+// Open loads the database from a CSV file, see Load.
+func Open(path string) (*Database, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open geoip database: %w", err)
+	}
+
+	db, err := Load(f)
+	if closeErr := f.Close(); closeErr != nil && err == nil {
+		return nil, fmt.Errorf("close geoip database: %w", closeErr)
+	}
+	return db, err
+}
+
+// Load reads a CSV database whose rows hold the first address, the last address and the
+// ISO 3166-1 alpha-2 country code of a range, as in the free DB-IP "IP to Country Lite"
+// download. IPv4 and IPv6 ranges may be mixed; ranges must not overlap.
+func Load(r io.Reader) (*Database, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+
+	var ranges []addrRange
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read geoip database: %w", err)
+		}
+
+		entry, err := parseRange(record)
+		if err != nil {
+			return nil, fmt.Errorf("geoip database line %d: %w", line, err)
+		}
+		if entry.country == unknownCountry {
+			continue
+		}
+		ranges = append(ranges, entry)
+	}
+
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].first.Less(ranges[j].first)
+	})
+	return &Database{ranges: ranges}, nil
+}
+
+// Country returns the country code of ip, or an empty string when no range contains it.
+func (d *Database) Country(ip netip.Addr) string {
+	ip = ip.Unmap()
+	i := sort.Search(len(d.ranges), func(i int) bool {
+		return ip.Less(d.ranges[i].first)
+	}) - 1
+	if i < 0 || d.ranges[i].last.Less(ip) {
+		return ""
+	}
+	return d.ranges[i].country
+}
+
+func parseRange(record []string) (addrRange, error) {
+	if len(record) < 3 {
+		return addrRange{}, fmt.Errorf("expected 3 fields, got %d", len(record))
+	}
+	first, err := netip.ParseAddr(strings.TrimSpace(record[0]))
+	if err != nil {
+		return addrRange{}, err
+	}
+	last, err := netip.ParseAddr(strings.TrimSpace(record[1]))
+	if err != nil {
+		return addrRange{}, err
+	}
+	first, last = first.Unmap(), last.Unmap()
+	if first.Is4() != last.Is4() || last.Less(first) {
+		return addrRange{}, fmt.Errorf("invalid range %s-%s", first, last)
+	}
+
+	country := strings.ToUpper(strings.TrimSpace(record[2]))
+	if len(country) != 2 {
+		return addrRange{}, fmt.Errorf("invalid country code %q", record[2])
+	}
+	return addrRange{first: first, last: last, country: country}, nil
+}
@@ -0,0 +1,58 @@
+package geoip
+
+import (
+	"net/netip"
+	"strings"
+	"testing"
+)
+
+func TestDatabaseCountry(t *testing.T) {
+	db, err := Load(strings.NewReader(
+		"1.0.16.0,1.0.31.255,JP\n" +
+			"1.0.0.0,1.0.0.255,AU\n" +
+			"1.0.32.0,1.0.32.255,ZZ\n" +
+			"2001:200::,2001:200:ffff:ffff:ffff:ffff:ffff:ffff,jp\n",
+	))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cases := map[string]string{
+		"1.0.0.0":           "AU",
+		"1.0.0.255":         "AU",
+		"1.0.1.0":           "",
+		"1.0.20.1":          "JP",
+		"::ffff:1.0.20.1":   "JP",
+		"1.0.32.1":          "",
+		"2001:200::1":       "JP",
+		"2001:201::1":       "",
+		"0.0.0.1":           "",
+		"255.255.255.255":   "",
+		"ffff::1":           "",
+		"2001:200:ffff::ab": "JP",
+	}
+	for raw, want := range cases {
+		if got := db.Country(netip.MustParseAddr(raw)); got != want {
+			t.Errorf("Country(%s) = %q, want %q", raw, got, want)
+		}
+	}
+
+	var empty Database
+	if got := empty.Country(netip.MustParseAddr("1.0.0.1")); got != "" {
+		t.Errorf("expected the zero database to know no country, got %q", got)
+	}
+}
+
+func TestLoadRejectsMalformedRows(t *testing.T) {
+	for _, row := range []string{
+		"1.0.0.0,1.0.0.255\n",
+		"1.0.0.0,not-an-ip,AU\n",
+		"1.0.0.255,1.0.0.0,AU\n",
+		"1.0.0.0,2001:200::,AU\n",
+		"1.0.0.0,1.0.0.255,AUS\n",
+	} {
+		if _, err := Load(strings.NewReader(row)); err == nil {
+			t.Errorf("expected %q to be rejected", row)
+		}
+	}
+}
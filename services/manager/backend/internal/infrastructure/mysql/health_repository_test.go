@@ -14,6 +14,7 @@ func TestHealthRepositoryPing(t *testing.T) {
 		t.Fatalf("failed to create sqlmock: %v", err)
 	}
 	defer func() {
+		mock.ExpectClose()
 		if closeErr := db.Close(); closeErr != nil {
 			t.Fatalf("failed to close db: %v", closeErr)
 		}
@@ -8,6 +8,9 @@ import (
 	"time"
 
 	_ "github.com/go-sql-driver/mysql"
+
+	mysqlsqlc "github.com/sky0621/techcv/manager/backend/internal/infrastructure/mysql/sqlc"
+	"github.com/sky0621/techcv/manager/backend/internal/infrastructure/transaction"
 )
 
 // Config holds the connection parameters required to connect to MySQL.
@@ -66,3 +69,11 @@ func NewConnection(ctx context.Context, cfg Config) (*sql.DB, error) {
 
 	return db, nil
 }
+
+// queriesFor binds the queries to the transaction carried by ctx, if any.
+func queriesFor(ctx context.Context, queries *mysqlsqlc.Queries) *mysqlsqlc.Queries {
+	if tx, ok := transaction.TxFromContext(ctx); ok {
+		return queries.WithTx(tx)
+	}
+	return queries
+}
@@ -6,11 +6,22 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"time"
 
 	"github.com/sky0621/techcv/manager/backend/internal/domain"
+	"github.com/sky0621/techcv/manager/backend/internal/domain/uuidv7"
 	mysqlsqlc "github.com/sky0621/techcv/manager/backend/internal/infrastructure/mysql/sqlc"
 )
 
+const (
+	// publicURLKeyIndex is the unique index guarding url_key.
+	publicURLKeyIndex = "idx_public_urls_url_key"
+	// publicURLSlugIndex is the primary key of public_url_slugs.
+	publicURLSlugIndex = "PRIMARY"
+	// publicURLEventIndex is the unique index keeping an event from being recorded twice.
+	publicURLEventIndex = "idx_public_url_events_public_url_id_occurred_at_reason"
+)
+
 // PublicURLRepository persists public URL entities in MySQL.
 type PublicURLRepository struct {
 	queries *mysqlsqlc.Queries
@@ -23,9 +34,27 @@ func NewPublicURLRepository(db *sql.DB) *PublicURLRepository {
 	}
 }
 
-// Create inserts a new public URL record and returns the generated identifier.
-func (r *PublicURLRepository) Create(ctx context.Context, urlKey string) (uint64, error) {
-	result, err := r.queries.CreatePublicURL(ctx, urlKey)
+// Create inserts a new active public URL and returns the generated identifier. A taken key
+// is reported as domain.ErrPublicURLKeyConflict.
+func (r *PublicURLRepository) Create(ctx context.Context, u domain.PublicURL) (uint64, error) {
+	owner, err := uuidv7.ToBytes(u.UserID)
+	if err != nil {
+		return 0, fmt.Errorf("convert user id: %w", err)
+	}
+
+	result, err := queriesFor(ctx, r.queries).CreatePublicURL(ctx, mysqlsqlc.CreatePublicURLParams{
+		UserID:          owner,
+		UrlKey:          u.URLKey,
+		Label:           u.Label,
+		CvVariant:       sql.NullString{String: u.CVVariant, Valid: u.CVVariant != ""},
+		PassphraseHash:  sql.NullString{String: u.PassphraseHash, Valid: u.PassphraseHash != ""},
+		ActivatesAt:     toNullTime(u.ActivatesAt),
+		ExpiresAt:       toNullTime(u.ExpiresAt),
+		NotifyFirstView: u.NotifyFirstView,
+	})
+	if isDuplicateKey(err, publicURLKeyIndex) {
+		return 0, domain.ErrPublicURLKeyConflict
+	}
 	if err != nil {
 		return 0, err
 	}
@@ -42,9 +71,38 @@ func (r *PublicURLRepository) Create(ctx context.Context, urlKey string) (uint64
 	return uint64(id), nil
 }
 
-// GetActive fetches the most recently updated active public URL.
-func (r *PublicURLRepository) GetActive(ctx context.Context) (*domain.PublicURL, error) {
-	record, err := r.queries.GetActivePublicURL(ctx)
+// Get fetches one of the user's public URLs by its identifier.
+func (r *PublicURLRepository) Get(ctx context.Context, userID string, id uint64) (*domain.PublicURL, error) {
+	if id > math.MaxInt64 {
+		return nil, fmt.Errorf("public URL id %d exceeds max int64", id)
+	}
+	owner, err := uuidv7.ToBytes(userID)
+	if err != nil {
+		return nil, fmt.Errorf("convert user id: %w", err)
+	}
+
+	record, err := queriesFor(ctx, r.queries).GetPublicURL(ctx, mysqlsqlc.GetPublicURLParams{
+		ID:     int64(id),
+		UserID: owner,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	entity, err := toDomainPublicURL(publicURLRecord(record))
+	if err != nil {
+		return nil, fmt.Errorf("convert record to domain model: %w", err)
+	}
+
+	return &entity, nil
+}
+
+// GetByKey fetches a public URL by its key regardless of owner or state.
+func (r *PublicURLRepository) GetByKey(ctx context.Context, urlKey string) (*domain.PublicURL, error) {
+	record, err := queriesFor(ctx, r.queries).GetPublicURLByKey(ctx, urlKey)
 	if errors.Is(err, sql.ErrNoRows) {
 		return nil, nil
 	}
@@ -52,7 +110,7 @@ func (r *PublicURLRepository) GetActive(ctx context.Context) (*domain.PublicURL,
 		return nil, err
 	}
 
-	entity, err := toDomainPublicURL(record)
+	entity, err := toDomainPublicURL(publicURLRecord(record))
 	if err != nil {
 		return nil, fmt.Errorf("convert record to domain model: %w", err)
 	}
@@ -60,16 +118,71 @@ func (r *PublicURLRepository) GetActive(ctx context.Context) (*domain.PublicURL,
 	return &entity, nil
 }
 
-// List returns all public URLs ordered by their update timestamp.
-func (r *PublicURLRepository) List(ctx context.Context) ([]domain.PublicURL, error) {
-	records, err := r.queries.ListPublicURLs(ctx)
+// GetBySlug fetches the public URL that a slug currently points to. Retired slugs match
+// until their redirect period ends at now, with retired reporting that the URL has moved.
+func (r *PublicURLRepository) GetBySlug(ctx context.Context, slug string, now time.Time) (*domain.PublicURL, bool, error) {
+	record, err := queriesFor(ctx, r.queries).GetPublicURLBySlug(ctx, mysqlsqlc.GetPublicURLBySlugParams{
+		Slug: slug,
+		Now:  sql.NullTime{Time: now, Valid: true},
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	entity, err := toDomainPublicURL(publicURLRecord{
+		ID:              record.ID,
+		UserID:          record.UserID,
+		UrlKey:          record.UrlKey,
+		Slug:            record.Slug,
+		Label:           record.Label,
+		CvVariant:       record.CvVariant,
+		PassphraseHash:  record.PassphraseHash,
+		IsActive:        record.IsActive,
+		ActivatesAt:     record.ActivatesAt,
+		ExpiresAt:       record.ExpiresAt,
+		NotifyFirstView: record.NotifyFirstView,
+		FirstViewedAt:   record.FirstViewedAt,
+		CreatedAt:       record.CreatedAt,
+		UpdatedAt:       record.UpdatedAt,
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("convert record to domain model: %w", err)
+	}
+
+	return &entity, record.RedirectUntil.Valid, nil
+}
+
+// LockOwner takes a row lock on the user so that concurrent changes to their public URLs
+// serialize. It only has an effect inside a transaction.
+func (r *PublicURLRepository) LockOwner(ctx context.Context, userID string) error {
+	owner, err := uuidv7.ToBytes(userID)
+	if err != nil {
+		return fmt.Errorf("convert user id: %w", err)
+	}
+	if _, err := queriesFor(ctx, r.queries).LockPublicURLOwner(ctx, owner); err != nil {
+		return fmt.Errorf("lock public URL owner: %w", err)
+	}
+	return nil
+}
+
+// List returns the user's public URLs ordered by their update timestamp.
+func (r *PublicURLRepository) List(ctx context.Context, userID string) ([]domain.PublicURL, error) {
+	owner, err := uuidv7.ToBytes(userID)
+	if err != nil {
+		return nil, fmt.Errorf("convert user id: %w", err)
+	}
+
+	records, err := queriesFor(ctx, r.queries).ListPublicURLs(ctx, owner)
 	if err != nil {
 		return nil, err
 	}
 
 	result := make([]domain.PublicURL, 0, len(records))
 	for _, record := range records {
-		entity, err := toDomainPublicURL(record)
+		entity, err := toDomainPublicURL(publicURLRecord(record))
 		if err != nil {
 			return nil, fmt.Errorf("convert record to domain model: %w", err)
 		}
@@ -79,24 +192,510 @@ func (r *PublicURLRepository) List(ctx context.Context) ([]domain.PublicURL, err
 	return result, nil
 }
 
-// Deactivate marks the specified public URL as inactive.
-func (r *PublicURLRepository) Deactivate(ctx context.Context, id uint64) error {
+// SetActive turns the specified public URL of the user on or off.
+func (r *PublicURLRepository) SetActive(ctx context.Context, userID string, id uint64, active bool) error {
+	if id > math.MaxInt64 {
+		return fmt.Errorf("public URL id %d exceeds max int64", id)
+	}
+	owner, err := uuidv7.ToBytes(userID)
+	if err != nil {
+		return fmt.Errorf("convert user id: %w", err)
+	}
+	return queriesFor(ctx, r.queries).SetPublicURLActive(ctx, mysqlsqlc.SetPublicURLActiveParams{
+		IsActive: active,
+		ID:       int64(id),
+		UserID:   owner,
+	})
+}
+
+// DeactivateAll turns off every active public URL of the user.
+func (r *PublicURLRepository) DeactivateAll(ctx context.Context, userID string) error {
+	owner, err := uuidv7.ToBytes(userID)
+	if err != nil {
+		return fmt.Errorf("convert user id: %w", err)
+	}
+	return queriesFor(ctx, r.queries).DeactivateUserPublicURLs(ctx, owner)
+}
+
+// UpdateDetails replaces the label, CV variant and first view notification setting of the
+// user's public URL.
+func (r *PublicURLRepository) UpdateDetails(ctx context.Context, userID string, id uint64, label, cvVariant string, notifyFirstView bool) error {
 	if id > math.MaxInt64 {
 		return fmt.Errorf("public URL id %d exceeds max int64", id)
 	}
-	return r.queries.DeactivatePublicURL(ctx, int64(id))
+	owner, err := uuidv7.ToBytes(userID)
+	if err != nil {
+		return fmt.Errorf("convert user id: %w", err)
+	}
+	return queriesFor(ctx, r.queries).UpdatePublicURLDetails(ctx, mysqlsqlc.UpdatePublicURLDetailsParams{
+		Label:           label,
+		CvVariant:       sql.NullString{String: cvVariant, Valid: cvVariant != ""},
+		NotifyFirstView: notifyFirstView,
+		ID:              int64(id),
+		UserID:          owner,
+	})
 }
 
-func toDomainPublicURL(model mysqlsqlc.PublicUrl) (domain.PublicURL, error) {
+// SetPassphrase replaces the passphrase hash of the user's public URL; an empty hash removes
+// the protection.
+func (r *PublicURLRepository) SetPassphrase(ctx context.Context, userID string, id uint64, passphraseHash string) error {
+	if id > math.MaxInt64 {
+		return fmt.Errorf("public URL id %d exceeds max int64", id)
+	}
+	owner, err := uuidv7.ToBytes(userID)
+	if err != nil {
+		return fmt.Errorf("convert user id: %w", err)
+	}
+	return queriesFor(ctx, r.queries).SetPublicURLPassphrase(ctx, mysqlsqlc.SetPublicURLPassphraseParams{
+		PassphraseHash: sql.NullString{String: passphraseHash, Valid: passphraseHash != ""},
+		ID:             int64(id),
+		UserID:         owner,
+	})
+}
+
+// ClaimSlug makes slug the current slug of the public URL. A slug that is in use or still
+// reserved by a redirect is reported as domain.ErrPublicURLSlugTaken.
+func (r *PublicURLRepository) ClaimSlug(ctx context.Context, id uint64, slug string) error {
+	if id > math.MaxInt64 {
+		return fmt.Errorf("public URL id %d exceeds max int64", id)
+	}
+	err := queriesFor(ctx, r.queries).ClaimPublicURLSlug(ctx, mysqlsqlc.ClaimPublicURLSlugParams{
+		Slug:        slug,
+		PublicUrlID: int64(id),
+	})
+	if isDuplicateKey(err, publicURLSlugIndex) {
+		return domain.ErrPublicURLSlugTaken
+	}
+	return err
+}
+
+// ReleaseSlug frees slug for the public URL when it is one of the URL's own retired slugs or
+// its redirect period ended at now. Slugs reserved for other URLs are left untouched.
+func (r *PublicURLRepository) ReleaseSlug(ctx context.Context, id uint64, slug string, now time.Time) error {
+	if id > math.MaxInt64 {
+		return fmt.Errorf("public URL id %d exceeds max int64", id)
+	}
+	return queriesFor(ctx, r.queries).ReleasePublicURLSlug(ctx, mysqlsqlc.ReleasePublicURLSlugParams{
+		Slug:        slug,
+		PublicUrlID: int64(id),
+		Now:         sql.NullTime{Time: now, Valid: true},
+	})
+}
+
+// RetireSlug turns the current slug of the public URL, if any, into a redirect that lasts
+// until redirectUntil.
+func (r *PublicURLRepository) RetireSlug(ctx context.Context, id uint64, redirectUntil time.Time) error {
+	if id > math.MaxInt64 {
+		return fmt.Errorf("public URL id %d exceeds max int64", id)
+	}
+	return queriesFor(ctx, r.queries).RetirePublicURLSlug(ctx, mysqlsqlc.RetirePublicURLSlugParams{
+		RedirectUntil: sql.NullTime{Time: redirectUntil, Valid: true},
+		PublicUrlID:   int64(id),
+	})
+}
+
+// SetSchedule replaces the viewing window of the user's public URL and re-arms the expiry
+// reminder.
+func (r *PublicURLRepository) SetSchedule(ctx context.Context, userID string, id uint64, activatesAt, expiresAt *time.Time) error {
+	if id > math.MaxInt64 {
+		return fmt.Errorf("public URL id %d exceeds max int64", id)
+	}
+	owner, err := uuidv7.ToBytes(userID)
+	if err != nil {
+		return fmt.Errorf("convert user id: %w", err)
+	}
+	return queriesFor(ctx, r.queries).SetPublicURLSchedule(ctx, mysqlsqlc.SetPublicURLScheduleParams{
+		ActivatesAt: toNullTime(activatesAt),
+		ExpiresAt:   toNullTime(expiresAt),
+		ID:          int64(id),
+		UserID:      owner,
+	})
+}
+
+// ListExpiring returns active URLs expiring in (now, until] whose owners have not been
+// reminded yet, soonest first.
+func (r *PublicURLRepository) ListExpiring(ctx context.Context, now, until time.Time) ([]domain.ExpiringPublicURL, error) {
+	records, err := queriesFor(ctx, r.queries).ListExpiringPublicURLs(ctx, mysqlsqlc.ListExpiringPublicURLsParams{
+		Now:   sql.NullTime{Time: now, Valid: true},
+		Until: sql.NullTime{Time: until, Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.ExpiringPublicURL, 0, len(records))
+	for _, record := range records {
+		entity, err := toDomainPublicURL(publicURLRecord{
+			ID:              record.ID,
+			UserID:          record.UserID,
+			UrlKey:          record.UrlKey,
+			Slug:            record.Slug,
+			Label:           record.Label,
+			CvVariant:       record.CvVariant,
+			PassphraseHash:  record.PassphraseHash,
+			IsActive:        record.IsActive,
+			ActivatesAt:     record.ActivatesAt,
+			ExpiresAt:       record.ExpiresAt,
+			NotifyFirstView: record.NotifyFirstView,
+			FirstViewedAt:   record.FirstViewedAt,
+			CreatedAt:       record.CreatedAt,
+			UpdatedAt:       record.UpdatedAt,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("convert record to domain model: %w", err)
+		}
+		result = append(result, domain.ExpiringPublicURL{PublicURL: entity, OwnerEmail: record.Email})
+	}
+
+	return result, nil
+}
+
+// ClaimExpiryReminder marks the reminder for the given expiry as sent. It reports false
+// when another worker claimed it first or the expiry has changed meanwhile.
+func (r *PublicURLRepository) ClaimExpiryReminder(ctx context.Context, id uint64, expiresAt, now time.Time) (bool, error) {
+	if id > math.MaxInt64 {
+		return false, fmt.Errorf("public URL id %d exceeds max int64", id)
+	}
+	affected, err := queriesFor(ctx, r.queries).ClaimPublicURLExpiryReminder(ctx, mysqlsqlc.ClaimPublicURLExpiryReminderParams{
+		ExpiryRemindedAt: sql.NullTime{Time: now, Valid: true},
+		ID:               int64(id),
+		ExpiresAt:        sql.NullTime{Time: expiresAt, Valid: true},
+	})
+	if err != nil {
+		return false, err
+	}
+	return affected == 1, nil
+}
+
+// ReleaseExpiryReminder undoes a claim so that the reminder is retried.
+func (r *PublicURLRepository) ReleaseExpiryReminder(ctx context.Context, id uint64) error {
+	if id > math.MaxInt64 {
+		return fmt.Errorf("public URL id %d exceeds max int64", id)
+	}
+	return queriesFor(ctx, r.queries).ReleasePublicURLExpiryReminder(ctx, int64(id))
+}
+
+// MarkFirstViewed records viewedAt as the first view of the public URL unless an earlier
+// view was recorded already.
+func (r *PublicURLRepository) MarkFirstViewed(ctx context.Context, id uint64, viewedAt time.Time) error {
+	if id > math.MaxInt64 {
+		return fmt.Errorf("public URL id %d exceeds max int64", id)
+	}
+	return queriesFor(ctx, r.queries).MarkPublicURLFirstViewed(ctx, mysqlsqlc.MarkPublicURLFirstViewedParams{
+		FirstViewedAt: sql.NullTime{Time: viewedAt, Valid: true},
+		ID:            int64(id),
+	})
+}
+
+// ListFirstViewed returns viewed URLs whose owners asked for the first view email and have
+// not received it yet, earliest view first.
+func (r *PublicURLRepository) ListFirstViewed(ctx context.Context) ([]domain.FirstViewedPublicURL, error) {
+	records, err := queriesFor(ctx, r.queries).ListFirstViewedPublicURLs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.FirstViewedPublicURL, 0, len(records))
+	for _, record := range records {
+		entity, err := toDomainPublicURL(publicURLRecord{
+			ID:              record.ID,
+			UserID:          record.UserID,
+			UrlKey:          record.UrlKey,
+			Slug:            record.Slug,
+			Label:           record.Label,
+			CvVariant:       record.CvVariant,
+			PassphraseHash:  record.PassphraseHash,
+			IsActive:        record.IsActive,
+			ActivatesAt:     record.ActivatesAt,
+			ExpiresAt:       record.ExpiresAt,
+			NotifyFirstView: record.NotifyFirstView,
+			FirstViewedAt:   record.FirstViewedAt,
+			CreatedAt:       record.CreatedAt,
+			UpdatedAt:       record.UpdatedAt,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("convert record to domain model: %w", err)
+		}
+		result = append(result, domain.FirstViewedPublicURL{PublicURL: entity, OwnerEmail: record.Email})
+	}
+
+	return result, nil
+}
+
+// ClaimFirstViewNotification marks the first view email as sent. It reports false when
+// another worker claimed it first.
+func (r *PublicURLRepository) ClaimFirstViewNotification(ctx context.Context, id uint64, now time.Time) (bool, error) {
+	if id > math.MaxInt64 {
+		return false, fmt.Errorf("public URL id %d exceeds max int64", id)
+	}
+	affected, err := queriesFor(ctx, r.queries).ClaimPublicURLFirstViewNotification(ctx, mysqlsqlc.ClaimPublicURLFirstViewNotificationParams{
+		FirstViewNotifiedAt: sql.NullTime{Time: now, Valid: true},
+		ID:                  int64(id),
+	})
+	if err != nil {
+		return false, err
+	}
+	return affected == 1, nil
+}
+
+// ReleaseFirstViewNotification undoes a claim so that the email is retried.
+func (r *PublicURLRepository) ReleaseFirstViewNotification(ctx context.Context, id uint64) error {
+	if id > math.MaxInt64 {
+		return fmt.Errorf("public URL id %d exceeds max int64", id)
+	}
+	return queriesFor(ctx, r.queries).ReleasePublicURLFirstViewNotification(ctx, int64(id))
+}
+
+// RecordView stores one view of a public URL.
+func (r *PublicURLRepository) RecordView(ctx context.Context, view domain.PublicURLView) error {
+	if view.PublicURLID > math.MaxInt64 {
+		return fmt.Errorf("public URL id %d exceeds max int64", view.PublicURLID)
+	}
+	return queriesFor(ctx, r.queries).CreatePublicURLView(ctx, mysqlsqlc.CreatePublicURLViewParams{
+		PublicUrlID:    int64(view.PublicURLID),
+		ViewedAt:       view.ViewedAt,
+		ReferrerHost:   sql.NullString{String: view.ReferrerHost, Valid: view.ReferrerHost != ""},
+		UserAgentClass: string(view.UserAgentClass),
+		Country:        sql.NullString{String: view.Country, Valid: view.Country != ""},
+		VisitorHash:    view.VisitorHash,
+	})
+}
+
+// DailyViews counts the views of a public URL per UTC day from since on, bots excluded.
+// Days without views are omitted.
+func (r *PublicURLRepository) DailyViews(ctx context.Context, id uint64, since time.Time) ([]domain.PublicURLDailyViews, error) {
+	if id > math.MaxInt64 {
+		return nil, fmt.Errorf("public URL id %d exceeds max int64", id)
+	}
+	records, err := queriesFor(ctx, r.queries).ListPublicURLDailyViews(ctx, mysqlsqlc.ListPublicURLDailyViewsParams{
+		PublicUrlID: int64(id),
+		Since:       since,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.PublicURLDailyViews, 0, len(records))
+	for _, record := range records {
+		day, err := toDay(record.Day)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, domain.PublicURLDailyViews{
+			Day:            day,
+			Views:          int(record.Views),
+			UniqueVisitors: int(record.UniqueVisitors),
+		})
+	}
+
+	return result, nil
+}
+
+// RecentViews returns up to limit of the latest views of a public URL, newest first. The
+// visitor hash is not loaded.
+func (r *PublicURLRepository) RecentViews(ctx context.Context, id uint64, limit int) ([]domain.PublicURLView, error) {
+	if id > math.MaxInt64 {
+		return nil, fmt.Errorf("public URL id %d exceeds max int64", id)
+	}
+	if limit < 0 || limit > math.MaxInt32 {
+		return nil, fmt.Errorf("view limit %d out of range", limit)
+	}
+	records, err := queriesFor(ctx, r.queries).ListRecentPublicURLViews(ctx, mysqlsqlc.ListRecentPublicURLViewsParams{
+		PublicUrlID: int64(id),
+		Limit:       int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.PublicURLView, 0, len(records))
+	for _, record := range records {
+		result = append(result, domain.PublicURLView{
+			PublicURLID:    id,
+			ViewedAt:       record.ViewedAt,
+			ReferrerHost:   record.ReferrerHost.String,
+			UserAgentClass: domain.UserAgentClass(record.UserAgentClass),
+			Country:        record.Country.String,
+		})
+	}
+
+	return result, nil
+}
+
+// RecordEvent stores an activation change of a public URL. An event of the same reason at
+// the same time is reported as domain.ErrPublicURLEventRecorded.
+func (r *PublicURLRepository) RecordEvent(ctx context.Context, event domain.PublicURLEvent) error {
+	if event.PublicURLID > math.MaxInt64 {
+		return fmt.Errorf("public URL id %d exceeds max int64", event.PublicURLID)
+	}
+	var actorUserID sql.NullString
+	if event.ActorUserID != "" {
+		key, err := uuidv7.ToBytes(event.ActorUserID)
+		if err != nil {
+			return err
+		}
+		actorUserID = sql.NullString{String: string(key), Valid: true}
+	}
+
+	err := queriesFor(ctx, r.queries).CreatePublicURLEvent(ctx, mysqlsqlc.CreatePublicURLEventParams{
+		PublicUrlID: int64(event.PublicURLID),
+		Reason:      string(event.Reason),
+		Active:      event.Active,
+		Actor:       string(event.Actor),
+		ActorUserID: actorUserID,
+		Note:        sql.NullString{String: event.Note, Valid: event.Note != ""},
+		OccurredAt:  event.OccurredAt,
+	})
+	if isDuplicateKey(err, publicURLEventIndex) {
+		return domain.ErrPublicURLEventRecorded
+	}
+	return err
+}
+
+// Events returns the activation changes of a public URL, oldest first.
+func (r *PublicURLRepository) Events(ctx context.Context, id uint64) ([]domain.PublicURLEvent, error) {
+	if id > math.MaxInt64 {
+		return nil, fmt.Errorf("public URL id %d exceeds max int64", id)
+	}
+	records, err := queriesFor(ctx, r.queries).ListPublicURLEvents(ctx, int64(id))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.PublicURLEvent, 0, len(records))
+	for _, record := range records {
+		if record.ID < 0 {
+			return nil, fmt.Errorf("public URL event id must be non-negative: %d", record.ID)
+		}
+		var actorUserID string
+		if record.ActorUserID.Valid {
+			if actorUserID, err = uuidv7.FromBytes([]byte(record.ActorUserID.String)); err != nil {
+				return nil, err
+			}
+		}
+		result = append(result, domain.PublicURLEvent{
+			ID:          uint64(record.ID),
+			PublicURLID: id,
+			Reason:      domain.PublicURLEventReason(record.Reason),
+			Active:      record.Active,
+			Actor:       domain.PublicURLActor(record.Actor),
+			ActorUserID: actorUserID,
+			Note:        record.Note.String,
+			OccurredAt:  record.OccurredAt,
+		})
+	}
+
+	return result, nil
+}
+
+// UnrecordedStarts returns the start events, not yet recorded, of active public URLs whose
+// viewing window started at or before now. Every change to an active URL is recorded, so a
+// URL without events since its window started, other than its expiry, has been on since
+// before the start and went live then.
+func (r *PublicURLRepository) UnrecordedStarts(ctx context.Context, now time.Time) ([]domain.PublicURLEvent, error) {
+	records, err := queriesFor(ctx, r.queries).ListUnrecordedPublicURLStarts(ctx, sql.NullTime{Time: now, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.PublicURLEvent, 0, len(records))
+	for _, record := range records {
+		if record.ID < 0 {
+			return nil, fmt.Errorf("public URL id must be non-negative: %d", record.ID)
+		}
+		result = append(result, domain.PublicURLEvent{
+			PublicURLID: uint64(record.ID),
+			Reason:      domain.PublicURLEventStarted,
+			Active:      true,
+			Actor:       domain.PublicURLActorSystem,
+			OccurredAt:  record.ActivatesAt.Time,
+		})
+	}
+
+	return result, nil
+}
+
+// UnrecordedExpiries returns the expiry events, not yet recorded, of active public URLs
+// that expired at or before now.
+func (r *PublicURLRepository) UnrecordedExpiries(ctx context.Context, now time.Time) ([]domain.PublicURLEvent, error) {
+	records, err := queriesFor(ctx, r.queries).ListUnrecordedPublicURLExpiries(ctx, sql.NullTime{Time: now, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.PublicURLEvent, 0, len(records))
+	for _, record := range records {
+		if record.ID < 0 {
+			return nil, fmt.Errorf("public URL id must be non-negative: %d", record.ID)
+		}
+		result = append(result, domain.PublicURLEvent{
+			PublicURLID: uint64(record.ID),
+			Reason:      domain.PublicURLEventExpired,
+			Actor:       domain.PublicURLActorSystem,
+			OccurredAt:  record.ExpiresAt.Time,
+		})
+	}
+
+	return result, nil
+}
+
+// publicURLRecord is the column set shared by the public URL read queries.
+type publicURLRecord struct {
+	ID              int64
+	UserID          []byte
+	UrlKey          string
+	Slug            sql.NullString
+	Label           string
+	CvVariant       sql.NullString
+	PassphraseHash  sql.NullString
+	IsActive        bool
+	ActivatesAt     sql.NullTime
+	ExpiresAt       sql.NullTime
+	NotifyFirstView bool
+	FirstViewedAt   sql.NullTime
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// toDay converts a DATE computed by a query, which sqlc leaves untyped. The driver returns
+// it as time.Time with parseTime enabled and as text otherwise.
+func toDay(value interface{}) (time.Time, error) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, nil
+	case []byte:
+		return time.Parse(time.DateOnly, string(v))
+	case string:
+		return time.Parse(time.DateOnly, v)
+	default:
+		return time.Time{}, fmt.Errorf("unexpected day value %T", value)
+	}
+}
+
+func toDomainPublicURL(model publicURLRecord) (domain.PublicURL, error) {
 	if model.ID < 0 {
 		return domain.PublicURL{}, fmt.Errorf("public URL id must be non-negative: %d", model.ID)
 	}
 
+	userID, err := uuidv7.FromBytes(model.UserID)
+	if err != nil {
+		return domain.PublicURL{}, fmt.Errorf("convert user id: %w", err)
+	}
+
 	return domain.PublicURL{
-		ID:        uint64(model.ID),
-		URLKey:    model.UrlKey,
-		IsActive:  model.IsActive,
-		CreatedAt: model.CreatedAt,
-		UpdatedAt: model.UpdatedAt,
+		ID:              uint64(model.ID),
+		UserID:          userID,
+		URLKey:          model.UrlKey,
+		Slug:            model.Slug.String,
+		Label:           model.Label,
+		CVVariant:       model.CvVariant.String,
+		PassphraseHash:  model.PassphraseHash.String,
+		IsActive:        model.IsActive,
+		ActivatesAt:     fromNullTime(model.ActivatesAt),
+		ExpiresAt:       fromNullTime(model.ExpiresAt),
+		NotifyFirstView: model.NotifyFirstView,
+		FirstViewedAt:   fromNullTime(model.FirstViewedAt),
+		CreatedAt:       model.CreatedAt,
+		UpdatedAt:       model.UpdatedAt,
 	}, nil
 }
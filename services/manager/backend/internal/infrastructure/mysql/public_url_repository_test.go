@@ -2,69 +2,236 @@ package mysql
 
 import (
 	"context"
+	"errors"
 	"regexp"
 	"testing"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	gomysql "github.com/go-sql-driver/mysql"
+
+	"github.com/sky0621/techcv/manager/backend/internal/domain"
+	"github.com/sky0621/techcv/manager/backend/internal/domain/uuidv7"
+	"github.com/sky0621/techcv/manager/backend/internal/infrastructure/transaction"
 )
 
+const ownerID = "0190c8a1-b2c3-7d4e-8f00-112233445566"
+
+var publicURLColumns = []string{
+	"id", "user_id", "url_key", "slug", "label", "cv_variant", "passphrase_hash", "is_active", "activates_at", "expires_at", "notify_first_view", "first_viewed_at", "created_at", "updated_at",
+}
+
 const (
-	getActivePublicURLQuery = "-- name: GetActivePublicURL :one\n" +
-		"SELECT\n" +
-		"  id,\n" +
-		"  url_key,\n" +
-		"  is_active,\n" +
-		"  created_at,\n" +
-		"  updated_at\n" +
-		"FROM public_urls\n" +
-		"WHERE is_active = TRUE\n" +
-		"ORDER BY updated_at DESC\n" +
-		"LIMIT 1\n"
 	createPublicURLQuery = "-- name: CreatePublicURL :execresult\n" +
-		"INSERT INTO public_urls (url_key)\n" +
-		"VALUES (?)\n"
+		"INSERT INTO public_urls (\n" +
+		"  user_id,\n" +
+		"  url_key,\n" +
+		"  label,\n" +
+		"  cv_variant,\n" +
+		"  passphrase_hash,\n" +
+		"  activates_at,\n" +
+		"  expires_at,\n" +
+		"  notify_first_view\n" +
+		") VALUES (?, ?, ?, ?, ?, ?, ?, ?)\n"
 	listPublicURLsQuery = "-- name: ListPublicURLs :many\n" +
 		"SELECT\n" +
-		"  id,\n" +
-		"  url_key,\n" +
-		"  is_active,\n" +
-		"  created_at,\n" +
-		"  updated_at\n" +
-		"FROM public_urls\n" +
-		"ORDER BY updated_at DESC\n"
-	deactivatePublicURLQuery = "-- name: DeactivatePublicURL :exec\n" +
+		"  p.id,\n" +
+		"  p.user_id,\n" +
+		"  p.url_key,\n" +
+		"  s.slug,\n" +
+		"  p.label,\n" +
+		"  p.cv_variant,\n" +
+		"  p.passphrase_hash,\n" +
+		"  p.is_active,\n" +
+		"  p.activates_at,\n" +
+		"  p.expires_at,\n" +
+		"  p.notify_first_view,\n" +
+		"  p.first_viewed_at,\n" +
+		"  p.created_at,\n" +
+		"  p.updated_at\n" +
+		"FROM public_urls p\n" +
+		"LEFT JOIN public_url_slugs s\n" +
+		"  ON s.public_url_id = p.id\n" +
+		"  AND s.redirect_until IS NULL\n" +
+		"WHERE p.user_id = ?\n" +
+		"ORDER BY p.updated_at DESC\n"
+	getPublicURLQuery = "-- name: GetPublicURL :one\n" +
+		"SELECT\n" +
+		"  p.id,\n" +
+		"  p.user_id,\n" +
+		"  p.url_key,\n" +
+		"  s.slug,\n" +
+		"  p.label,\n" +
+		"  p.cv_variant,\n" +
+		"  p.passphrase_hash,\n" +
+		"  p.is_active,\n" +
+		"  p.activates_at,\n" +
+		"  p.expires_at,\n" +
+		"  p.notify_first_view,\n" +
+		"  p.first_viewed_at,\n" +
+		"  p.created_at,\n" +
+		"  p.updated_at\n" +
+		"FROM public_urls p\n" +
+		"LEFT JOIN public_url_slugs s\n" +
+		"  ON s.public_url_id = p.id\n" +
+		"  AND s.redirect_until IS NULL\n" +
+		"WHERE p.id = ?\n" +
+		"  AND p.user_id = ?\n"
+	lockPublicURLOwnerQuery = "-- name: LockPublicURLOwner :one\n" +
+		"SELECT id\n" +
+		"FROM users\n" +
+		"WHERE id = ?\n" +
+		"FOR UPDATE\n"
+	setPublicURLScheduleQuery = "-- name: SetPublicURLSchedule :exec\n" +
+		"UPDATE public_urls\n" +
+		"SET activates_at = ?,\n" +
+		"    expires_at = ?,\n" +
+		"    expiry_reminded_at = NULL,\n" +
+		"    updated_at = CURRENT_TIMESTAMP(6)\n" +
+		"WHERE id = ?\n" +
+		"  AND user_id = ?\n"
+	claimPublicURLExpiryReminderQuery = "-- name: ClaimPublicURLExpiryReminder :execrows\n" +
+		"UPDATE public_urls\n" +
+		"SET expiry_reminded_at = ?,\n" +
+		"    updated_at = updated_at\n" +
+		"WHERE id = ?\n" +
+		"  AND expires_at = ?\n" +
+		"  AND expiry_reminded_at IS NULL\n"
+	setPublicURLPassphraseQuery = "-- name: SetPublicURLPassphrase :exec\n" +
+		"UPDATE public_urls\n" +
+		"SET passphrase_hash = ?,\n" +
+		"    updated_at = CURRENT_TIMESTAMP(6)\n" +
+		"WHERE id = ?\n" +
+		"  AND user_id = ?\n"
+	setPublicURLActiveQuery = "-- name: SetPublicURLActive :exec\n" +
+		"UPDATE public_urls\n" +
+		"SET is_active = ?,\n" +
+		"    updated_at = CURRENT_TIMESTAMP(6)\n" +
+		"WHERE id = ?\n" +
+		"  AND user_id = ?\n"
+	claimPublicURLSlugQuery = "-- name: ClaimPublicURLSlug :exec\n" +
+		"INSERT INTO public_url_slugs (\n" +
+		"  slug,\n" +
+		"  public_url_id\n" +
+		") VALUES (?, ?)\n"
+	getPublicURLBySlugQuery = "-- name: GetPublicURLBySlug :one\n" +
+		"SELECT\n" +
+		"  p.id,\n" +
+		"  p.user_id,\n" +
+		"  p.url_key,\n" +
+		"  cur.slug,\n" +
+		"  p.label,\n" +
+		"  p.cv_variant,\n" +
+		"  p.passphrase_hash,\n" +
+		"  p.is_active,\n" +
+		"  p.activates_at,\n" +
+		"  p.expires_at,\n" +
+		"  p.notify_first_view,\n" +
+		"  p.first_viewed_at,\n" +
+		"  p.created_at,\n" +
+		"  p.updated_at,\n" +
+		"  s.redirect_until\n" +
+		"FROM public_url_slugs s\n" +
+		"JOIN public_urls p ON p.id = s.public_url_id\n" +
+		"LEFT JOIN public_url_slugs cur\n" +
+		"  ON cur.public_url_id = p.id\n" +
+		"  AND cur.redirect_until IS NULL\n" +
+		"WHERE s.slug = ?\n" +
+		"  AND (s.redirect_until IS NULL OR s.redirect_until > ?)\n"
+	createPublicURLViewQuery = "-- name: CreatePublicURLView :exec\n" +
+		"INSERT INTO public_url_views (\n" +
+		"  public_url_id,\n" +
+		"  viewed_at,\n" +
+		"  referrer_host,\n" +
+		"  user_agent_class,\n" +
+		"  country,\n" +
+		"  visitor_hash\n" +
+		") VALUES (?, ?, ?, ?, ?, ?)\n"
+	listPublicURLDailyViewsQuery = "-- name: ListPublicURLDailyViews :many\n" +
+		"SELECT\n" +
+		"  CAST(viewed_at AS DATE) AS day,\n" +
+		"  COUNT(*) AS views,\n" +
+		"  COUNT(DISTINCT visitor_hash) AS unique_visitors\n" +
+		"FROM public_url_views\n" +
+		"WHERE public_url_id = ?\n" +
+		"  AND viewed_at >= ?\n" +
+		"  AND user_agent_class <> 'bot'\n" +
+		"GROUP BY day\n" +
+		"ORDER BY day\n"
+	deactivateUserPublicURLsQuery = "-- name: DeactivateUserPublicURLs :exec\n" +
 		"UPDATE public_urls\n" +
 		"SET is_active = FALSE,\n" +
 		"    updated_at = CURRENT_TIMESTAMP(6)\n" +
-		"WHERE id = ?\n"
+		"WHERE user_id = ?\n" +
+		"  AND is_active = TRUE\n"
+	createPublicURLEventQuery = "-- name: CreatePublicURLEvent :exec\n" +
+		"INSERT INTO public_url_events (\n" +
+		"  public_url_id,\n" +
+		"  reason,\n" +
+		"  active,\n" +
+		"  actor,\n" +
+		"  actor_user_id,\n" +
+		"  note,\n" +
+		"  occurred_at\n" +
+		") VALUES (?, ?, ?, ?, ?, ?, ?)\n"
+	listPublicURLEventsQuery = "-- name: ListPublicURLEvents :many\n" +
+		"SELECT\n" +
+		"  id,\n" +
+		"  public_url_id,\n" +
+		"  reason,\n" +
+		"  active,\n" +
+		"  actor,\n" +
+		"  actor_user_id,\n" +
+		"  note,\n" +
+		"  occurred_at\n" +
+		"FROM public_url_events\n" +
+		"WHERE public_url_id = ?\n" +
+		"ORDER BY occurred_at, id\n"
+	listUnrecordedPublicURLStartsQuery = "-- name: ListUnrecordedPublicURLStarts :many\n" +
+		"SELECT\n" +
+		"  p.id,\n" +
+		"  p.activates_at\n" +
+		"FROM public_urls p\n" +
+		"WHERE p.is_active = TRUE\n" +
+		"  AND p.activates_at <= ?\n" +
+		"  AND NOT EXISTS (\n" +
+		"    SELECT 1\n" +
+		"    FROM public_url_events e\n" +
+		"    WHERE e.public_url_id = p.id\n" +
+		"      AND e.occurred_at >= p.activates_at\n" +
+		"      AND e.reason <> 'expired'\n" +
+		"  )\n" +
+		"ORDER BY p.activates_at\n"
 )
 
-func TestPublicURLRepositoryGetActive(t *testing.T) {
+func TestPublicURLRepositoryGet(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
 		t.Fatalf("failed to create sqlmock: %v", err)
 	}
 	defer func() {
+		mock.ExpectClose()
 		if closeErr := db.Close(); closeErr != nil {
 			t.Fatalf("failed to close db: %v", closeErr)
 		}
 	}()
 
 	now := time.Now()
+	owner := ownerBytes(t)
 	rows := sqlmock.
-		NewRows([]string{"id", "url_key", "is_active", "created_at", "updated_at"}).
-		AddRow(int64(1), "active-key", true, now, now)
+		NewRows(publicURLColumns).
+		AddRow(int64(1), owner, "active-key", nil, "Company A", "backend", "$2a$10$hash", true, nil, nil, true, now, now, now)
 
-	mock.ExpectQuery(regexp.QuoteMeta(getActivePublicURLQuery)).WillReturnRows(rows)
+	mock.ExpectQuery(regexp.QuoteMeta(getPublicURLQuery)).WithArgs(int64(1), owner).WillReturnRows(rows)
 
 	repo := NewPublicURLRepository(db)
-	result, err := repo.GetActive(context.Background())
+	result, err := repo.Get(context.Background(), ownerID, 1)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
-	if result == nil || result.URLKey != "active-key" {
+	if result == nil || result.URLKey != "active-key" || result.UserID != ownerID || result.Label != "Company A" || result.CVVariant != "backend" || !result.Protected() ||
+		!result.NotifyFirstView || result.FirstViewedAt == nil || !result.FirstViewedAt.Equal(now) {
 		t.Fatalf("unexpected result: %+v", result)
 	}
 
@@ -79,17 +246,24 @@ func TestPublicURLRepositoryCreate(t *testing.T) {
 		t.Fatalf("failed to create sqlmock: %v", err)
 	}
 	defer func() {
+		mock.ExpectClose()
 		if closeErr := db.Close(); closeErr != nil {
 			t.Fatalf("failed to close db: %v", closeErr)
 		}
 	}()
 
 	mock.ExpectExec(regexp.QuoteMeta(createPublicURLQuery)).
-		WithArgs("new-key").
+		WithArgs(ownerBytes(t), "new-key", "Company A", nil, "$2a$10$hash", nil, nil, true).
 		WillReturnResult(sqlmock.NewResult(10, 1))
 
 	repo := NewPublicURLRepository(db)
-	id, err := repo.Create(context.Background(), "new-key")
+	id, err := repo.Create(context.Background(), domain.PublicURL{
+		UserID:          ownerID,
+		URLKey:          "new-key",
+		Label:           "Company A",
+		PassphraseHash:  "$2a$10$hash",
+		NotifyFirstView: true,
+	})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -103,27 +277,114 @@ func TestPublicURLRepositoryCreate(t *testing.T) {
 	}
 }
 
+func TestPublicURLRepositoryCreateKeyConflict(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer func() {
+		mock.ExpectClose()
+		if closeErr := db.Close(); closeErr != nil {
+			t.Fatalf("failed to close db: %v", closeErr)
+		}
+	}()
+
+	mock.ExpectExec(regexp.QuoteMeta(createPublicURLQuery)).
+		WithArgs(ownerBytes(t), "taken", "", nil, nil, nil, nil, false).
+		WillReturnError(&gomysql.MySQLError{
+			Number:  mysqlErrDuplicateEntry,
+			Message: "Duplicate entry 'taken' for key 'public_urls.idx_public_urls_url_key'",
+		})
+
+	repo := NewPublicURLRepository(db)
+	_, err = repo.Create(context.Background(), domain.PublicURL{UserID: ownerID, URLKey: "taken"})
+	if !errors.Is(err, domain.ErrPublicURLKeyConflict) {
+		t.Fatalf("expected key conflict, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestPublicURLRepositoryCreateWithinTransaction(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer func() {
+		mock.ExpectClose()
+		if closeErr := db.Close(); closeErr != nil {
+			t.Fatalf("failed to close db: %v", closeErr)
+		}
+	}()
+
+	now := time.Now()
+	owner := ownerBytes(t)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(lockPublicURLOwnerQuery)).
+		WithArgs(owner).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(owner))
+	mock.ExpectExec(regexp.QuoteMeta(createPublicURLQuery)).
+		WithArgs(owner, "new-key", "Company A", nil, nil, nil, nil, false).
+		WillReturnResult(sqlmock.NewResult(12, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(getPublicURLQuery)).
+		WithArgs(int64(12), owner).
+		WillReturnRows(sqlmock.
+			NewRows(publicURLColumns).
+			AddRow(int64(12), owner, "new-key", nil, "Company A", nil, nil, true, nil, nil, false, nil, now, now))
+	mock.ExpectCommit()
+
+	repo := NewPublicURLRepository(db)
+	var created *domain.PublicURL
+	err = transaction.NewSQLManager(db).WithinTransaction(context.Background(), func(ctx context.Context) error {
+		if err := repo.LockOwner(ctx, ownerID); err != nil {
+			return err
+		}
+		id, err := repo.Create(ctx, domain.PublicURL{UserID: ownerID, URLKey: "new-key", Label: "Company A"})
+		if err != nil {
+			return err
+		}
+		created, err = repo.Get(ctx, ownerID, id)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if created == nil || created.ID != 12 || created.URLKey != "new-key" {
+		t.Fatalf("unexpected result: %+v", created)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
 func TestPublicURLRepositoryList(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
 		t.Fatalf("failed to create sqlmock: %v", err)
 	}
 	defer func() {
+		mock.ExpectClose()
 		if closeErr := db.Close(); closeErr != nil {
 			t.Fatalf("failed to close db: %v", closeErr)
 		}
 	}()
 
 	now := time.Now()
+	owner := ownerBytes(t)
 	rows := sqlmock.
-		NewRows([]string{"id", "url_key", "is_active", "created_at", "updated_at"}).
-		AddRow(int64(1), "first", true, now, now).
-		AddRow(int64(2), "second", false, now, now)
+		NewRows(publicURLColumns).
+		AddRow(int64(1), owner, "first", "taro", "Company A", nil, nil, true, nil, nil, false, nil, now, now).
+		AddRow(int64(2), owner, "second", nil, "Company A", nil, nil, false, nil, nil, false, nil, now, now)
 
-	mock.ExpectQuery(regexp.QuoteMeta(listPublicURLsQuery)).WillReturnRows(rows)
+	mock.ExpectQuery(regexp.QuoteMeta(listPublicURLsQuery)).WithArgs(owner).WillReturnRows(rows)
 
 	repo := NewPublicURLRepository(db)
-	results, err := repo.List(context.Background())
+	results, err := repo.List(context.Background(), ownerID)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -132,7 +393,7 @@ func TestPublicURLRepositoryList(t *testing.T) {
 		t.Fatalf("unexpected result length: got %d, want %d", len(results), 2)
 	}
 
-	if results[0].URLKey != "first" || !results[0].IsActive {
+	if results[0].URLKey != "first" || results[0].Slug != "taro" || results[0].Address() != "taro" || !results[0].IsActive {
 		t.Fatalf("unexpected first result: %+v", results[0])
 	}
 
@@ -141,23 +402,398 @@ func TestPublicURLRepositoryList(t *testing.T) {
 	}
 }
 
-func TestPublicURLRepositoryDeactivate(t *testing.T) {
+func TestPublicURLRepositorySetActive(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer func() {
+		mock.ExpectClose()
+		if closeErr := db.Close(); closeErr != nil {
+			t.Fatalf("failed to close db: %v", closeErr)
+		}
+	}()
+
+	mock.ExpectExec(regexp.QuoteMeta(setPublicURLActiveQuery)).
+		WithArgs(false, int64(5), ownerBytes(t)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(deactivateUserPublicURLsQuery)).
+		WithArgs(ownerBytes(t)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	repo := NewPublicURLRepository(db)
+	if err := repo.SetActive(context.Background(), ownerID, 5, false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := repo.DeactivateAll(context.Background(), ownerID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestPublicURLRepositoryGetBySlug(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer func() {
+		mock.ExpectClose()
+		if closeErr := db.Close(); closeErr != nil {
+			t.Fatalf("failed to close db: %v", closeErr)
+		}
+	}()
+
+	now := time.Now()
+	owner := ownerBytes(t)
+	rows := sqlmock.
+		NewRows(append(publicURLColumns, "redirect_until")).
+		AddRow(int64(1), owner, "active-key", "taro", "Company A", nil, nil, true, nil, nil, false, nil, now, now, now.Add(time.Hour))
+
+	mock.ExpectQuery(regexp.QuoteMeta(getPublicURLBySlugQuery)).WithArgs("taro-yamada", now).WillReturnRows(rows)
+
+	repo := NewPublicURLRepository(db)
+	result, retired, err := repo.GetBySlug(context.Background(), "taro-yamada", now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result == nil || result.ID != 1 || result.Slug != "taro" || !retired {
+		t.Fatalf("unexpected result: %+v, retired=%v", result, retired)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestPublicURLRepositoryClaimSlugTaken(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer func() {
+		mock.ExpectClose()
+		if closeErr := db.Close(); closeErr != nil {
+			t.Fatalf("failed to close db: %v", closeErr)
+		}
+	}()
+
+	mock.ExpectExec(regexp.QuoteMeta(claimPublicURLSlugQuery)).
+		WithArgs("taro", int64(1)).
+		WillReturnError(&gomysql.MySQLError{
+			Number:  mysqlErrDuplicateEntry,
+			Message: "Duplicate entry 'taro' for key 'public_url_slugs.PRIMARY'",
+		})
+
+	repo := NewPublicURLRepository(db)
+	if err := repo.ClaimSlug(context.Background(), 1, "taro"); !errors.Is(err, domain.ErrPublicURLSlugTaken) {
+		t.Fatalf("expected slug taken, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestPublicURLRepositoryRecordView(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer func() {
+		mock.ExpectClose()
+		if closeErr := db.Close(); closeErr != nil {
+			t.Fatalf("failed to close db: %v", closeErr)
+		}
+	}()
+
+	now := time.Now()
+	hash := []byte("visitor-hash")
+	mock.ExpectExec(regexp.QuoteMeta(createPublicURLViewQuery)).
+		WithArgs(int64(3), now, "linkedin.com", "mobile", nil, hash).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	repo := NewPublicURLRepository(db)
+	err = repo.RecordView(context.Background(), domain.PublicURLView{
+		PublicURLID:    3,
+		ViewedAt:       now,
+		ReferrerHost:   "linkedin.com",
+		UserAgentClass: domain.UserAgentMobile,
+		VisitorHash:    hash,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestPublicURLRepositoryDailyViews(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer func() {
+		mock.ExpectClose()
+		if closeErr := db.Close(); closeErr != nil {
+			t.Fatalf("failed to close db: %v", closeErr)
+		}
+	}()
+
+	since := time.Date(2026, 9, 29, 0, 0, 0, 0, time.UTC)
+	rows := sqlmock.
+		NewRows([]string{"day", "views", "unique_visitors"}).
+		AddRow(since, int64(3), int64(2)).
+		// Without parseTime the driver returns the DATE as text.
+		AddRow([]byte("2026-09-30"), int64(1), int64(1))
+	mock.ExpectQuery(regexp.QuoteMeta(listPublicURLDailyViewsQuery)).WithArgs(int64(3), since).WillReturnRows(rows)
+
+	repo := NewPublicURLRepository(db)
+	results, err := repo.DailyViews(context.Background(), 3, since)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(results) != 2 || !results[0].Day.Equal(since) || results[0].Views != 3 || results[0].UniqueVisitors != 2 ||
+		!results[1].Day.Equal(since.AddDate(0, 0, 1)) {
+		t.Fatalf("unexpected results: %+v", results)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestPublicURLRepositoryRecordEventAlreadyRecorded(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer func() {
+		mock.ExpectClose()
+		if closeErr := db.Close(); closeErr != nil {
+			t.Fatalf("failed to close db: %v", closeErr)
+		}
+	}()
+
+	expiredAt := time.Now()
+	mock.ExpectExec(regexp.QuoteMeta(createPublicURLEventQuery)).
+		WithArgs(int64(3), "expired", false, "system", nil, nil, expiredAt).
+		WillReturnError(&gomysql.MySQLError{
+			Number:  mysqlErrDuplicateEntry,
+			Message: "Duplicate entry '3-2026-10-01 09:00:00.000000-expired' for key 'public_url_events.idx_public_url_events_public_url_id_occurred_at_reason'",
+		})
+
+	repo := NewPublicURLRepository(db)
+	err = repo.RecordEvent(context.Background(), domain.PublicURLEvent{
+		PublicURLID: 3,
+		Reason:      domain.PublicURLEventExpired,
+		Actor:       domain.PublicURLActorSystem,
+		OccurredAt:  expiredAt,
+	})
+	if !errors.Is(err, domain.ErrPublicURLEventRecorded) {
+		t.Fatalf("expected event already recorded, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestPublicURLRepositoryRecordEventByOwner(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer func() {
+		mock.ExpectClose()
+		if closeErr := db.Close(); closeErr != nil {
+			t.Fatalf("failed to close db: %v", closeErr)
+		}
+	}()
+
+	ownerBytes, err := uuidv7.ToBytes(ownerID)
+	if err != nil {
+		t.Fatalf("failed to convert owner id: %v", err)
+	}
+	revokedAt := time.Now()
+	mock.ExpectExec(regexp.QuoteMeta(createPublicURLEventQuery)).
+		WithArgs(int64(3), "revoked", false, "owner", string(ownerBytes), nil, revokedAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	repo := NewPublicURLRepository(db)
+	err = repo.RecordEvent(context.Background(), domain.PublicURLEvent{
+		PublicURLID: 3,
+		Reason:      domain.PublicURLEventRevoked,
+		Actor:       domain.PublicURLActorOwner,
+		ActorUserID: ownerID,
+		OccurredAt:  revokedAt,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestPublicURLRepositoryEvents(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer func() {
+		mock.ExpectClose()
+		if closeErr := db.Close(); closeErr != nil {
+			t.Fatalf("failed to close db: %v", closeErr)
+		}
+	}()
+
+	ownerBytes, err := uuidv7.ToBytes(ownerID)
+	if err != nil {
+		t.Fatalf("failed to convert owner id: %v", err)
+	}
+	now := time.Now()
+	rows := sqlmock.NewRows([]string{"id", "public_url_id", "reason", "active", "actor", "actor_user_id", "note", "occurred_at"}).
+		AddRow(int64(1), int64(3), "created", true, "owner", ownerBytes, nil, now).
+		AddRow(int64(2), int64(3), "admin_takedown", false, "admin", nil, "phishing report", now.Add(time.Hour))
+	mock.ExpectQuery(regexp.QuoteMeta(listPublicURLEventsQuery)).WithArgs(int64(3)).WillReturnRows(rows)
+
+	repo := NewPublicURLRepository(db)
+	events, err := repo.Events(context.Background(), 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("expected two events, got %+v", events)
+	}
+	if events[0].Reason != domain.PublicURLEventCreated || !events[0].Active || events[0].ActorUserID != ownerID || events[0].Note != "" {
+		t.Fatalf("unexpected owner event: %+v", events[0])
+	}
+	if events[1].Actor != domain.PublicURLActorAdmin || events[1].Active || events[1].ActorUserID != "" || events[1].Note != "phishing report" {
+		t.Fatalf("unexpected admin event: %+v", events[1])
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestPublicURLRepositoryUnrecordedStarts(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer func() {
+		mock.ExpectClose()
+		if closeErr := db.Close(); closeErr != nil {
+			t.Fatalf("failed to close db: %v", closeErr)
+		}
+	}()
+
+	now := time.Now()
+	startedAt := now.Add(-time.Hour)
+	rows := sqlmock.NewRows([]string{"id", "activates_at"}).AddRow(int64(3), startedAt)
+	mock.ExpectQuery(regexp.QuoteMeta(listUnrecordedPublicURLStartsQuery)).
+		WithArgs(now).
+		WillReturnRows(rows)
+
+	repo := NewPublicURLRepository(db)
+	starts, err := repo.UnrecordedStarts(context.Background(), now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(starts) != 1 {
+		t.Fatalf("expected one start, got %+v", starts)
+	}
+	start := starts[0]
+	if start.PublicURLID != 3 || start.Reason != domain.PublicURLEventStarted || !start.Active ||
+		start.Actor != domain.PublicURLActorSystem || !start.OccurredAt.Equal(startedAt) {
+		t.Fatalf("unexpected start: %+v", start)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestPublicURLRepositoryRejectsInvalidOwner(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer func() {
+		mock.ExpectClose()
+		if closeErr := db.Close(); closeErr != nil {
+			t.Fatalf("failed to close db: %v", closeErr)
+		}
+	}()
+
+	repo := NewPublicURLRepository(db)
+	if _, err := repo.List(context.Background(), "not-a-uuid"); err == nil {
+		t.Fatalf("expected error for invalid owner id")
+	}
+}
+
+func TestPublicURLRepositorySetSchedule(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer func() {
+		mock.ExpectClose()
+		if closeErr := db.Close(); closeErr != nil {
+			t.Fatalf("failed to close db: %v", closeErr)
+		}
+	}()
+
+	expiresAt := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	mock.ExpectExec(regexp.QuoteMeta(setPublicURLScheduleQuery)).
+		WithArgs(nil, expiresAt, int64(3), ownerBytes(t)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	repo := NewPublicURLRepository(db)
+	if err := repo.SetSchedule(context.Background(), ownerID, 3, nil, &expiresAt); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestPublicURLRepositorySetPassphrase(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
 		t.Fatalf("failed to create sqlmock: %v", err)
 	}
 	defer func() {
+		mock.ExpectClose()
 		if closeErr := db.Close(); closeErr != nil {
 			t.Fatalf("failed to close db: %v", closeErr)
 		}
 	}()
 
-	mock.ExpectExec(regexp.QuoteMeta(deactivatePublicURLQuery)).
-		WithArgs(int64(5)).
+	mock.ExpectExec(regexp.QuoteMeta(setPublicURLPassphraseQuery)).
+		WithArgs("$2a$10$hash", int64(3), ownerBytes(t)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(setPublicURLPassphraseQuery)).
+		WithArgs(nil, int64(3), ownerBytes(t)).
 		WillReturnResult(sqlmock.NewResult(0, 1))
 
 	repo := NewPublicURLRepository(db)
-	if err := repo.Deactivate(context.Background(), 5); err != nil {
+	if err := repo.SetPassphrase(context.Background(), ownerID, 3, "$2a$10$hash"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := repo.SetPassphrase(context.Background(), ownerID, 3, ""); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
@@ -165,3 +801,49 @@ func TestPublicURLRepositoryDeactivate(t *testing.T) {
 		t.Fatalf("unmet expectations: %v", err)
 	}
 }
+
+func TestPublicURLRepositoryClaimExpiryReminder(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer func() {
+		mock.ExpectClose()
+		if closeErr := db.Close(); closeErr != nil {
+			t.Fatalf("failed to close db: %v", closeErr)
+		}
+	}()
+
+	now := time.Date(2026, 10, 31, 0, 0, 0, 0, time.UTC)
+	expiresAt := now.Add(12 * time.Hour)
+	mock.ExpectExec(regexp.QuoteMeta(claimPublicURLExpiryReminderQuery)).
+		WithArgs(now, int64(3), expiresAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(claimPublicURLExpiryReminderQuery)).
+		WithArgs(now, int64(3), expiresAt).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	repo := NewPublicURLRepository(db)
+	for i, want := range []bool{true, false} {
+		claimed, err := repo.ClaimExpiryReminder(context.Background(), 3, expiresAt, now)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if claimed != want {
+			t.Fatalf("claim %d = %v, want %v", i, claimed, want)
+		}
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func ownerBytes(t *testing.T) []byte {
+	t.Helper()
+	b, err := uuidv7.ToBytes(ownerID)
+	if err != nil {
+		t.Fatalf("failed to convert owner id: %v", err)
+	}
+	return b
+}
@@ -0,0 +1,46 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	mysqlsqlc "github.com/sky0621/techcv/manager/backend/internal/infrastructure/mysql/sqlc"
+)
+
+// RateLimitRepository keeps rate limit windows in MySQL so that every instance counts
+// against the same limit.
+type RateLimitRepository struct {
+	queries *mysqlsqlc.Queries
+}
+
+// NewRateLimitRepository constructs a new repository backed by sqlc queries.
+func NewRateLimitRepository(db *sql.DB) *RateLimitRepository {
+	return &RateLimitRepository{
+		queries: mysqlsqlc.New(db),
+	}
+}
+
+// Hit records an attempt for key and returns the attempts in its current window. The
+// count is read after the increment, so an attempt racing with it can only make the
+// result larger, never let an attempt over the limit through.
+func (r *RateLimitRepository) Hit(ctx context.Context, key string, now time.Time, period time.Duration) (int, error) {
+	queries := queriesFor(ctx, r.queries)
+
+	if err := queries.DeleteExpiredRateLimitWindows(ctx, now); err != nil {
+		return 0, fmt.Errorf("delete expired rate limit windows: %w", err)
+	}
+	if err := queries.HitRateLimitWindow(ctx, mysqlsqlc.HitRateLimitWindowParams{
+		LimiterKey:       key,
+		NextWindowEndsAt: now.Add(period),
+		Now:              now,
+	}); err != nil {
+		return 0, fmt.Errorf("hit rate limit window: %w", err)
+	}
+	attempts, err := queries.GetRateLimitAttempts(ctx, key)
+	if err != nil {
+		return 0, fmt.Errorf("get rate limit attempts: %w", err)
+	}
+	return int(attempts), nil
+}
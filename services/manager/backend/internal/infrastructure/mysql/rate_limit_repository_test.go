@@ -0,0 +1,66 @@
+package mysql
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+const (
+	deleteExpiredRateLimitWindowsQuery = "-- name: DeleteExpiredRateLimitWindows :exec\n" +
+		"DELETE FROM rate_limit_windows\n" +
+		"WHERE window_ends_at <= ?\n"
+	hitRateLimitWindowQuery = "-- name: HitRateLimitWindow :exec\n" +
+		"INSERT INTO rate_limit_windows (\n" +
+		"  limiter_key,\n" +
+		"  attempts,\n" +
+		"  window_ends_at\n" +
+		") VALUES (?, 1, ?)\n" +
+		"ON DUPLICATE KEY UPDATE\n" +
+		"  attempts = IF(window_ends_at <= ?, 1, attempts + 1),\n" +
+		"  window_ends_at = IF(window_ends_at <= ?, VALUES(window_ends_at), window_ends_at)\n"
+	getRateLimitAttemptsQuery = "-- name: GetRateLimitAttempts :one\n" +
+		"SELECT attempts\n" +
+		"FROM rate_limit_windows\n" +
+		"WHERE limiter_key = ?\n"
+)
+
+func TestRateLimitRepositoryHit(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer func() {
+		mock.ExpectClose()
+		if closeErr := db.Close(); closeErr != nil {
+			t.Fatalf("failed to close db: %v", closeErr)
+		}
+	}()
+
+	now := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+	key := "public_url:1:203.0.113.7"
+	mock.ExpectExec(regexp.QuoteMeta(deleteExpiredRateLimitWindowsQuery)).
+		WithArgs(now).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(hitRateLimitWindowQuery)).
+		WithArgs(key, now.Add(15*time.Minute), now, now).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectQuery(regexp.QuoteMeta(getRateLimitAttemptsQuery)).
+		WithArgs(key).
+		WillReturnRows(sqlmock.NewRows([]string{"attempts"}).AddRow(4))
+
+	attempts, err := NewRateLimitRepository(db).Hit(context.Background(), key, now, 15*time.Minute)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if attempts != 4 {
+		t.Fatalf("expected 4 attempts, got %d", attempts)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
@@ -0,0 +1,65 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sky0621/techcv/manager/backend/internal/domain/uuidv7"
+	mysqlsqlc "github.com/sky0621/techcv/manager/backend/internal/infrastructure/mysql/sqlc"
+)
+
+// SessionRepository persists sign-in sessions in MySQL. Sessions are keyed by a hash of
+// their bearer token, so a leaked table does not hand out usable tokens.
+type SessionRepository struct {
+	queries *mysqlsqlc.Queries
+}
+
+// NewSessionRepository constructs a new repository backed by sqlc queries.
+func NewSessionRepository(db *sql.DB) *SessionRepository {
+	return &SessionRepository{
+		queries: mysqlsqlc.New(db),
+	}
+}
+
+// Create stores a session for the user that is valid until expiresAt.
+func (r *SessionRepository) Create(ctx context.Context, tokenHash []byte, userID string, expiresAt, now time.Time) error {
+	owner, err := uuidv7.ToBytes(userID)
+	if err != nil {
+		return fmt.Errorf("convert user id: %w", err)
+	}
+
+	return queriesFor(ctx, r.queries).CreateUserSession(ctx, mysqlsqlc.CreateUserSessionParams{
+		TokenHash: tokenHash,
+		UserID:    owner,
+		ExpiresAt: expiresAt,
+		CreatedAt: now,
+	})
+}
+
+// FindUserID returns the user of the session, if it exists and has not expired at now.
+func (r *SessionRepository) FindUserID(ctx context.Context, tokenHash []byte, now time.Time) (string, bool, error) {
+	owner, err := queriesFor(ctx, r.queries).GetUserSessionUserID(ctx, mysqlsqlc.GetUserSessionUserIDParams{
+		TokenHash: tokenHash,
+		ExpiresAt: now,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+
+	userID, err := uuidv7.FromBytes(owner)
+	if err != nil {
+		return "", false, fmt.Errorf("convert user id: %w", err)
+	}
+	return userID, true, nil
+}
+
+// DeleteExpired removes the sessions that have expired at now.
+func (r *SessionRepository) DeleteExpired(ctx context.Context, now time.Time) error {
+	return queriesFor(ctx, r.queries).DeleteExpiredUserSessions(ctx, now)
+}
@@ -0,0 +1,52 @@
+package mysql
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+const getUserSessionUserIDQuery = "-- name: GetUserSessionUserID :one\n" +
+	"SELECT user_id\n" +
+	"FROM user_sessions\n" +
+	"WHERE token_hash = ?\n" +
+	"  AND expires_at > ?\n"
+
+func TestSessionRepositoryFindUserID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer func() {
+		mock.ExpectClose()
+		if closeErr := db.Close(); closeErr != nil {
+			t.Fatalf("failed to close db: %v", closeErr)
+		}
+	}()
+
+	now := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+	known := []byte("known-token-hash")
+	mock.ExpectQuery(regexp.QuoteMeta(getUserSessionUserIDQuery)).
+		WithArgs(known, now).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(ownerBytes(t)))
+	mock.ExpectQuery(regexp.QuoteMeta(getUserSessionUserIDQuery)).
+		WithArgs([]byte("expired-token-hash"), now).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}))
+
+	repo := NewSessionRepository(db)
+	userID, ok, err := repo.FindUserID(context.Background(), known, now)
+	if err != nil || !ok || userID != ownerID {
+		t.Fatalf("expected session of %s, got %q (%v, %v)", ownerID, userID, ok, err)
+	}
+
+	if _, ok, err := repo.FindUserID(context.Background(), []byte("expired-token-hash"), now); err != nil || ok {
+		t.Fatalf("expected no session, got %v (%v)", ok, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
@@ -0,0 +1,212 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+
+	"github.com/sky0621/techcv/manager/backend/internal/domain/skill"
+	"github.com/sky0621/techcv/manager/backend/internal/domain/uuidv7"
+	mysqlsqlc "github.com/sky0621/techcv/manager/backend/internal/infrastructure/mysql/sqlc"
+)
+
+// skillCatalogStateIndex is the primary key of the single skill_catalog_state row.
+const skillCatalogStateIndex = "PRIMARY"
+
+// SkillCatalogRepository persists the skill catalog in MySQL.
+type SkillCatalogRepository struct {
+	queries *mysqlsqlc.Queries
+}
+
+// NewSkillCatalogRepository constructs a new repository backed by sqlc queries.
+func NewSkillCatalogRepository(db *sql.DB) *SkillCatalogRepository {
+	return &SkillCatalogRepository{
+		queries: mysqlsqlc.New(db),
+	}
+}
+
+// Seed stores the initial catalog unless one has been stored before, and reports whether it
+// did. It must run inside a transaction so that a failed seed leaves nothing behind; when
+// several instances start at once, only the first one to create the catalog state seeds.
+func (r *SkillCatalogRepository) Seed(ctx context.Context, catalog *skill.Catalog) (bool, error) {
+	err := queriesFor(ctx, r.queries).CreateSkillCatalogState(ctx)
+	if isDuplicateKey(err, skillCatalogStateIndex) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("create skill catalog state: %w", err)
+	}
+	if err := r.Save(ctx, catalog); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Load reads the whole catalog.
+func (r *SkillCatalogRepository) Load(ctx context.Context) (*skill.Catalog, error) {
+	skills, err := r.stored(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return skill.NewCatalog(skills)
+}
+
+// Save writes the skills that changed since the catalog was loaded and deletes the ones it
+// no longer holds, such as the source of a merge. Callers hold Lock for the duration.
+func (r *SkillCatalogRepository) Save(ctx context.Context, catalog *skill.Catalog) error {
+	queries := queriesFor(ctx, r.queries)
+
+	stored, err := r.stored(ctx)
+	if err != nil {
+		return err
+	}
+	previous := make(map[string]skill.Skill, len(stored))
+	for _, s := range stored {
+		previous[s.ID()] = s
+	}
+
+	// Parents are written before their children so that the foreign key always holds.
+	skills := catalog.Skills()
+	sort.SliceStable(skills, func(i, j int) bool {
+		return len(catalog.Ancestors(skills[i].ID())) < len(catalog.Ancestors(skills[j].ID()))
+	})
+	for _, s := range skills {
+		old, ok := previous[s.ID()]
+		delete(previous, s.ID())
+		if ok && sameSkill(old, s) {
+			continue
+		}
+		if err := r.write(ctx, queries, s, ok); err != nil {
+			return err
+		}
+	}
+
+	for id := range previous {
+		key, err := uuidv7.ToBytes(id)
+		if err != nil {
+			return fmt.Errorf("convert skill id: %w", err)
+		}
+		if err := queries.DeleteSkillAliases(ctx, key); err != nil {
+			return fmt.Errorf("delete skill aliases: %w", err)
+		}
+		if err := queries.DeleteSkill(ctx, key); err != nil {
+			return fmt.Errorf("delete skill: %w", err)
+		}
+	}
+
+	if err := queries.IncrementSkillCatalogRevision(ctx); err != nil {
+		return fmt.Errorf("increment skill catalog revision: %w", err)
+	}
+	return nil
+}
+
+// Revision returns the number of times the catalog has been saved.
+func (r *SkillCatalogRepository) Revision(ctx context.Context) (uint64, error) {
+	revision, err := queriesFor(ctx, r.queries).GetSkillCatalogRevision(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("get skill catalog revision: %w", err)
+	}
+	if revision < 0 {
+		return 0, fmt.Errorf("received negative skill catalog revision: %d", revision)
+	}
+	return uint64(revision), nil
+}
+
+// Lock takes a row lock on the catalog state so that concurrent changes to the catalog
+// serialize. It only has an effect inside a transaction.
+func (r *SkillCatalogRepository) Lock(ctx context.Context) error {
+	if _, err := queriesFor(ctx, r.queries).LockSkillCatalog(ctx); err != nil {
+		return fmt.Errorf("lock skill catalog: %w", err)
+	}
+	return nil
+}
+
+func (r *SkillCatalogRepository) stored(ctx context.Context) ([]skill.Skill, error) {
+	queries := queriesFor(ctx, r.queries)
+
+	records, err := queries.ListSkills(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list skills: %w", err)
+	}
+	aliasRecords, err := queries.ListSkillAliases(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list skill aliases: %w", err)
+	}
+	aliases := make(map[string][]string, len(records))
+	for _, record := range aliasRecords {
+		aliases[string(record.SkillID)] = append(aliases[string(record.SkillID)], record.Alias)
+	}
+
+	result := make([]skill.Skill, 0, len(records))
+	for _, record := range records {
+		id, err := uuidv7.FromBytes(record.ID)
+		if err != nil {
+			return nil, fmt.Errorf("convert skill id: %w", err)
+		}
+		var parentID string
+		if record.ParentID.Valid {
+			if parentID, err = uuidv7.FromBytes([]byte(record.ParentID.String)); err != nil {
+				return nil, fmt.Errorf("convert skill parent id: %w", err)
+			}
+		}
+		s, err := skill.RestoreSkill(id, record.Name, skill.Category(record.Category), aliases[string(record.ID)], parentID)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, s)
+	}
+	return result, nil
+}
+
+func (r *SkillCatalogRepository) write(ctx context.Context, queries *mysqlsqlc.Queries, s skill.Skill, existing bool) error {
+	key, err := uuidv7.ToBytes(s.ID())
+	if err != nil {
+		return fmt.Errorf("convert skill id: %w", err)
+	}
+	var parent sql.NullString
+	if s.ParentID() != "" {
+		parentKey, err := uuidv7.ToBytes(s.ParentID())
+		if err != nil {
+			return fmt.Errorf("convert skill parent id: %w", err)
+		}
+		parent = sql.NullString{String: string(parentKey), Valid: true}
+	}
+
+	if err := queries.UpsertSkill(ctx, mysqlsqlc.UpsertSkillParams{
+		ID:       key,
+		Name:     s.Name(),
+		Category: string(s.Category()),
+		ParentID: parent,
+	}); err != nil {
+		return fmt.Errorf("upsert skill: %w", err)
+	}
+
+	if existing {
+		if err := queries.DeleteSkillAliases(ctx, key); err != nil {
+			return fmt.Errorf("delete skill aliases: %w", err)
+		}
+	}
+	for position, alias := range s.Aliases() {
+		if position > math.MaxInt32 {
+			return fmt.Errorf("skill %s has too many aliases", s.ID())
+		}
+		if err := queries.CreateSkillAlias(ctx, mysqlsqlc.CreateSkillAliasParams{
+			SkillID:  key,
+			Position: int32(position),
+			Alias:    alias,
+		}); err != nil {
+			return fmt.Errorf("create skill alias: %w", err)
+		}
+	}
+	return nil
+}
+
+func sameSkill(a, b skill.Skill) bool {
+	return a.Name() == b.Name() &&
+		a.Category() == b.Category() &&
+		a.ParentID() == b.ParentID() &&
+		slices.Equal(a.Aliases(), b.Aliases())
+}
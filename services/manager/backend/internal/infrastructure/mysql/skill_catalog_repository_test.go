@@ -0,0 +1,169 @@
+package mysql
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	gomysql "github.com/go-sql-driver/mysql"
+
+	"github.com/sky0621/techcv/manager/backend/internal/domain/skill"
+	"github.com/sky0621/techcv/manager/backend/internal/domain/uuidv7"
+)
+
+const (
+	createSkillCatalogStateQuery = "-- name: CreateSkillCatalogState :exec\n" +
+		"INSERT INTO skill_catalog_state (\n" +
+		"  id,\n" +
+		"  revision\n" +
+		") VALUES (1, 0)\n"
+	listSkillsQuery = "-- name: ListSkills :many\n" +
+		"SELECT\n" +
+		"  id,\n" +
+		"  name,\n" +
+		"  category,\n" +
+		"  parent_id\n" +
+		"FROM skills\n" +
+		"ORDER BY id\n"
+	listSkillAliasesQuery = "-- name: ListSkillAliases :many\n" +
+		"SELECT\n" +
+		"  skill_id,\n" +
+		"  alias\n" +
+		"FROM skill_aliases\n" +
+		"ORDER BY skill_id, position\n"
+	upsertSkillQuery = "-- name: UpsertSkill :exec\n" +
+		"INSERT INTO skills (\n" +
+		"  id,\n" +
+		"  name,\n" +
+		"  category,\n" +
+		"  parent_id\n" +
+		") VALUES (?, ?, ?, ?)\n" +
+		"ON DUPLICATE KEY UPDATE\n" +
+		"  name = VALUES(name),\n" +
+		"  category = VALUES(category),\n" +
+		"  parent_id = VALUES(parent_id)\n"
+	deleteSkillQuery = "-- name: DeleteSkill :exec\n" +
+		"DELETE FROM skills\n" +
+		"WHERE id = ?\n"
+	createSkillAliasQuery = "-- name: CreateSkillAlias :exec\n" +
+		"INSERT INTO skill_aliases (\n" +
+		"  skill_id,\n" +
+		"  position,\n" +
+		"  alias\n" +
+		") VALUES (?, ?, ?)\n"
+	deleteSkillAliasesQuery = "-- name: DeleteSkillAliases :exec\n" +
+		"DELETE FROM skill_aliases\n" +
+		"WHERE skill_id = ?\n"
+	incrementSkillCatalogRevisionQuery = "-- name: IncrementSkillCatalogRevision :exec\n" +
+		"UPDATE skill_catalog_state\n" +
+		"SET revision = revision + 1\n" +
+		"WHERE id = 1\n"
+)
+
+func TestSkillCatalogRepositorySaveMerge(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer func() {
+		mock.ExpectClose()
+		if closeErr := db.Close(); closeErr != nil {
+			t.Fatalf("failed to close db: %v", closeErr)
+		}
+	}()
+
+	golang, err := skill.NewSkill("Go", skill.CategoryLanguage, nil, "")
+	if err != nil {
+		t.Fatalf("NewSkill() error = %v", err)
+	}
+	duplicate, err := skill.NewSkill("Golang", skill.CategoryLanguage, []string{"ゴー"}, "")
+	if err != nil {
+		t.Fatalf("NewSkill() error = %v", err)
+	}
+	catalog, err := skill.NewCatalog([]skill.Skill{golang, duplicate})
+	if err != nil {
+		t.Fatalf("NewCatalog() error = %v", err)
+	}
+	if _, err := catalog.Merge(duplicate.ID(), golang.ID()); err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+	golangKey, err := uuidv7.ToBytes(golang.ID())
+	if err != nil {
+		t.Fatalf("ToBytes() error = %v", err)
+	}
+	duplicateKey, err := uuidv7.ToBytes(duplicate.ID())
+	if err != nil {
+		t.Fatalf("ToBytes() error = %v", err)
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta(listSkillsQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "category", "parent_id"}).
+			AddRow(golangKey, "Go", "language", nil).
+			AddRow(duplicateKey, "Golang", "language", nil))
+	mock.ExpectQuery(regexp.QuoteMeta(listSkillAliasesQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"skill_id", "alias"}).AddRow(duplicateKey, "ゴー"))
+	mock.ExpectExec(regexp.QuoteMeta(upsertSkillQuery)).
+		WithArgs(golangKey, "Go", "language", nil).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(deleteSkillAliasesQuery)).
+		WithArgs(golangKey).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(createSkillAliasQuery)).
+		WithArgs(golangKey, 0, "Golang").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(createSkillAliasQuery)).
+		WithArgs(golangKey, 1, "ゴー").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(deleteSkillAliasesQuery)).
+		WithArgs(duplicateKey).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(deleteSkillQuery)).
+		WithArgs(duplicateKey).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(incrementSkillCatalogRevisionQuery)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := NewSkillCatalogRepository(db).Save(context.Background(), catalog); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestSkillCatalogRepositorySeedAlreadySeeded(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer func() {
+		mock.ExpectClose()
+		if closeErr := db.Close(); closeErr != nil {
+			t.Fatalf("failed to close db: %v", closeErr)
+		}
+	}()
+
+	mock.ExpectExec(regexp.QuoteMeta(createSkillCatalogStateQuery)).
+		WillReturnError(&gomysql.MySQLError{
+			Number:  mysqlErrDuplicateEntry,
+			Message: "Duplicate entry '1' for key 'skill_catalog_state.PRIMARY'",
+		})
+
+	catalog, err := skill.NewCatalog(nil)
+	if err != nil {
+		t.Fatalf("NewCatalog() error = %v", err)
+	}
+	seeded, err := NewSkillCatalogRepository(db).Seed(context.Background(), catalog)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if seeded {
+		t.Fatal("expected an existing catalog to be left alone")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
@@ -0,0 +1,88 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/sky0621/techcv/manager/backend/internal/domain/uuidv7"
+	mysqlsqlc "github.com/sky0621/techcv/manager/backend/internal/infrastructure/mysql/sqlc"
+)
+
+// SkillUsageRepository persists which users use each skill in MySQL.
+type SkillUsageRepository struct {
+	queries *mysqlsqlc.Queries
+}
+
+// NewSkillUsageRepository constructs a new repository backed by sqlc queries.
+func NewSkillUsageRepository(db *sql.DB) *SkillUsageRepository {
+	return &SkillUsageRepository{
+		queries: mysqlsqlc.New(db),
+	}
+}
+
+// Counts returns the number of users per skill identifier.
+func (r *SkillUsageRepository) Counts(ctx context.Context) (map[string]int, error) {
+	records, err := queriesFor(ctx, r.queries).ListSkillUsageCounts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list skill usage counts: %w", err)
+	}
+
+	counts := make(map[string]int, len(records))
+	for _, record := range records {
+		id, err := uuidv7.FromBytes(record.SkillID)
+		if err != nil {
+			return nil, fmt.Errorf("convert skill id: %w", err)
+		}
+		counts[id] = int(record.Users)
+	}
+	return counts, nil
+}
+
+// Record marks the skills as used by the user; skills the user already uses are left as
+// they are.
+func (r *SkillUsageRepository) Record(ctx context.Context, userID string, skillIDs []string, now time.Time) error {
+	owner, err := uuidv7.ToBytes(userID)
+	if err != nil {
+		return fmt.Errorf("convert user id: %w", err)
+	}
+
+	queries := queriesFor(ctx, r.queries)
+	for _, skillID := range skillIDs {
+		key, err := uuidv7.ToBytes(skillID)
+		if err != nil {
+			return fmt.Errorf("convert skill id: %w", err)
+		}
+		if err := queries.CreateSkillUsage(ctx, mysqlsqlc.CreateSkillUsageParams{
+			SkillID:     key,
+			UserID:      owner,
+			FirstUsedAt: now,
+		}); err != nil {
+			return fmt.Errorf("create skill usage: %w", err)
+		}
+	}
+	return nil
+}
+
+// Transfer moves the users of one skill onto another. It must run inside a transaction so
+// that the copy and the delete apply together.
+func (r *SkillUsageRepository) Transfer(ctx context.Context, fromID, toID string) error {
+	from, err := uuidv7.ToBytes(fromID)
+	if err != nil {
+		return fmt.Errorf("convert skill id: %w", err)
+	}
+	to, err := uuidv7.ToBytes(toID)
+	if err != nil {
+		return fmt.Errorf("convert skill id: %w", err)
+	}
+
+	queries := queriesFor(ctx, r.queries)
+	if err := queries.CopySkillUsages(ctx, mysqlsqlc.CopySkillUsagesParams{SkillID: to, SkillID_2: from}); err != nil {
+		return fmt.Errorf("copy skill usages: %w", err)
+	}
+	if err := queries.DeleteSkillUsages(ctx, from); err != nil {
+		return fmt.Errorf("delete skill usages: %w", err)
+	}
+	return nil
+}
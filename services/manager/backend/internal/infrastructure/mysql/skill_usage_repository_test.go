@@ -0,0 +1,78 @@
+package mysql
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/sky0621/techcv/manager/backend/internal/domain/uuidv7"
+	"github.com/sky0621/techcv/manager/backend/internal/infrastructure/transaction"
+)
+
+const (
+	sourceSkillID = "0190c8a1-b2c3-7d4e-8f00-aabbccddeeff"
+	targetSkillID = "0190c8a1-b2c3-7d4e-8f00-ffeeddccbbaa"
+)
+
+const (
+	copySkillUsagesQuery = "-- name: CopySkillUsages :exec\n" +
+		"INSERT IGNORE INTO skill_usages (\n" +
+		"  skill_id,\n" +
+		"  user_id,\n" +
+		"  first_used_at\n" +
+		")\n" +
+		"SELECT\n" +
+		"  ?,\n" +
+		"  su.user_id,\n" +
+		"  su.first_used_at\n" +
+		"FROM skill_usages AS su\n" +
+		"WHERE su.skill_id = ?\n"
+	deleteSkillUsagesQuery = "-- name: DeleteSkillUsages :exec\n" +
+		"DELETE FROM skill_usages\n" +
+		"WHERE skill_id = ?\n"
+)
+
+func TestSkillUsageRepositoryTransfer(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer func() {
+		mock.ExpectClose()
+		if closeErr := db.Close(); closeErr != nil {
+			t.Fatalf("failed to close db: %v", closeErr)
+		}
+	}()
+
+	source, err := uuidv7.ToBytes(sourceSkillID)
+	if err != nil {
+		t.Fatalf("ToBytes() error = %v", err)
+	}
+	target, err := uuidv7.ToBytes(targetSkillID)
+	if err != nil {
+		t.Fatalf("ToBytes() error = %v", err)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(copySkillUsagesQuery)).
+		WithArgs(target, source).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectExec(regexp.QuoteMeta(deleteSkillUsagesQuery)).
+		WithArgs(source).
+		WillReturnResult(sqlmock.NewResult(0, 4))
+	mock.ExpectCommit()
+
+	repo := NewSkillUsageRepository(db)
+	err = transaction.NewSQLManager(db).WithinTransaction(context.Background(), func(ctx context.Context) error {
+		return repo.Transfer(ctx, sourceSkillID, targetSkillID)
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
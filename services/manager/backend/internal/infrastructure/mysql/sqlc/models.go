@@ -5,13 +5,152 @@
 package mysqlsqlc
 
 import (
+	"database/sql"
 	"time"
 )
 
+// メール確認トークン
+type EmailVerificationToken struct {
+	// トークンID（UUID v7）
+	ID []byte `json:"id"`
+	// 確認対象のメールアドレス
+	UserEmail string `json:"user_email"`
+	// 確認トークン（UUID v7）
+	Token string `json:"token"`
+	// 登録時に入力されたパスワードのハッシュ
+	PasswordHash string `json:"password_hash"`
+	// 有効期限
+	ExpiresAt time.Time `json:"expires_at"`
+	// 作成日時
+	CreatedAt time.Time `json:"created_at"`
+}
+
 type PublicUrl struct {
-	ID        int64     `json:"id"`
-	UrlKey    string    `json:"url_key"`
-	IsActive  bool      `json:"is_active"`
+	ID                  int64          `json:"id"`
+	UserID              []byte         `json:"user_id"`
+	UrlKey              string         `json:"url_key"`
+	Label               string         `json:"label"`
+	CvVariant           sql.NullString `json:"cv_variant"`
+	PassphraseHash      sql.NullString `json:"passphrase_hash"`
+	IsActive            bool           `json:"is_active"`
+	ActivatesAt         sql.NullTime   `json:"activates_at"`
+	ExpiresAt           sql.NullTime   `json:"expires_at"`
+	ExpiryRemindedAt    sql.NullTime   `json:"expiry_reminded_at"`
+	NotifyFirstView     bool           `json:"notify_first_view"`
+	FirstViewedAt       sql.NullTime   `json:"first_viewed_at"`
+	FirstViewNotifiedAt sql.NullTime   `json:"first_view_notified_at"`
+	CreatedAt           time.Time      `json:"created_at"`
+	UpdatedAt           time.Time      `json:"updated_at"`
+}
+
+type PublicUrlEvent struct {
+	ID          int64          `json:"id"`
+	PublicUrlID int64          `json:"public_url_id"`
+	Reason      string         `json:"reason"`
+	Active      bool           `json:"active"`
+	Actor       string         `json:"actor"`
+	ActorUserID sql.NullString `json:"actor_user_id"`
+	Note        sql.NullString `json:"note"`
+	OccurredAt  time.Time      `json:"occurred_at"`
+}
+
+type PublicUrlSlug struct {
+	Slug          string       `json:"slug"`
+	PublicUrlID   int64        `json:"public_url_id"`
+	RedirectUntil sql.NullTime `json:"redirect_until"`
+	CreatedAt     time.Time    `json:"created_at"`
+}
+
+type PublicUrlView struct {
+	ID             int64          `json:"id"`
+	PublicUrlID    int64          `json:"public_url_id"`
+	ViewedAt       time.Time      `json:"viewed_at"`
+	ReferrerHost   sql.NullString `json:"referrer_host"`
+	UserAgentClass string         `json:"user_agent_class"`
+	Country        sql.NullString `json:"country"`
+	VisitorHash    []byte         `json:"visitor_hash"`
+}
+
+type RateLimitWindow struct {
+	LimiterKey   string    `json:"limiter_key"`
+	Attempts     int32     `json:"attempts"`
+	WindowEndsAt time.Time `json:"window_ends_at"`
+}
+
+type Skill struct {
+	ID        []byte         `json:"id"`
+	Name      string         `json:"name"`
+	Category  string         `json:"category"`
+	ParentID  sql.NullString `json:"parent_id"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+}
+
+type SkillAlias struct {
+	SkillID  []byte `json:"skill_id"`
+	Position int32  `json:"position"`
+	Alias    string `json:"alias"`
+}
+
+type SkillCatalogState struct {
+	ID       int32 `json:"id"`
+	Revision int64 `json:"revision"`
+}
+
+type SkillUsage struct {
+	SkillID     []byte    `json:"skill_id"`
+	UserID      []byte    `json:"user_id"`
+	FirstUsedAt time.Time `json:"first_used_at"`
+}
+
+// ユーザー情報
+type User struct {
+	// ユーザーID（UUID v7）
+	ID []byte `json:"id"`
+	// メールアドレス
+	Email string `json:"email"`
+	// パスワードハッシュ（ソーシャルログインの場合はNULL）
+	PasswordHash sql.NullString `json:"password_hash"`
+	// GoogleユーザーID（sub）
+	GoogleID sql.NullString `json:"google_id"`
+	// ユーザー名
+	Name sql.NullString `json:"name"`
+	// プロフィール画像URL
+	ProfileImage sql.NullString `json:"profile_image"`
+	// 自己紹介
+	Bio sql.NullString `json:"bio"`
+	// アクティブ状態
+	IsActive bool `json:"is_active"`
+	// メール確認日時
+	EmailVerifiedAt sql.NullTime `json:"email_verified_at"`
+	// 最終ログイン日時
+	LastLoginAt sql.NullTime `json:"last_login_at"`
+	// 作成日時
 	CreatedAt time.Time `json:"created_at"`
+	// 更新日時
 	UpdatedAt time.Time `json:"updated_at"`
+	// 削除日時
+	DeletedAt sql.NullTime `json:"deleted_at"`
+}
+
+// gitの作成者メールアドレス
+type UserGitAuthorEmail struct {
+	// ユーザーID
+	UserID []byte `json:"user_id"`
+	// コミットに使うメールアドレス（アカウントのメールアドレス以外）
+	Email string `json:"email"`
+	// 作成日時
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ログインセッション
+type UserSession struct {
+	// セッショントークンのSHA-256ハッシュ
+	TokenHash []byte `json:"token_hash"`
+	// ユーザーID
+	UserID []byte `json:"user_id"`
+	// 有効期限
+	ExpiresAt time.Time `json:"expires_at"`
+	// 作成日時
+	CreatedAt time.Time `json:"created_at"`
 }
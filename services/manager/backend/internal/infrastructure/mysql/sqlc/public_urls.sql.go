@@ -8,79 +8,474 @@ package mysqlsqlc
 import (
 	"context"
 	"database/sql"
+	"time"
 )
 
+const claimPublicURLExpiryReminder = `-- name: ClaimPublicURLExpiryReminder :execrows
+UPDATE public_urls
+SET expiry_reminded_at = ?,
+    updated_at = updated_at
+WHERE id = ?
+  AND expires_at = ?
+  AND expiry_reminded_at IS NULL
+`
+
+type ClaimPublicURLExpiryReminderParams struct {
+	ExpiryRemindedAt sql.NullTime `json:"expiry_reminded_at"`
+	ID               int64        `json:"id"`
+	ExpiresAt        sql.NullTime `json:"expires_at"`
+}
+
+func (q *Queries) ClaimPublicURLExpiryReminder(ctx context.Context, arg ClaimPublicURLExpiryReminderParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, claimPublicURLExpiryReminder, arg.ExpiryRemindedAt, arg.ID, arg.ExpiresAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const claimPublicURLFirstViewNotification = `-- name: ClaimPublicURLFirstViewNotification :execrows
+UPDATE public_urls
+SET first_view_notified_at = ?,
+    updated_at = updated_at
+WHERE id = ?
+  AND first_view_notified_at IS NULL
+`
+
+type ClaimPublicURLFirstViewNotificationParams struct {
+	FirstViewNotifiedAt sql.NullTime `json:"first_view_notified_at"`
+	ID                  int64        `json:"id"`
+}
+
+func (q *Queries) ClaimPublicURLFirstViewNotification(ctx context.Context, arg ClaimPublicURLFirstViewNotificationParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, claimPublicURLFirstViewNotification, arg.FirstViewNotifiedAt, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const createPublicURL = `-- name: CreatePublicURL :execresult
-INSERT INTO public_urls (url_key)
-VALUES (?)
+INSERT INTO public_urls (
+  user_id,
+  url_key,
+  label,
+  cv_variant,
+  passphrase_hash,
+  activates_at,
+  expires_at,
+  notify_first_view
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?)
 `
 
-func (q *Queries) CreatePublicURL(ctx context.Context, urlKey string) (sql.Result, error) {
-	return q.db.ExecContext(ctx, createPublicURL, urlKey)
+type CreatePublicURLParams struct {
+	UserID          []byte         `json:"user_id"`
+	UrlKey          string         `json:"url_key"`
+	Label           string         `json:"label"`
+	CvVariant       sql.NullString `json:"cv_variant"`
+	PassphraseHash  sql.NullString `json:"passphrase_hash"`
+	ActivatesAt     sql.NullTime   `json:"activates_at"`
+	ExpiresAt       sql.NullTime   `json:"expires_at"`
+	NotifyFirstView bool           `json:"notify_first_view"`
+}
+
+func (q *Queries) CreatePublicURL(ctx context.Context, arg CreatePublicURLParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, createPublicURL,
+		arg.UserID,
+		arg.UrlKey,
+		arg.Label,
+		arg.CvVariant,
+		arg.PassphraseHash,
+		arg.ActivatesAt,
+		arg.ExpiresAt,
+		arg.NotifyFirstView,
+	)
 }
 
-const deactivatePublicURL = `-- name: DeactivatePublicURL :exec
+const deactivateUserPublicURLs = `-- name: DeactivateUserPublicURLs :exec
 UPDATE public_urls
 SET is_active = FALSE,
     updated_at = CURRENT_TIMESTAMP(6)
-WHERE id = ?
+WHERE user_id = ?
+  AND is_active = TRUE
 `
 
-func (q *Queries) DeactivatePublicURL(ctx context.Context, id int64) error {
-	_, err := q.db.ExecContext(ctx, deactivatePublicURL, id)
+func (q *Queries) DeactivateUserPublicURLs(ctx context.Context, userID []byte) error {
+	_, err := q.db.ExecContext(ctx, deactivateUserPublicURLs, userID)
 	return err
 }
 
-const getActivePublicURL = `-- name: GetActivePublicURL :one
+const getPublicURL = `-- name: GetPublicURL :one
 SELECT
-  id,
-  url_key,
-  is_active,
-  created_at,
-  updated_at
-FROM public_urls
-WHERE is_active = TRUE
-ORDER BY updated_at DESC
-LIMIT 1
-`
-
-func (q *Queries) GetActivePublicURL(ctx context.Context) (PublicUrl, error) {
-	row := q.db.QueryRowContext(ctx, getActivePublicURL)
-	var i PublicUrl
+  p.id,
+  p.user_id,
+  p.url_key,
+  s.slug,
+  p.label,
+  p.cv_variant,
+  p.passphrase_hash,
+  p.is_active,
+  p.activates_at,
+  p.expires_at,
+  p.notify_first_view,
+  p.first_viewed_at,
+  p.created_at,
+  p.updated_at
+FROM public_urls p
+LEFT JOIN public_url_slugs s
+  ON s.public_url_id = p.id
+  AND s.redirect_until IS NULL
+WHERE p.id = ?
+  AND p.user_id = ?
+`
+
+type GetPublicURLParams struct {
+	ID     int64  `json:"id"`
+	UserID []byte `json:"user_id"`
+}
+
+type GetPublicURLRow struct {
+	ID              int64          `json:"id"`
+	UserID          []byte         `json:"user_id"`
+	UrlKey          string         `json:"url_key"`
+	Slug            sql.NullString `json:"slug"`
+	Label           string         `json:"label"`
+	CvVariant       sql.NullString `json:"cv_variant"`
+	PassphraseHash  sql.NullString `json:"passphrase_hash"`
+	IsActive        bool           `json:"is_active"`
+	ActivatesAt     sql.NullTime   `json:"activates_at"`
+	ExpiresAt       sql.NullTime   `json:"expires_at"`
+	NotifyFirstView bool           `json:"notify_first_view"`
+	FirstViewedAt   sql.NullTime   `json:"first_viewed_at"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+}
+
+func (q *Queries) GetPublicURL(ctx context.Context, arg GetPublicURLParams) (GetPublicURLRow, error) {
+	row := q.db.QueryRowContext(ctx, getPublicURL, arg.ID, arg.UserID)
+	var i GetPublicURLRow
 	err := row.Scan(
 		&i.ID,
+		&i.UserID,
 		&i.UrlKey,
+		&i.Slug,
+		&i.Label,
+		&i.CvVariant,
+		&i.PassphraseHash,
 		&i.IsActive,
+		&i.ActivatesAt,
+		&i.ExpiresAt,
+		&i.NotifyFirstView,
+		&i.FirstViewedAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
 	return i, err
 }
 
+const getPublicURLByKey = `-- name: GetPublicURLByKey :one
+SELECT
+  p.id,
+  p.user_id,
+  p.url_key,
+  s.slug,
+  p.label,
+  p.cv_variant,
+  p.passphrase_hash,
+  p.is_active,
+  p.activates_at,
+  p.expires_at,
+  p.notify_first_view,
+  p.first_viewed_at,
+  p.created_at,
+  p.updated_at
+FROM public_urls p
+LEFT JOIN public_url_slugs s
+  ON s.public_url_id = p.id
+  AND s.redirect_until IS NULL
+WHERE p.url_key = ?
+`
+
+type GetPublicURLByKeyRow struct {
+	ID              int64          `json:"id"`
+	UserID          []byte         `json:"user_id"`
+	UrlKey          string         `json:"url_key"`
+	Slug            sql.NullString `json:"slug"`
+	Label           string         `json:"label"`
+	CvVariant       sql.NullString `json:"cv_variant"`
+	PassphraseHash  sql.NullString `json:"passphrase_hash"`
+	IsActive        bool           `json:"is_active"`
+	ActivatesAt     sql.NullTime   `json:"activates_at"`
+	ExpiresAt       sql.NullTime   `json:"expires_at"`
+	NotifyFirstView bool           `json:"notify_first_view"`
+	FirstViewedAt   sql.NullTime   `json:"first_viewed_at"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+}
+
+func (q *Queries) GetPublicURLByKey(ctx context.Context, urlKey string) (GetPublicURLByKeyRow, error) {
+	row := q.db.QueryRowContext(ctx, getPublicURLByKey, urlKey)
+	var i GetPublicURLByKeyRow
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.UrlKey,
+		&i.Slug,
+		&i.Label,
+		&i.CvVariant,
+		&i.PassphraseHash,
+		&i.IsActive,
+		&i.ActivatesAt,
+		&i.ExpiresAt,
+		&i.NotifyFirstView,
+		&i.FirstViewedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const listExpiringPublicURLs = `-- name: ListExpiringPublicURLs :many
+SELECT
+  p.id,
+  p.user_id,
+  p.url_key,
+  s.slug,
+  p.label,
+  p.cv_variant,
+  p.passphrase_hash,
+  p.is_active,
+  p.activates_at,
+  p.expires_at,
+  p.notify_first_view,
+  p.first_viewed_at,
+  p.created_at,
+  p.updated_at,
+  u.email
+FROM public_urls p
+JOIN users u ON u.id = p.user_id
+LEFT JOIN public_url_slugs s
+  ON s.public_url_id = p.id
+  AND s.redirect_until IS NULL
+WHERE p.is_active = TRUE
+  AND p.expiry_reminded_at IS NULL
+  AND p.expires_at > ?
+  AND p.expires_at <= ?
+  AND u.deleted_at IS NULL
+ORDER BY p.expires_at
+`
+
+type ListExpiringPublicURLsParams struct {
+	Now   sql.NullTime `json:"now"`
+	Until sql.NullTime `json:"until"`
+}
+
+type ListExpiringPublicURLsRow struct {
+	ID              int64          `json:"id"`
+	UserID          []byte         `json:"user_id"`
+	UrlKey          string         `json:"url_key"`
+	Slug            sql.NullString `json:"slug"`
+	Label           string         `json:"label"`
+	CvVariant       sql.NullString `json:"cv_variant"`
+	PassphraseHash  sql.NullString `json:"passphrase_hash"`
+	IsActive        bool           `json:"is_active"`
+	ActivatesAt     sql.NullTime   `json:"activates_at"`
+	ExpiresAt       sql.NullTime   `json:"expires_at"`
+	NotifyFirstView bool           `json:"notify_first_view"`
+	FirstViewedAt   sql.NullTime   `json:"first_viewed_at"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	Email           string         `json:"email"`
+}
+
+func (q *Queries) ListExpiringPublicURLs(ctx context.Context, arg ListExpiringPublicURLsParams) ([]ListExpiringPublicURLsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listExpiringPublicURLs, arg.Now, arg.Until)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListExpiringPublicURLsRow
+	for rows.Next() {
+		var i ListExpiringPublicURLsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.UrlKey,
+			&i.Slug,
+			&i.Label,
+			&i.CvVariant,
+			&i.PassphraseHash,
+			&i.IsActive,
+			&i.ActivatesAt,
+			&i.ExpiresAt,
+			&i.NotifyFirstView,
+			&i.FirstViewedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Email,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listFirstViewedPublicURLs = `-- name: ListFirstViewedPublicURLs :many
+SELECT
+  p.id,
+  p.user_id,
+  p.url_key,
+  s.slug,
+  p.label,
+  p.cv_variant,
+  p.passphrase_hash,
+  p.is_active,
+  p.activates_at,
+  p.expires_at,
+  p.notify_first_view,
+  p.first_viewed_at,
+  p.created_at,
+  p.updated_at,
+  u.email
+FROM public_urls p
+JOIN users u ON u.id = p.user_id
+LEFT JOIN public_url_slugs s
+  ON s.public_url_id = p.id
+  AND s.redirect_until IS NULL
+WHERE p.notify_first_view = TRUE
+  AND p.first_viewed_at IS NOT NULL
+  AND p.first_view_notified_at IS NULL
+  AND u.deleted_at IS NULL
+ORDER BY p.first_viewed_at
+`
+
+type ListFirstViewedPublicURLsRow struct {
+	ID              int64          `json:"id"`
+	UserID          []byte         `json:"user_id"`
+	UrlKey          string         `json:"url_key"`
+	Slug            sql.NullString `json:"slug"`
+	Label           string         `json:"label"`
+	CvVariant       sql.NullString `json:"cv_variant"`
+	PassphraseHash  sql.NullString `json:"passphrase_hash"`
+	IsActive        bool           `json:"is_active"`
+	ActivatesAt     sql.NullTime   `json:"activates_at"`
+	ExpiresAt       sql.NullTime   `json:"expires_at"`
+	NotifyFirstView bool           `json:"notify_first_view"`
+	FirstViewedAt   sql.NullTime   `json:"first_viewed_at"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	Email           string         `json:"email"`
+}
+
+func (q *Queries) ListFirstViewedPublicURLs(ctx context.Context) ([]ListFirstViewedPublicURLsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listFirstViewedPublicURLs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListFirstViewedPublicURLsRow
+	for rows.Next() {
+		var i ListFirstViewedPublicURLsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.UrlKey,
+			&i.Slug,
+			&i.Label,
+			&i.CvVariant,
+			&i.PassphraseHash,
+			&i.IsActive,
+			&i.ActivatesAt,
+			&i.ExpiresAt,
+			&i.NotifyFirstView,
+			&i.FirstViewedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Email,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listPublicURLs = `-- name: ListPublicURLs :many
 SELECT
-  id,
-  url_key,
-  is_active,
-  created_at,
-  updated_at
-FROM public_urls
-ORDER BY updated_at DESC
+  p.id,
+  p.user_id,
+  p.url_key,
+  s.slug,
+  p.label,
+  p.cv_variant,
+  p.passphrase_hash,
+  p.is_active,
+  p.activates_at,
+  p.expires_at,
+  p.notify_first_view,
+  p.first_viewed_at,
+  p.created_at,
+  p.updated_at
+FROM public_urls p
+LEFT JOIN public_url_slugs s
+  ON s.public_url_id = p.id
+  AND s.redirect_until IS NULL
+WHERE p.user_id = ?
+ORDER BY p.updated_at DESC
 `
 
-func (q *Queries) ListPublicURLs(ctx context.Context) ([]PublicUrl, error) {
-	rows, err := q.db.QueryContext(ctx, listPublicURLs)
+type ListPublicURLsRow struct {
+	ID              int64          `json:"id"`
+	UserID          []byte         `json:"user_id"`
+	UrlKey          string         `json:"url_key"`
+	Slug            sql.NullString `json:"slug"`
+	Label           string         `json:"label"`
+	CvVariant       sql.NullString `json:"cv_variant"`
+	PassphraseHash  sql.NullString `json:"passphrase_hash"`
+	IsActive        bool           `json:"is_active"`
+	ActivatesAt     sql.NullTime   `json:"activates_at"`
+	ExpiresAt       sql.NullTime   `json:"expires_at"`
+	NotifyFirstView bool           `json:"notify_first_view"`
+	FirstViewedAt   sql.NullTime   `json:"first_viewed_at"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+}
+
+func (q *Queries) ListPublicURLs(ctx context.Context, userID []byte) ([]ListPublicURLsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listPublicURLs, userID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []PublicUrl
+	var items []ListPublicURLsRow
 	for rows.Next() {
-		var i PublicUrl
+		var i ListPublicURLsRow
 		if err := rows.Scan(
 			&i.ID,
+			&i.UserID,
 			&i.UrlKey,
+			&i.Slug,
+			&i.Label,
+			&i.CvVariant,
+			&i.PassphraseHash,
 			&i.IsActive,
+			&i.ActivatesAt,
+			&i.ExpiresAt,
+			&i.NotifyFirstView,
+			&i.FirstViewedAt,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 		); err != nil {
@@ -96,3 +491,152 @@ func (q *Queries) ListPublicURLs(ctx context.Context) ([]PublicUrl, error) {
 	}
 	return items, nil
 }
+
+const lockPublicURLOwner = `-- name: LockPublicURLOwner :one
+SELECT id
+FROM users
+WHERE id = ?
+FOR UPDATE
+`
+
+func (q *Queries) LockPublicURLOwner(ctx context.Context, id []byte) ([]byte, error) {
+	row := q.db.QueryRowContext(ctx, lockPublicURLOwner, id)
+	err := row.Scan(&id)
+	return id, err
+}
+
+const markPublicURLFirstViewed = `-- name: MarkPublicURLFirstViewed :exec
+UPDATE public_urls
+SET first_viewed_at = ?,
+    updated_at = updated_at
+WHERE id = ?
+  AND first_viewed_at IS NULL
+`
+
+type MarkPublicURLFirstViewedParams struct {
+	FirstViewedAt sql.NullTime `json:"first_viewed_at"`
+	ID            int64        `json:"id"`
+}
+
+func (q *Queries) MarkPublicURLFirstViewed(ctx context.Context, arg MarkPublicURLFirstViewedParams) error {
+	_, err := q.db.ExecContext(ctx, markPublicURLFirstViewed, arg.FirstViewedAt, arg.ID)
+	return err
+}
+
+const releasePublicURLExpiryReminder = `-- name: ReleasePublicURLExpiryReminder :exec
+UPDATE public_urls
+SET expiry_reminded_at = NULL,
+    updated_at = updated_at
+WHERE id = ?
+`
+
+func (q *Queries) ReleasePublicURLExpiryReminder(ctx context.Context, id int64) error {
+	_, err := q.db.ExecContext(ctx, releasePublicURLExpiryReminder, id)
+	return err
+}
+
+const releasePublicURLFirstViewNotification = `-- name: ReleasePublicURLFirstViewNotification :exec
+UPDATE public_urls
+SET first_view_notified_at = NULL,
+    updated_at = updated_at
+WHERE id = ?
+`
+
+func (q *Queries) ReleasePublicURLFirstViewNotification(ctx context.Context, id int64) error {
+	_, err := q.db.ExecContext(ctx, releasePublicURLFirstViewNotification, id)
+	return err
+}
+
+const setPublicURLActive = `-- name: SetPublicURLActive :exec
+UPDATE public_urls
+SET is_active = ?,
+    updated_at = CURRENT_TIMESTAMP(6)
+WHERE id = ?
+  AND user_id = ?
+`
+
+type SetPublicURLActiveParams struct {
+	IsActive bool   `json:"is_active"`
+	ID       int64  `json:"id"`
+	UserID   []byte `json:"user_id"`
+}
+
+func (q *Queries) SetPublicURLActive(ctx context.Context, arg SetPublicURLActiveParams) error {
+	_, err := q.db.ExecContext(ctx, setPublicURLActive, arg.IsActive, arg.ID, arg.UserID)
+	return err
+}
+
+const setPublicURLPassphrase = `-- name: SetPublicURLPassphrase :exec
+UPDATE public_urls
+SET passphrase_hash = ?,
+    updated_at = CURRENT_TIMESTAMP(6)
+WHERE id = ?
+  AND user_id = ?
+`
+
+type SetPublicURLPassphraseParams struct {
+	PassphraseHash sql.NullString `json:"passphrase_hash"`
+	ID             int64          `json:"id"`
+	UserID         []byte         `json:"user_id"`
+}
+
+func (q *Queries) SetPublicURLPassphrase(ctx context.Context, arg SetPublicURLPassphraseParams) error {
+	_, err := q.db.ExecContext(ctx, setPublicURLPassphrase, arg.PassphraseHash, arg.ID, arg.UserID)
+	return err
+}
+
+const setPublicURLSchedule = `-- name: SetPublicURLSchedule :exec
+UPDATE public_urls
+SET activates_at = ?,
+    expires_at = ?,
+    expiry_reminded_at = NULL,
+    updated_at = CURRENT_TIMESTAMP(6)
+WHERE id = ?
+  AND user_id = ?
+`
+
+type SetPublicURLScheduleParams struct {
+	ActivatesAt sql.NullTime `json:"activates_at"`
+	ExpiresAt   sql.NullTime `json:"expires_at"`
+	ID          int64        `json:"id"`
+	UserID      []byte       `json:"user_id"`
+}
+
+func (q *Queries) SetPublicURLSchedule(ctx context.Context, arg SetPublicURLScheduleParams) error {
+	_, err := q.db.ExecContext(ctx, setPublicURLSchedule,
+		arg.ActivatesAt,
+		arg.ExpiresAt,
+		arg.ID,
+		arg.UserID,
+	)
+	return err
+}
+
+const updatePublicURLDetails = `-- name: UpdatePublicURLDetails :exec
+UPDATE public_urls
+SET label = ?,
+    cv_variant = ?,
+    notify_first_view = ?,
+    updated_at = CURRENT_TIMESTAMP(6)
+WHERE id = ?
+  AND user_id = ?
+`
+
+type UpdatePublicURLDetailsParams struct {
+	Label           string         `json:"label"`
+	CvVariant       sql.NullString `json:"cv_variant"`
+	NotifyFirstView bool           `json:"notify_first_view"`
+	ID              int64          `json:"id"`
+	UserID          []byte         `json:"user_id"`
+}
+
+func (q *Queries) UpdatePublicURLDetails(ctx context.Context, arg UpdatePublicURLDetailsParams) error {
+	_, err := q.db.ExecContext(ctx, updatePublicURLDetails,
+		arg.Label,
+		arg.CvVariant,
+		arg.NotifyFirstView,
+		arg.ID,
+		arg.UserID,
+	)
+	return err
+}
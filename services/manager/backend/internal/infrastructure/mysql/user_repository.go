@@ -0,0 +1,205 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+
+	"github.com/sky0621/techcv/manager/backend/internal/domain"
+	"github.com/sky0621/techcv/manager/backend/internal/domain/user"
+	"github.com/sky0621/techcv/manager/backend/internal/domain/uuidv7"
+	mysqlsqlc "github.com/sky0621/techcv/manager/backend/internal/infrastructure/mysql/sqlc"
+)
+
+// mysqlErrDuplicateEntry is the server error number for unique key violations.
+const mysqlErrDuplicateEntry = 1062
+
+// UserRepository persists user aggregates in MySQL.
+type UserRepository struct {
+	queries *mysqlsqlc.Queries
+}
+
+// NewUserRepository constructs a new repository backed by sqlc queries.
+func NewUserRepository(db *sql.DB) *UserRepository {
+	return &UserRepository{
+		queries: mysqlsqlc.New(db),
+	}
+}
+
+// ExistsByEmail reports whether a user with the provided email already exists.
+func (r *UserRepository) ExistsByEmail(ctx context.Context, email user.Email) (bool, error) {
+	return queriesFor(ctx, r.queries).ExistsUserByEmail(ctx, email.String())
+}
+
+// Create persists a new user aggregate.
+func (r *UserRepository) Create(ctx context.Context, u user.User) error {
+	id, err := uuidv7.ToBytes(u.ID())
+	if err != nil {
+		return fmt.Errorf("convert user id: %w", err)
+	}
+
+	err = queriesFor(ctx, r.queries).CreateUser(ctx, mysqlsqlc.CreateUserParams{
+		ID:              id,
+		Email:           u.Email().String(),
+		PasswordHash:    sql.NullString{String: u.PasswordHash(), Valid: u.PasswordHash() != ""},
+		Name:            toNullString(u.Name()),
+		Bio:             toNullString(u.Bio()),
+		IsActive:        u.IsActive(),
+		EmailVerifiedAt: sql.NullTime{Time: u.EmailVerifiedAt(), Valid: !u.EmailVerifiedAt().IsZero()},
+		LastLoginAt:     toNullTime(u.LastLoginAt()),
+		CreatedAt:       u.CreatedAt(),
+		UpdatedAt:       u.UpdatedAt(),
+	})
+	if isDuplicateEntry(err) {
+		detail := domain.ErrorDetail{Field: "email", Code: domain.ErrorCodeEmailAlreadyRegistered, Message: "このメールアドレスは既に登録されています"}
+		return domain.NewValidation(domain.ErrorCodeEmailAlreadyRegistered, "このメールアドレスは既に登録されています").WithDetails(detail)
+	}
+	return err
+}
+
+// GetByEmail loads the user aggregate associated with the given email.
+func (r *UserRepository) GetByEmail(ctx context.Context, email user.Email) (user.User, error) {
+	record, err := queriesFor(ctx, r.queries).GetUserByEmail(ctx, email.String())
+	if errors.Is(err, sql.ErrNoRows) {
+		detail := domain.ErrorDetail{Field: "email", Code: domain.ErrorCodeUserNotFound, Message: "ユーザーが見つかりません"}
+		return user.User{}, domain.NewNotFound(domain.ErrorCodeUserNotFound, "ユーザーが見つかりません").WithDetails(detail)
+	}
+	if err != nil {
+		return user.User{}, err
+	}
+
+	id, err := uuidv7.FromBytes(record.ID)
+	if err != nil {
+		return user.User{}, fmt.Errorf("convert user id: %w", err)
+	}
+	restoredEmail, err := user.NewEmail(record.Email)
+	if err != nil {
+		return user.User{}, fmt.Errorf("convert email: %w", err)
+	}
+
+	return user.RestoreUser(
+		id,
+		restoredEmail,
+		record.PasswordHash.String,
+		fromNullString(record.Name),
+		fromNullString(record.Bio),
+		record.IsActive,
+		record.EmailVerifiedAt.Time,
+		fromNullTime(record.LastLoginAt),
+		record.CreatedAt,
+		record.UpdatedAt,
+	), nil
+}
+
+// GetEmail returns the account email of the user.
+func (r *UserRepository) GetEmail(ctx context.Context, userID string) (user.Email, error) {
+	id, err := uuidv7.ToBytes(userID)
+	if err != nil {
+		return user.Email{}, fmt.Errorf("convert user id: %w", err)
+	}
+
+	email, err := queriesFor(ctx, r.queries).GetUserEmailByID(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return user.Email{}, domain.NewNotFound(domain.ErrorCodeUserNotFound, "ユーザーが見つかりません")
+	}
+	if err != nil {
+		return user.Email{}, err
+	}
+	return user.NewEmail(email)
+}
+
+// GitAuthorEmails returns the additional emails the user commits with, oldest first.
+func (r *UserRepository) GitAuthorEmails(ctx context.Context, userID string) ([]user.Email, error) {
+	id, err := uuidv7.ToBytes(userID)
+	if err != nil {
+		return nil, fmt.Errorf("convert user id: %w", err)
+	}
+
+	records, err := queriesFor(ctx, r.queries).ListUserGitAuthorEmails(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	emails := make([]user.Email, 0, len(records))
+	for _, record := range records {
+		email, err := user.NewEmail(record)
+		if err != nil {
+			return nil, fmt.Errorf("convert email: %w", err)
+		}
+		emails = append(emails, email)
+	}
+	return emails, nil
+}
+
+// ReplaceGitAuthorEmails replaces the additional emails the user commits with. Callers
+// run it in a transaction so readers never see a partial list.
+func (r *UserRepository) ReplaceGitAuthorEmails(ctx context.Context, userID string, emails []user.Email, now time.Time) error {
+	id, err := uuidv7.ToBytes(userID)
+	if err != nil {
+		return fmt.Errorf("convert user id: %w", err)
+	}
+
+	q := queriesFor(ctx, r.queries)
+	if err := q.DeleteUserGitAuthorEmails(ctx, id); err != nil {
+		return err
+	}
+	for _, email := range emails {
+		err := q.CreateUserGitAuthorEmail(ctx, mysqlsqlc.CreateUserGitAuthorEmailParams{
+			UserID:    id,
+			Email:     email.String(),
+			CreatedAt: now,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func isDuplicateEntry(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlErrDuplicateEntry
+}
+
+// isDuplicateKey reports whether err is a unique key violation of the named index. The
+// server names the key last, qualified by its table on MySQL 8.
+func isDuplicateKey(err error, index string) bool {
+	var mysqlErr *mysql.MySQLError
+	if !errors.As(err, &mysqlErr) || mysqlErr.Number != mysqlErrDuplicateEntry {
+		return false
+	}
+	return strings.HasSuffix(mysqlErr.Message, "'"+index+"'") || strings.HasSuffix(mysqlErr.Message, "."+index+"'")
+}
+
+func toNullString(s *string) sql.NullString {
+	if s == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: *s, Valid: true}
+}
+
+func fromNullString(ns sql.NullString) *string {
+	if !ns.Valid {
+		return nil
+	}
+	return &ns.String
+}
+
+func toNullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: *t, Valid: true}
+}
+
+func fromNullTime(nt sql.NullTime) *time.Time {
+	if !nt.Valid {
+		return nil
+	}
+	return &nt.Time
+}
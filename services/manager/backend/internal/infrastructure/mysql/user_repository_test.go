@@ -0,0 +1,196 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	gomysql "github.com/go-sql-driver/mysql"
+
+	"github.com/sky0621/techcv/manager/backend/internal/domain"
+	"github.com/sky0621/techcv/manager/backend/internal/domain/user"
+)
+
+const (
+	createUserQuery = "-- name: CreateUser :exec\n" +
+		"INSERT INTO users (\n" +
+		"  id,\n" +
+		"  email,\n" +
+		"  password_hash,\n" +
+		"  name,\n" +
+		"  bio,\n" +
+		"  is_active,\n" +
+		"  email_verified_at,\n" +
+		"  last_login_at,\n" +
+		"  created_at,\n" +
+		"  updated_at\n" +
+		") VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)\n"
+	getUserByEmailQuery = "-- name: GetUserByEmail :one\n" +
+		"SELECT\n" +
+		"  id,\n" +
+		"  email,\n" +
+		"  password_hash,\n" +
+		"  name,\n" +
+		"  bio,\n" +
+		"  is_active,\n" +
+		"  email_verified_at,\n" +
+		"  last_login_at,\n" +
+		"  created_at,\n" +
+		"  updated_at\n" +
+		"FROM users\n" +
+		"WHERE email = ?\n" +
+		"  AND deleted_at IS NULL\n"
+	deleteUserGitAuthorEmailsQuery = "-- name: DeleteUserGitAuthorEmails :exec\n" +
+		"DELETE FROM user_git_author_emails\n" +
+		"WHERE user_id = ?\n"
+	createUserGitAuthorEmailQuery = "-- name: CreateUserGitAuthorEmail :exec\n" +
+		"INSERT INTO user_git_author_emails (\n" +
+		"  user_id,\n" +
+		"  email,\n" +
+		"  created_at\n" +
+		") VALUES (?, ?, ?)\n"
+)
+
+func TestUserRepositoryCreateDuplicateEmail(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer func() {
+		mock.ExpectClose()
+		if closeErr := db.Close(); closeErr != nil {
+			t.Fatalf("failed to close db: %v", closeErr)
+		}
+	}()
+
+	email, err := user.NewEmail("taken@example.com")
+	if err != nil {
+		t.Fatalf("unexpected email error: %v", err)
+	}
+	u, err := user.NewUser(email, "hash", time.Now())
+	if err != nil {
+		t.Fatalf("unexpected user error: %v", err)
+	}
+
+	mock.ExpectExec(regexp.QuoteMeta(createUserQuery)).
+		WillReturnError(&gomysql.MySQLError{Number: mysqlErrDuplicateEntry, Message: "Duplicate entry"})
+
+	err = NewUserRepository(db).Create(context.Background(), u)
+
+	var appErr *domain.AppError
+	if !errors.As(err, &appErr) || appErr.Code != domain.ErrorCodeEmailAlreadyRegistered {
+		t.Fatalf("expected email already registered error, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestUserRepositoryGetByEmail(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer func() {
+		mock.ExpectClose()
+		if closeErr := db.Close(); closeErr != nil {
+			t.Fatalf("failed to close db: %v", closeErr)
+		}
+	}()
+
+	now := time.Now()
+	rows := sqlmock.
+		NewRows([]string{
+			"id", "email", "password_hash", "name", "bio", "is_active",
+			"email_verified_at", "last_login_at", "created_at", "updated_at",
+		}).
+		AddRow(ownerBytes(t), "owner@example.com", "hash", "Owner", nil, true, now, nil, now, now)
+
+	mock.ExpectQuery(regexp.QuoteMeta(getUserByEmailQuery)).WithArgs("owner@example.com").WillReturnRows(rows)
+
+	email, err := user.NewEmail("owner@example.com")
+	if err != nil {
+		t.Fatalf("unexpected email error: %v", err)
+	}
+	result, err := NewUserRepository(db).GetByEmail(context.Background(), email)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.ID() != ownerID || result.Name() == nil || *result.Name() != "Owner" || result.Bio() != nil || result.LastLoginAt() != nil {
+		t.Fatalf("unexpected user: %+v", result)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestUserRepositoryGetByEmailNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer func() {
+		mock.ExpectClose()
+		if closeErr := db.Close(); closeErr != nil {
+			t.Fatalf("failed to close db: %v", closeErr)
+		}
+	}()
+
+	mock.ExpectQuery(regexp.QuoteMeta(getUserByEmailQuery)).
+		WithArgs("missing@example.com").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	email, err := user.NewEmail("missing@example.com")
+	if err != nil {
+		t.Fatalf("unexpected email error: %v", err)
+	}
+	_, err = NewUserRepository(db).GetByEmail(context.Background(), email)
+
+	var appErr *domain.AppError
+	if !errors.As(err, &appErr) || appErr.Code != domain.ErrorCodeUserNotFound {
+		t.Fatalf("expected user not found error, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestUserRepositoryReplaceGitAuthorEmails(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer func() {
+		mock.ExpectClose()
+		if closeErr := db.Close(); closeErr != nil {
+			t.Fatalf("failed to close db: %v", closeErr)
+		}
+	}()
+
+	now := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+	mock.ExpectExec(regexp.QuoteMeta(deleteUserGitAuthorEmailsQuery)).
+		WithArgs(ownerBytes(t)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(regexp.QuoteMeta(createUserGitAuthorEmailQuery)).
+		WithArgs(ownerBytes(t), "owner@work.example.com", now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	email, err := user.NewEmail("owner@work.example.com")
+	if err != nil {
+		t.Fatalf("unexpected email error: %v", err)
+	}
+	if err := NewUserRepository(db).ReplaceGitAuthorEmails(context.Background(), ownerID, []user.Email{email}, now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
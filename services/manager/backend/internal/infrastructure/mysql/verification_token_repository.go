@@ -0,0 +1,74 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/sky0621/techcv/manager/backend/internal/domain"
+	"github.com/sky0621/techcv/manager/backend/internal/domain/user"
+	"github.com/sky0621/techcv/manager/backend/internal/domain/uuidv7"
+	mysqlsqlc "github.com/sky0621/techcv/manager/backend/internal/infrastructure/mysql/sqlc"
+)
+
+// VerificationTokenRepository persists email verification tokens in MySQL.
+type VerificationTokenRepository struct {
+	queries *mysqlsqlc.Queries
+}
+
+// NewVerificationTokenRepository constructs a new repository backed by sqlc queries.
+func NewVerificationTokenRepository(db *sql.DB) *VerificationTokenRepository {
+	return &VerificationTokenRepository{
+		queries: mysqlsqlc.New(db),
+	}
+}
+
+// Save persists a new verification token.
+func (r *VerificationTokenRepository) Save(ctx context.Context, token user.VerificationToken) error {
+	id, err := uuidv7.ToBytes(token.ID())
+	if err != nil {
+		return fmt.Errorf("convert token id: %w", err)
+	}
+
+	return queriesFor(ctx, r.queries).CreateEmailVerificationToken(ctx, mysqlsqlc.CreateEmailVerificationTokenParams{
+		ID:           id,
+		UserEmail:    token.Email().String(),
+		Token:        token.Token(),
+		PasswordHash: token.PasswordHash(),
+		ExpiresAt:    token.ExpiresAt(),
+		CreatedAt:    token.CreatedAt(),
+	})
+}
+
+// FindByToken retrieves a token by its value.
+func (r *VerificationTokenRepository) FindByToken(ctx context.Context, token string) (user.VerificationToken, error) {
+	record, err := queriesFor(ctx, r.queries).GetEmailVerificationTokenByToken(ctx, token)
+	if errors.Is(err, sql.ErrNoRows) {
+		detail := domain.ErrorDetail{Field: "token", Code: domain.ErrorCodeTokenNotFound, Message: "確認トークンが見つかりません"}
+		return user.VerificationToken{}, domain.NewNotFound(domain.ErrorCodeTokenNotFound, "確認トークンが見つかりません").WithDetails(detail)
+	}
+	if err != nil {
+		return user.VerificationToken{}, err
+	}
+
+	id, err := uuidv7.FromBytes(record.ID)
+	if err != nil {
+		return user.VerificationToken{}, fmt.Errorf("convert token id: %w", err)
+	}
+	email, err := user.NewEmail(record.UserEmail)
+	if err != nil {
+		return user.VerificationToken{}, fmt.Errorf("restore email: %w", err)
+	}
+	return user.RestoreVerificationToken(id, email, record.Token, record.PasswordHash, record.ExpiresAt, record.CreatedAt), nil
+}
+
+// DeleteByToken removes a token using its value.
+func (r *VerificationTokenRepository) DeleteByToken(ctx context.Context, token string) error {
+	return queriesFor(ctx, r.queries).DeleteEmailVerificationTokenByToken(ctx, token)
+}
+
+// DeleteByEmail removes all tokens associated with the given email.
+func (r *VerificationTokenRepository) DeleteByEmail(ctx context.Context, email user.Email) error {
+	return queriesFor(ctx, r.queries).DeleteEmailVerificationTokensByEmail(ctx, email.String())
+}
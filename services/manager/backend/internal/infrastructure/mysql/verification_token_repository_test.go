@@ -0,0 +1,73 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/sky0621/techcv/manager/backend/internal/domain"
+	"github.com/sky0621/techcv/manager/backend/internal/domain/uuidv7"
+)
+
+const (
+	tokenID                               = "0190c8a1-b2c3-7d4e-8f00-665544332211"
+	getEmailVerificationTokenByTokenQuery = "-- name: GetEmailVerificationTokenByToken :one\n" +
+		"SELECT\n" +
+		"  id,\n" +
+		"  user_email,\n" +
+		"  token,\n" +
+		"  password_hash,\n" +
+		"  expires_at,\n" +
+		"  created_at\n" +
+		"FROM email_verification_tokens\n" +
+		"WHERE token = ?\n"
+)
+
+func TestVerificationTokenRepositoryFindByToken(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer func() {
+		mock.ExpectClose()
+		if closeErr := db.Close(); closeErr != nil {
+			t.Fatalf("failed to close db: %v", closeErr)
+		}
+	}()
+
+	id, err := uuidv7.ToBytes(tokenID)
+	if err != nil {
+		t.Fatalf("ToBytes() error = %v", err)
+	}
+	createdAt := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(regexp.QuoteMeta(getEmailVerificationTokenByTokenQuery)).
+		WithArgs("the-token").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_email", "token", "password_hash", "expires_at", "created_at"}).
+			AddRow(id, "taro@example.com", "the-token", "hash", createdAt.Add(24*time.Hour), createdAt))
+	mock.ExpectQuery(regexp.QuoteMeta(getEmailVerificationTokenByTokenQuery)).
+		WithArgs("missing").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	repo := NewVerificationTokenRepository(db)
+	token, err := repo.FindByToken(context.Background(), "the-token")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if token.ID() != tokenID || token.Email().String() != "taro@example.com" || token.PasswordHash() != "hash" || token.IsExpired(createdAt) {
+		t.Fatalf("unexpected token: %+v", token)
+	}
+
+	_, err = repo.FindByToken(context.Background(), "missing")
+	var appErr *domain.AppError
+	if !errors.As(err, &appErr) || appErr.Code != domain.ErrorCodeTokenNotFound {
+		t.Fatalf("expected token not found, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
@@ -0,0 +1,54 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/sky0621/techcv/manager/backend/internal/domain/skill"
+)
+
+// SkillCatalogRepository provides in-memory storage for the skill catalog.
+type SkillCatalogRepository struct {
+	mu       sync.RWMutex
+	catalog  *skill.Catalog
+	revision uint64
+}
+
+// NewSkillCatalogRepository constructs a repository holding the given initial catalog.
+func NewSkillCatalogRepository(initial *skill.Catalog) *SkillCatalogRepository {
+	return &SkillCatalogRepository{
+		catalog: initial.Clone(),
+	}
+}
+
+// Load returns a copy of the stored catalog so callers can modify it freely.
+func (r *SkillCatalogRepository) Load(_ context.Context) (*skill.Catalog, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.catalog.Clone(), nil
+}
+
+// Save replaces the stored catalog.
+func (r *SkillCatalogRepository) Save(_ context.Context, catalog *skill.Catalog) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.catalog = catalog.Clone()
+	r.revision++
+	return nil
+}
+
+// Revision returns the number of times the catalog has been saved.
+func (r *SkillCatalogRepository) Revision(_ context.Context) (uint64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.revision, nil
+}
+
+// Lock is a no-op; the repository is only shared within one process, where callers
+// serialize their own changes.
+func (r *SkillCatalogRepository) Lock(_ context.Context) error {
+	return nil
+}
@@ -0,0 +1,65 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// SkillUsageRepository provides in-memory storage for the users of each skill.
+type SkillUsageRepository struct {
+	mu    sync.RWMutex
+	users map[string]map[string]struct{}
+}
+
+// NewSkillUsageRepository constructs a new repository instance.
+func NewSkillUsageRepository() *SkillUsageRepository {
+	return &SkillUsageRepository{
+		users: make(map[string]map[string]struct{}),
+	}
+}
+
+// Counts returns a snapshot of the number of users per skill identifier.
+func (r *SkillUsageRepository) Counts(_ context.Context) (map[string]int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	snapshot := make(map[string]int, len(r.users))
+	for id, users := range r.users {
+		snapshot[id] = len(users)
+	}
+	return snapshot, nil
+}
+
+// Record marks the skills as used by the user.
+func (r *SkillUsageRepository) Record(_ context.Context, userID string, skillIDs []string, _ time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, id := range skillIDs {
+		if r.users[id] == nil {
+			r.users[id] = make(map[string]struct{})
+		}
+		r.users[id][userID] = struct{}{}
+	}
+	return nil
+}
+
+// Transfer moves the users of one skill onto another.
+func (r *SkillUsageRepository) Transfer(_ context.Context, fromID, toID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	users, ok := r.users[fromID]
+	if !ok {
+		return nil
+	}
+	if r.users[toID] == nil {
+		r.users[toID] = make(map[string]struct{}, len(users))
+	}
+	for userID := range users {
+		r.users[toID][userID] = struct{}{}
+	}
+	delete(r.users, fromID)
+	return nil
+}
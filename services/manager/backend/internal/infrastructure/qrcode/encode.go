@@ -0,0 +1,454 @@
+// Package qrcode renders QR codes (ISO/IEC 18004) as PNG and SVG images.
+package qrcode
+
+import "errors"
+
+const (
+	minVersion = 1
+	maxVersion = 40
+	// modeByte is the mode indicator of 8-bit byte data.
+	modeByte = 0x4
+)
+
+// errDataTooLong is returned when the data does not fit even the largest symbol.
+var errDataTooLong = errors.New("qrcode: data too long")
+
+// symbol is an encoded QR code: a square of dark and light modules without the quiet zone.
+type symbol struct {
+	version  int
+	level    eccLevel
+	size     int
+	modules  []bool
+	function []bool
+}
+
+// dark reports whether the module in column x and row y is dark.
+func (s *symbol) dark(x, y int) bool {
+	return s.modules[y*s.size+x]
+}
+
+// encode encodes data in byte mode into the smallest symbol that holds it at the level,
+// using the mask with the lowest penalty.
+func encode(data []byte, level eccLevel) (*symbol, error) {
+	version := minVersion
+	for ; version <= maxVersion; version++ {
+		if dataBits(len(data), version) <= dataCodewords(version, level)*8 {
+			break
+		}
+	}
+	if version > maxVersion {
+		return nil, errDataTooLong
+	}
+
+	codewords := addErrorCorrection(dataCodewordsFor(data, version, level), version, level)
+
+	s := newSymbol(version, level)
+	s.drawFunctionPatterns(version)
+	s.drawCodewords(codewords)
+
+	best, bestPenalty := 0, -1
+	for mask := 0; mask < 8; mask++ {
+		s.applyMask(mask)
+		s.drawFormat(level, mask)
+		if penalty := s.penalty(); bestPenalty < 0 || penalty < bestPenalty {
+			best, bestPenalty = mask, penalty
+		}
+		s.applyMask(mask)
+	}
+	s.applyMask(best)
+	s.drawFormat(level, best)
+	return s, nil
+}
+
+func newSymbol(version int, level eccLevel) *symbol {
+	size := version*4 + 17
+	return &symbol{
+		version:  version,
+		level:    level,
+		size:     size,
+		modules:  make([]bool, size*size),
+		function: make([]bool, size*size),
+	}
+}
+
+// countBits returns the length of the character count indicator of byte mode.
+func countBits(version int) int {
+	if version <= 9 {
+		return 8
+	}
+	return 16
+}
+
+func dataBits(length, version int) int {
+	return 4 + countBits(version) + length*8
+}
+
+// dataCodewordsFor builds the data codewords: mode, count, data, terminator and padding.
+func dataCodewordsFor(data []byte, version int, level eccLevel) []byte {
+	capacity := dataCodewords(version, level)
+	var w bitWriter
+	w.write(modeByte, 4)
+	w.write(len(data), countBits(version))
+	for _, b := range data {
+		w.write(int(b), 8)
+	}
+	w.write(0, min(4, capacity*8-w.length))
+	if rem := w.length % 8; rem != 0 {
+		w.write(0, 8-rem)
+	}
+	for pad := 0xec; len(w.bytes) < capacity; pad ^= 0xec ^ 0x11 {
+		w.write(pad, 8)
+	}
+	return w.bytes
+}
+
+// addErrorCorrection splits the data codewords into blocks, appends each block's error
+// correction codewords and interleaves the blocks.
+func addErrorCorrection(data []byte, version int, level eccLevel) []byte {
+	blocks := eccBlocks[level][version]
+	eccLength := eccCodewordsPerBlock[level][version]
+	raw := rawDataModules(version) / 8
+	// The first blocks are one data codeword shorter than the rest.
+	shortBlocks := blocks - raw%blocks
+	shortLength := raw / blocks
+
+	divisor := rsDivisor(eccLength)
+	all := make([][]byte, 0, blocks)
+	for i, k := 0, 0; i < blocks; i++ {
+		length := shortLength - eccLength
+		if i >= shortBlocks {
+			length++
+		}
+		block := append([]byte(nil), data[k:k+length]...)
+		k += length
+		ecc := rsRemainder(block, divisor)
+		if i < shortBlocks {
+			block = append(block, 0)
+		}
+		all = append(all, append(block, ecc...))
+	}
+
+	result := make([]byte, 0, raw)
+	for i := range all[0] {
+		for j, block := range all {
+			// Skip the padding byte of short blocks.
+			if i != shortLength-eccLength || j >= shortBlocks {
+				result = append(result, block[i])
+			}
+		}
+	}
+	return result
+}
+
+// codewordBlocks returns the block of every codeword in the interleaved order that
+// addErrorCorrection produces.
+func codewordBlocks(version int, level eccLevel) []int {
+	blocks := eccBlocks[level][version]
+	eccLength := eccCodewordsPerBlock[level][version]
+	raw := rawDataModules(version) / 8
+	shortBlocks := blocks - raw%blocks
+	shortLength := raw / blocks
+
+	result := make([]int, 0, raw)
+	for i := 0; i <= shortLength; i++ {
+		for j := 0; j < blocks; j++ {
+			if i != shortLength-eccLength || j >= shortBlocks {
+				result = append(result, j)
+			}
+		}
+	}
+	return result
+}
+
+func (s *symbol) setFunction(x, y int, dark bool) {
+	s.modules[y*s.size+x] = dark
+	s.function[y*s.size+x] = true
+}
+
+func (s *symbol) drawFunctionPatterns(version int) {
+	for i := 0; i < s.size; i++ {
+		s.setFunction(6, i, i%2 == 0)
+		s.setFunction(i, 6, i%2 == 0)
+	}
+
+	s.drawFinder(3, 3)
+	s.drawFinder(s.size-4, 3)
+	s.drawFinder(3, s.size-4)
+
+	positions := alignmentPositions(version)
+	last := len(positions) - 1
+	for i, x := range positions {
+		for j, y := range positions {
+			// Alignment patterns would overlap the finder patterns in three corners.
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+			s.drawAlignment(x, y)
+		}
+	}
+
+	// Reserve the format areas; drawFormat fills them in once the mask is chosen.
+	s.drawFormat(levelL, 0)
+	s.drawVersion(version)
+}
+
+// drawFinder draws a finder pattern with its separator centered on x, y.
+func (s *symbol) drawFinder(x, y int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			xx, yy := x+dx, y+dy
+			if xx < 0 || xx >= s.size || yy < 0 || yy >= s.size {
+				continue
+			}
+			dist := max(abs(dx), abs(dy))
+			s.setFunction(xx, yy, dist != 2 && dist != 4)
+		}
+	}
+}
+
+func (s *symbol) drawAlignment(x, y int) {
+	for dy := -2; dy <= 2; dy++ {
+		for dx := -2; dx <= 2; dx++ {
+			s.setFunction(x+dx, y+dy, max(abs(dx), abs(dy)) != 1)
+		}
+	}
+}
+
+// formatInformation returns the 15 format bits of the level and mask, BCH-coded and masked.
+func formatInformation(level eccLevel, mask int) int {
+	data := formatBits[level]<<3 | mask
+	rem := data
+	for i := 0; i < 10; i++ {
+		rem = (rem << 1) ^ ((rem >> 9) * 0x537)
+	}
+	return (data<<10 | rem) ^ 0x5412
+}
+
+// versionInformation returns the 18 BCH-coded version bits drawn from version 7 on.
+func versionInformation(version int) int {
+	rem := version
+	for i := 0; i < 12; i++ {
+		rem = (rem << 1) ^ ((rem >> 11) * 0x1f25)
+	}
+	return version<<12 | rem
+}
+
+func (s *symbol) drawFormat(level eccLevel, mask int) {
+	bits := formatInformation(level, mask)
+
+	// The copy around the top left finder pattern.
+	for i := 0; i <= 5; i++ {
+		s.setFunction(8, i, bit(bits, i))
+	}
+	s.setFunction(8, 7, bit(bits, 6))
+	s.setFunction(8, 8, bit(bits, 7))
+	s.setFunction(7, 8, bit(bits, 8))
+	for i := 9; i < 15; i++ {
+		s.setFunction(14-i, 8, bit(bits, i))
+	}
+
+	// The copy split between the other two finder patterns.
+	for i := 0; i < 8; i++ {
+		s.setFunction(s.size-1-i, 8, bit(bits, i))
+	}
+	for i := 8; i < 15; i++ {
+		s.setFunction(8, s.size-15+i, bit(bits, i))
+	}
+	s.setFunction(8, s.size-8, true)
+}
+
+func (s *symbol) drawVersion(version int) {
+	if version < 7 {
+		return
+	}
+	bits := versionInformation(version)
+	for i := 0; i < 18; i++ {
+		a, b := s.size-11+i%3, i/3
+		s.setFunction(a, b, bit(bits, i))
+		s.setFunction(b, a, bit(bits, i))
+	}
+}
+
+// eachDataModule calls fn with the data modules in the zigzag order of two-module wide
+// columns running up and down from the bottom right corner, skipping function modules.
+func (s *symbol) eachDataModule(fn func(x, y int)) {
+	for right := s.size - 1; right >= 1; right -= 2 {
+		// The vertical timing pattern takes a whole column.
+		if right == 6 {
+			right = 5
+		}
+		upward := (right+1)&2 == 0
+		for vert := 0; vert < s.size; vert++ {
+			y := vert
+			if upward {
+				y = s.size - 1 - vert
+			}
+			for j := 0; j < 2; j++ {
+				if x := right - j; !s.function[y*s.size+x] {
+					fn(x, y)
+				}
+			}
+		}
+	}
+}
+
+// drawCodewords places the codewords on the data modules; the remainder bits stay light.
+func (s *symbol) drawCodewords(codewords []byte) {
+	i := 0
+	s.eachDataModule(func(x, y int) {
+		if i < len(codewords)*8 {
+			s.modules[y*s.size+x] = bit(int(codewords[i>>3]), 7-i&7)
+			i++
+		}
+	})
+}
+
+// codewordAt returns, for every module, the index of the codeword it holds, or -1 for
+// function modules and remainder bits.
+func (s *symbol) codewordAt() []int {
+	count := rawDataModules(s.version) / 8
+	result := make([]int, len(s.modules))
+	for i := range result {
+		result[i] = -1
+	}
+	i := 0
+	s.eachDataModule(func(x, y int) {
+		if i/8 < count {
+			result[y*s.size+x] = i / 8
+		}
+		i++
+	})
+	return result
+}
+
+// applyMask inverts the data modules selected by the mask. Applying a mask twice undoes it.
+func (s *symbol) applyMask(mask int) {
+	for y := 0; y < s.size; y++ {
+		for x := 0; x < s.size; x++ {
+			if !s.function[y*s.size+x] && masked(mask, x, y) {
+				s.modules[y*s.size+x] = !s.modules[y*s.size+x]
+			}
+		}
+	}
+}
+
+func masked(mask, x, y int) bool {
+	switch mask {
+	case 0:
+		return (x+y)%2 == 0
+	case 1:
+		return y%2 == 0
+	case 2:
+		return x%3 == 0
+	case 3:
+		return (x+y)%3 == 0
+	case 4:
+		return (x/3+y/2)%2 == 0
+	case 5:
+		return x*y%2+x*y%3 == 0
+	case 6:
+		return (x*y%2+x*y%3)%2 == 0
+	default:
+		return ((x+y)%2+x*y%3)%2 == 0
+	}
+}
+
+// finderLike is the 1:1:3:1:1 finder pattern preceded by four light modules, in both
+// directions, which the third penalty rule looks for.
+var finderLike = [2][11]bool{
+	{false, false, false, false, true, false, true, true, true, false, true},
+	{true, false, true, true, true, false, true, false, false, false, false},
+}
+
+// penalty scores the symbol by the four rules of ISO/IEC 18004 7.8.3; lower is better.
+func (s *symbol) penalty() int {
+	total := 0
+	for _, vertical := range []bool{false, true} {
+		at := func(i, j int) bool {
+			if vertical {
+				return s.dark(i, j)
+			}
+			return s.dark(j, i)
+		}
+		for i := 0; i < s.size; i++ {
+			run := 1
+			for j := 1; j < s.size; j++ {
+				if at(i, j) == at(i, j-1) {
+					run++
+					continue
+				}
+				if run >= 5 {
+					total += run - 2
+				}
+				run = 1
+			}
+			if run >= 5 {
+				total += run - 2
+			}
+
+			for j := 0; j+len(finderLike[0]) <= s.size; j++ {
+				for _, pattern := range finderLike {
+					if matches(pattern[:], func(k int) bool { return at(i, j+k) }) {
+						total += 40
+					}
+				}
+			}
+		}
+	}
+
+	darkCount := 0
+	for y := 0; y < s.size; y++ {
+		for x := 0; x < s.size; x++ {
+			if s.dark(x, y) {
+				darkCount++
+			}
+			if x+1 < s.size && y+1 < s.size {
+				c := s.dark(x, y)
+				if c == s.dark(x+1, y) && c == s.dark(x, y+1) && c == s.dark(x+1, y+1) {
+					total += 3
+				}
+			}
+		}
+	}
+	percent := darkCount * 100 / (s.size * s.size)
+	total += abs(percent-50) / 5 * 10
+	return total
+}
+
+func matches(pattern []bool, at func(int) bool) bool {
+	for k, want := range pattern {
+		if at(k) != want {
+			return false
+		}
+	}
+	return true
+}
+
+// bitWriter appends bits most significant first.
+type bitWriter struct {
+	bytes  []byte
+	length int
+}
+
+func (w *bitWriter) write(value, n int) {
+	for i := n - 1; i >= 0; i-- {
+		if w.length%8 == 0 {
+			w.bytes = append(w.bytes, 0)
+		}
+		if bit(value, i) {
+			w.bytes[len(w.bytes)-1] |= 0x80 >> (w.length % 8)
+		}
+		w.length++
+	}
+}
+
+func bit(value, i int) bool {
+	return (value>>i)&1 != 0
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
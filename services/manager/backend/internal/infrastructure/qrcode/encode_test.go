@@ -0,0 +1,181 @@
+package qrcode
+
+import (
+	"bytes"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestRSRemainder(t *testing.T) {
+	// The data codewords of "HELLO WORLD" in a 1-M symbol and their error correction.
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	want := []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}
+
+	if got := rsRemainder(data, rsDivisor(len(want))); !bytes.Equal(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestFormatInformation(t *testing.T) {
+	tests := []struct {
+		level eccLevel
+		mask  int
+		want  string
+	}{
+		{level: levelL, mask: 0, want: "111011111000100"},
+		{level: levelM, mask: 0, want: "101010000010010"},
+		{level: levelQ, mask: 0, want: "011010101011111"},
+		{level: levelH, mask: 0, want: "001011010001001"},
+		{level: levelL, mask: 4, want: "110011000101111"},
+	}
+
+	for _, tt := range tests {
+		got := strconv.FormatInt(int64(formatInformation(tt.level, tt.mask)), 2)
+		got = strings.Repeat("0", 15-len(got)) + got
+		if got != tt.want {
+			t.Errorf("level %d mask %d: expected %s, got %s", tt.level, tt.mask, tt.want, got)
+		}
+	}
+}
+
+func TestVersionInformation(t *testing.T) {
+	if got := versionInformation(7); got != 0b000111110010010100 {
+		t.Fatalf("expected version 7 information 000111110010010100, got %018b", got)
+	}
+}
+
+func TestAlignmentPositions(t *testing.T) {
+	tests := map[int][]int{
+		1:  nil,
+		2:  {6, 18},
+		7:  {6, 22, 38},
+		32: {6, 34, 60, 86, 112, 138},
+		40: {6, 30, 58, 86, 114, 142, 170},
+	}
+
+	for version, want := range tests {
+		got := alignmentPositions(version)
+		if !slices.Equal(got, want) {
+			t.Errorf("version %d: expected %v, got %v", version, want, got)
+		}
+	}
+}
+
+func TestEncodeCanBeReadBack(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		level   eccLevel
+		version int
+	}{
+		{name: "short link at M", content: "https://techcv.example.com/cv/3f9a1c", level: levelM, version: 3},
+		{name: "key link at H", content: "https://techcv.example.com/cv/0123456789abcdef0123456789abcdef", level: levelH, version: 7},
+		{name: "long content with version information", content: strings.Repeat("techcv-", 40), level: levelQ, version: 15},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := encode([]byte(tt.content), tt.level)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if s.size != tt.version*4+17 {
+				t.Fatalf("expected version %d, got size %d", tt.version, s.size)
+			}
+
+			for _, corner := range [][2]int{{0, 0}, {s.size - 7, 0}, {0, s.size - 7}} {
+				for i := 0; i < 7; i++ {
+					if !s.dark(corner[0]+i, corner[1]) || !s.dark(corner[0], corner[1]+i) {
+						t.Fatalf("expected finder pattern border at %v", corner)
+					}
+				}
+			}
+			if !s.dark(8, s.size-8) {
+				t.Fatalf("expected the dark module")
+			}
+
+			level, mask := readFormat(t, s)
+			if level != tt.level {
+				t.Fatalf("expected level %d in format information, got %d", tt.level, level)
+			}
+
+			s.applyMask(mask)
+			got := readCodewords(s)
+			want := addErrorCorrection(dataCodewordsFor([]byte(tt.content), tt.version, tt.level), tt.version, tt.level)
+			if !bytes.Equal(got, want) {
+				t.Fatalf("codewords read back differ from the encoded ones")
+			}
+
+			data := dataCodewordsFor([]byte(tt.content), tt.version, tt.level)
+			if data[0]>>4 != modeByte {
+				t.Fatalf("expected byte mode, got %x", data[0]>>4)
+			}
+		})
+	}
+}
+
+func TestEncodeRejectsTooLongData(t *testing.T) {
+	if _, err := encode(make([]byte, 3000), levelL); err != errDataTooLong {
+		t.Fatalf("expected errDataTooLong, got %v", err)
+	}
+}
+
+// readFormat decodes the format information next to the top left finder pattern.
+func readFormat(t *testing.T, s *symbol) (eccLevel, int) {
+	t.Helper()
+	bits := 0
+	set := func(i int, dark bool) {
+		if dark {
+			bits |= 1 << i
+		}
+	}
+	for i := 0; i <= 5; i++ {
+		set(i, s.dark(8, i))
+	}
+	set(6, s.dark(8, 7))
+	set(7, s.dark(8, 8))
+	set(8, s.dark(7, 8))
+	for i := 9; i < 15; i++ {
+		set(i, s.dark(14-i, 8))
+	}
+
+	for _, level := range []eccLevel{levelL, levelM, levelQ, levelH} {
+		for mask := 0; mask < 8; mask++ {
+			if formatInformation(level, mask) == bits {
+				return level, mask
+			}
+		}
+	}
+	t.Fatalf("invalid format information %015b", bits)
+	return 0, 0
+}
+
+// readCodewords reads the unmasked data modules back in placement order.
+func readCodewords(s *symbol) []byte {
+	var w bitWriter
+	for right := s.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		upward := (right+1)&2 == 0
+		for vert := 0; vert < s.size; vert++ {
+			y := vert
+			if upward {
+				y = s.size - 1 - vert
+			}
+			for j := 0; j < 2; j++ {
+				if x := right - j; !s.function[y*s.size+x] {
+					value := 0
+					if s.dark(x, y) {
+						value = 1
+					}
+					w.write(value, 1)
+				}
+			}
+		}
+	}
+	// Drop the remainder bits that follow the last codeword.
+	return w.bytes[:w.length/8]
+}
@@ -0,0 +1,251 @@
+package qrcode
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// quietZone is the light border around the symbol, in modules, that scanners need.
+const quietZone = 4
+
+// errSizeTooSmall is returned when the image cannot give every module at least one pixel.
+var errSizeTooSmall = errors.New("qrcode: image size too small for the code")
+
+// logoMark is the techcv "</>" mark drawn on a light plate near the center of the code.
+var logoMark = []string{
+	"...#.......#.#...",
+	"..#.......#...#..",
+	".#.......#.....#.",
+	"#.......#.......#",
+	".#.....#.......#.",
+	"..#...#.......#..",
+	"...#.#.......#...",
+}
+
+// plate is the light square, in modules, cleared for the logo. A zero width means no logo.
+type plate struct {
+	x, y, width int
+}
+
+func (p plate) covers(x, y int) bool {
+	return x >= p.x && x < p.x+p.width && y >= p.y && y < p.y+p.width
+}
+
+// minLogoPlate is the narrowest plate, in modules, worth drawing the logo on.
+const minLogoPlate = 3
+
+// logoPlate places the logo plate. Scanners read the code back through error correction,
+// so the plate never covers a function pattern, which they need to find the modules, and
+// hides at most a quarter of the codewords of any block, half of what the block can
+// correct, to leave the rest for wear and bad lighting. It is the widest such square of
+// up to a fifth of the symbol and sits as close to the center as the function patterns
+// allow; from version 7 on that is off the center, which holds an alignment pattern.
+func logoPlate(s *symbol) plate {
+	fit := newPlateFit(s)
+	width := s.size / 5
+	if width%2 == 0 {
+		width++
+	}
+	for ; width >= minLogoPlate; width -= 2 {
+		candidates := make([]plate, 0, (s.size-width+1)*(s.size-width+1))
+		for y := 0; y+width <= s.size; y++ {
+			for x := 0; x+width <= s.size; x++ {
+				candidates = append(candidates, plate{x: x, y: y, width: width})
+			}
+		}
+		offCenter := func(p plate) int {
+			dx, dy := 2*p.x+p.width-s.size, 2*p.y+p.width-s.size
+			return dx*dx + dy*dy
+		}
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return offCenter(candidates[i]) < offCenter(candidates[j])
+		})
+		for _, p := range candidates {
+			if fit.fits(p) {
+				return p
+			}
+		}
+	}
+	return plate{}
+}
+
+// plateFit checks candidate plates against the function patterns and the error correction
+// budget of a symbol.
+type plateFit struct {
+	s         *symbol
+	codewords []int
+	blocks    []int
+	budget    int
+	// functions counts the function modules above and to the left of every module, so
+	// that a plate covering one is rejected without visiting its modules.
+	functions []int
+	// seen marks the codewords hidden by the plate being checked, perBlock counts them.
+	seen     []int
+	perBlock []int
+	checked  int
+}
+
+func newPlateFit(s *symbol) *plateFit {
+	blocks := codewordBlocks(s.version, s.level)
+	f := &plateFit{
+		s:         s,
+		codewords: s.codewordAt(),
+		blocks:    blocks,
+		budget:    eccCodewordsPerBlock[s.level][s.version] / 4,
+		functions: make([]int, (s.size+1)*(s.size+1)),
+		seen:      make([]int, len(blocks)),
+		perBlock:  make([]int, eccBlocks[s.level][s.version]),
+	}
+	stride := s.size + 1
+	for y := 0; y < s.size; y++ {
+		for x := 0; x < s.size; x++ {
+			n := f.functions[y*stride+x+1] + f.functions[(y+1)*stride+x] - f.functions[y*stride+x]
+			if s.function[y*s.size+x] {
+				n++
+			}
+			f.functions[(y+1)*stride+x+1] = n
+		}
+	}
+	return f
+}
+
+// fits reports whether the plate leaves the function patterns alone and hides no more
+// than the budget of codewords of any block.
+func (f *plateFit) fits(p plate) bool {
+	stride := f.s.size + 1
+	x0, y0, x1, y1 := p.x, p.y, p.x+p.width, p.y+p.width
+	if f.functions[y1*stride+x1]-f.functions[y0*stride+x1]-f.functions[y1*stride+x0]+f.functions[y0*stride+x0] > 0 {
+		return false
+	}
+
+	f.checked++
+	clear(f.perBlock)
+	for y := y0; y < y1; y++ {
+		for x := x0; x < x1; x++ {
+			codeword := f.codewords[y*f.s.size+x]
+			if codeword < 0 || f.seen[codeword] == f.checked {
+				continue
+			}
+			f.seen[codeword] = f.checked
+			block := f.blocks[codeword]
+			if f.perBlock[block] == f.budget {
+				return false
+			}
+			f.perBlock[block]++
+		}
+	}
+	return true
+}
+
+// visible reports whether the module is drawn dark, that is dark and not under the logo.
+func visible(s *symbol, x, y int, logo plate) bool {
+	return s.dark(x, y) && !logo.covers(x, y)
+}
+
+// renderPNG draws the symbol on a size by size image. Modules are scaled by a whole number
+// of pixels to stay sharp; what is left over widens the quiet zone.
+func renderPNG(s *symbol, size int, logo plate) ([]byte, error) {
+	total := s.size + 2*quietZone
+	scale := size / total
+	if scale < 1 {
+		return nil, errSizeTooSmall
+	}
+	offset := (size-scale*total)/2 + quietZone*scale
+
+	img := image.NewPaletted(image.Rect(0, 0, size, size), color.Palette{color.White, color.Black})
+	fill := func(x0, y0, width, height int) {
+		for y := y0; y < y0+height; y++ {
+			for x := x0; x < x0+width; x++ {
+				img.SetColorIndex(x, y, 1)
+			}
+		}
+	}
+
+	for y := 0; y < s.size; y++ {
+		for x := 0; x < s.size; x++ {
+			if visible(s, x, y, logo) {
+				fill(offset+x*scale, offset+y*scale, scale, scale)
+			}
+		}
+	}
+
+	if logo.width > 0 {
+		width := logo.width * scale
+		markWidth, markHeight := len(logoMark[0]), len(logoMark)
+		// The mark keeps a margin of a tenth of the plate on either side.
+		if cell := width * 9 / 10 / markWidth; cell > 0 {
+			left := offset + logo.x*scale + (width-cell*markWidth)/2
+			top := offset + logo.y*scale + (width-cell*markHeight)/2
+			for row, line := range logoMark {
+				for col := range line {
+					if line[col] == '#' {
+						fill(left+col*cell, top+row*cell, cell, cell)
+					}
+				}
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("encode png: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// renderSVG draws the symbol as an SVG image of size by size pixels. Its coordinates are in
+// modules, so the image scales without losing sharpness.
+func renderSVG(s *symbol, size int, logo plate) []byte {
+	total := s.size + 2*quietZone
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`, size, size, total, total)
+	b.WriteString(`<rect width="100%" height="100%" fill="#fff"/><path fill="#000" d="`)
+	for y := 0; y < s.size; y++ {
+		for x := 0; x < s.size; {
+			if !visible(s, x, y, logo) {
+				x++
+				continue
+			}
+			run := 1
+			for x+run < s.size && visible(s, x+run, y, logo) {
+				run++
+			}
+			fmt.Fprintf(&b, "M%d %dh%dv1h-%dz", x+quietZone, y+quietZone, run, run)
+			x += run
+		}
+	}
+	b.WriteString(`"/>`)
+
+	if logo.width > 0 {
+		markWidth, markHeight := len(logoMark[0]), len(logoMark)
+		cell := float64(logo.width) * 0.9 / float64(markWidth)
+		left := float64(logo.x+quietZone) + (float64(logo.width)-cell*float64(markWidth))/2
+		top := float64(logo.y+quietZone) + (float64(logo.width)-cell*float64(markHeight))/2
+		b.WriteString(`<path fill="#000" d="`)
+		for row, line := range logoMark {
+			for col := range line {
+				if line[col] == '#' {
+					fmt.Fprintf(&b, "M%s %sh%sv%sh-%sz",
+						formatFloat(left+float64(col)*cell), formatFloat(top+float64(row)*cell),
+						formatFloat(cell), formatFloat(cell), formatFloat(cell))
+				}
+			}
+		}
+		b.WriteString(`"/>`)
+	}
+
+	b.WriteString(`</svg>`)
+	return []byte(b.String())
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', 3, 64)
+}
@@ -0,0 +1,110 @@
+package qrcode
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+
+	"github.com/sky0621/techcv/manager/backend/internal/domain"
+)
+
+var levels = map[domain.QRCodeLevel]eccLevel{
+	domain.QRCodeLevelLow:      levelL,
+	domain.QRCodeLevelMedium:   levelM,
+	domain.QRCodeLevelQuartile: levelQ,
+	domain.QRCodeLevelHigh:     levelH,
+}
+
+type cacheKey struct {
+	content string
+	opts    domain.QRCodeOptions
+}
+
+type cacheEntry struct {
+	key   cacheKey
+	image []byte
+}
+
+// Renderer renders QR codes and keeps the most recently used images in memory, so that a
+// link's code is only encoded again once it changes or falls out of the cache.
+type Renderer struct {
+	mu       sync.Mutex
+	capacity int
+	entries  map[cacheKey]*list.Element
+	order    *list.List
+}
+
+// NewRenderer constructs a renderer caching up to capacity images.
+func NewRenderer(capacity int) *Renderer {
+	return &Renderer{
+		capacity: capacity,
+		entries:  make(map[cacheKey]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// Render returns the image of a QR code holding content. The returned bytes are shared
+// with the cache and must not be modified.
+func (r *Renderer) Render(content string, opts domain.QRCodeOptions) ([]byte, error) {
+	key := cacheKey{content: content, opts: opts}
+	if image, ok := r.cached(key); ok {
+		return image, nil
+	}
+
+	level, ok := levels[opts.Level]
+	if !ok {
+		return nil, fmt.Errorf("qrcode: unknown error correction level %q", opts.Level)
+	}
+	s, err := encode([]byte(content), level)
+	if err != nil {
+		return nil, err
+	}
+
+	var logo plate
+	if opts.Logo {
+		logo = logoPlate(s)
+	}
+
+	var image []byte
+	switch opts.Format {
+	case domain.QRCodeFormatPNG:
+		if image, err = renderPNG(s, opts.Size, logo); err != nil {
+			return nil, err
+		}
+	case domain.QRCodeFormatSVG:
+		image = renderSVG(s, opts.Size, logo)
+	default:
+		return nil, fmt.Errorf("qrcode: unknown format %q", opts.Format)
+	}
+
+	r.store(key, image)
+	return image, nil
+}
+
+func (r *Renderer) cached(key cacheKey) ([]byte, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	elem, ok := r.entries[key]
+	if !ok {
+		return nil, false
+	}
+	r.order.MoveToFront(elem)
+	return elem.Value.(*cacheEntry).image, true
+}
+
+func (r *Renderer) store(key cacheKey, image []byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if elem, ok := r.entries[key]; ok {
+		r.order.MoveToFront(elem)
+		return
+	}
+	r.entries[key] = r.order.PushFront(&cacheEntry{key: key, image: image})
+	for r.order.Len() > r.capacity {
+		oldest := r.order.Back()
+		r.order.Remove(oldest)
+		delete(r.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
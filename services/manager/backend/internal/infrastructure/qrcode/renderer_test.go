@@ -0,0 +1,174 @@
+package qrcode
+
+import (
+	"bytes"
+	"errors"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/sky0621/techcv/manager/backend/internal/domain"
+)
+
+const testContent = "https://techcv.example.com/cv/3f9a1c"
+
+func TestRendererPNG(t *testing.T) {
+	renderer := NewRenderer(8)
+
+	image, err := renderer.Render(testContent, domain.QRCodeOptions{Format: domain.QRCodeFormatPNG, Size: 300, Level: domain.QRCodeLevelMedium})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	img, err := png.Decode(bytes.NewReader(image))
+	if err != nil {
+		t.Fatalf("expected a PNG image: %v", err)
+	}
+	if b := img.Bounds(); b.Dx() != 300 || b.Dy() != 300 {
+		t.Fatalf("expected 300x300, got %dx%d", b.Dx(), b.Dy())
+	}
+
+	// A version 3 code is 29 modules plus the quiet zone, 37 in all, so modules are 8
+	// pixels wide and the image keeps a 2 pixel margin besides the quiet zone.
+	if !isBlack(img.At(2+4*8, 2+4*8)) {
+		t.Fatalf("expected the top left finder pattern to be dark")
+	}
+	if isBlack(img.At(2+4*8-1, 2+4*8)) || isBlack(img.At(0, 0)) {
+		t.Fatalf("expected the quiet zone to be light")
+	}
+}
+
+func TestRendererSVG(t *testing.T) {
+	renderer := NewRenderer(8)
+
+	image, err := renderer.Render(testContent, domain.QRCodeOptions{Format: domain.QRCodeFormatSVG, Size: 256, Level: domain.QRCodeLevelHigh, Logo: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	svg := string(image)
+	if !strings.HasPrefix(svg, `<svg xmlns="http://www.w3.org/2000/svg" width="256" height="256" viewBox="0 0 45 45"`) {
+		t.Fatalf("unexpected SVG header: %.120s", svg)
+	}
+	if !strings.Contains(svg, "M4 4h7v1h-7z") {
+		t.Fatalf("expected the top edge of the finder pattern")
+	}
+	if strings.Count(svg, "<path") != 2 {
+		t.Fatalf("expected a path for the modules and one for the logo")
+	}
+}
+
+func TestRendererLogoCanBeReadBack(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		level   domain.QRCodeLevel
+	}{
+		{name: "short link at H", content: testContent, level: domain.QRCodeLevelHigh},
+		{name: "key link at H with a center alignment pattern", content: "https://techcv.example.com/cv/0123456789abcdef0123456789abcdef", level: domain.QRCodeLevelHigh},
+		{name: "key link at Q", content: "https://techcv.example.com/cv/0123456789abcdef0123456789abcdef", level: domain.QRCodeLevelQuartile},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			image, err := NewRenderer(8).Render(tt.content, domain.QRCodeOptions{Format: domain.QRCodeFormatPNG, Size: 512, Level: tt.level, Logo: true})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			img, err := png.Decode(bytes.NewReader(image))
+			if err != nil {
+				t.Fatalf("expected a PNG image: %v", err)
+			}
+
+			want, err := encode([]byte(tt.content), levels[tt.level])
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if logoPlate(want).width < minLogoPlate {
+				t.Fatalf("expected room for the logo")
+			}
+
+			// Sample the center of every module, the way a scanner would.
+			total := want.size + 2*quietZone
+			scale := 512 / total
+			offset := (512-scale*total)/2 + quietZone*scale
+			got := &symbol{
+				version:  want.version,
+				level:    want.level,
+				size:     want.size,
+				modules:  make([]bool, len(want.modules)),
+				function: want.function,
+			}
+			for y := 0; y < got.size; y++ {
+				for x := 0; x < got.size; x++ {
+					got.modules[y*got.size+x] = isBlack(img.At(offset+x*scale+scale/2, offset+y*scale+scale/2))
+					if got.function[y*got.size+x] && got.dark(x, y) != want.dark(x, y) {
+						t.Fatalf("expected function module %d,%d to be intact", x, y)
+					}
+				}
+			}
+
+			level, mask := readFormat(t, got)
+			if level != want.level {
+				t.Fatalf("expected level %d in format information, got %d", want.level, level)
+			}
+			got.applyMask(mask)
+			want.applyMask(mask)
+
+			// Every block must stay within what its error correction can repair.
+			blocks := codewordBlocks(want.version, want.level)
+			errors := make(map[int]int)
+			gotCodewords, wantCodewords := readCodewords(got), readCodewords(want)
+			for i := range wantCodewords {
+				if gotCodewords[i] != wantCodewords[i] {
+					errors[blocks[i]]++
+				}
+			}
+			if len(errors) == 0 {
+				t.Fatalf("expected the logo to hide some codewords")
+			}
+			for block, count := range errors {
+				if limit := eccCodewordsPerBlock[want.level][want.version] / 2; count > limit {
+					t.Fatalf("block %d has %d wrong codewords, more than the %d it can correct", block, count, limit)
+				}
+			}
+		})
+	}
+}
+
+func TestRendererCache(t *testing.T) {
+	renderer := NewRenderer(1)
+	opts := domain.QRCodeOptions{Format: domain.QRCodeFormatSVG, Size: 256, Level: domain.QRCodeLevelMedium}
+
+	first, err := renderer.Render(testContent, opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	again, _ := renderer.Render(testContent, opts)
+	if &first[0] != &again[0] {
+		t.Fatalf("expected the cached image to be returned")
+	}
+
+	if _, err := renderer.Render(testContent+"x", opts); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	evicted, _ := renderer.Render(testContent, opts)
+	if &first[0] == &evicted[0] {
+		t.Fatalf("expected the oldest image to be evicted")
+	}
+}
+
+func TestRendererErrors(t *testing.T) {
+	renderer := NewRenderer(8)
+
+	if _, err := renderer.Render(testContent, domain.QRCodeOptions{Format: domain.QRCodeFormatPNG, Size: 20, Level: domain.QRCodeLevelLow}); !errors.Is(err, errSizeTooSmall) {
+		t.Fatalf("expected errSizeTooSmall, got %v", err)
+	}
+	if _, err := renderer.Render(testContent, domain.QRCodeOptions{Format: domain.QRCodeFormatPNG, Size: 256, Level: "X"}); err == nil {
+		t.Fatalf("expected an error for an unknown level")
+	}
+}
+
+func isBlack(c color.Color) bool {
+	r, g, b, _ := c.RGBA()
+	return r == 0 && g == 0 && b == 0
+}
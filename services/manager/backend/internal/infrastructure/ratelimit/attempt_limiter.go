@@ -0,0 +1,48 @@
+// Package ratelimit provides rate limiters whose counters are kept in a shared store.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Clock abstracts the source of current time for window bookkeeping.
+type Clock interface {
+	Now() time.Time
+}
+
+// Store counts attempts per key in fixed windows. Instances sharing a store enforce one
+// limit between them.
+type Store interface {
+	// Hit records an attempt for key at now and returns the number of attempts in the key's
+	// current window. A window lasts period from the attempt that opened it.
+	Hit(ctx context.Context, key string, now time.Time, period time.Duration) (int, error)
+}
+
+// AttemptLimiter allows up to a fixed number of attempts per key within each window.
+type AttemptLimiter struct {
+	store  Store
+	clock  Clock
+	limit  int
+	period time.Duration
+}
+
+// NewAttemptLimiter constructs a limiter allowing limit attempts per key every period.
+func NewAttemptLimiter(store Store, clock Clock, limit int, period time.Duration) *AttemptLimiter {
+	return &AttemptLimiter{
+		store:  store,
+		clock:  clock,
+		limit:  limit,
+		period: period,
+	}
+}
+
+// Allow records an attempt for key and reports whether it is within the limit.
+func (l *AttemptLimiter) Allow(ctx context.Context, key string) (bool, error) {
+	attempts, err := l.store.Hit(ctx, key, l.clock.Now(), l.period)
+	if err != nil {
+		return false, fmt.Errorf("record attempt: %w", err)
+	}
+	return attempts <= l.limit, nil
+}
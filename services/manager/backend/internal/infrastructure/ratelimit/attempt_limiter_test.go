@@ -0,0 +1,57 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+type stubClock struct {
+	now time.Time
+}
+
+func (c *stubClock) Now() time.Time {
+	return c.now
+}
+
+type windowState struct {
+	endsAt   time.Time
+	attempts int
+}
+
+type stubStore struct {
+	windows map[string]windowState
+}
+
+func (s *stubStore) Hit(_ context.Context, key string, now time.Time, period time.Duration) (int, error) {
+	w, ok := s.windows[key]
+	if !ok || !now.Before(w.endsAt) {
+		w = windowState{endsAt: now.Add(period)}
+	}
+	w.attempts++
+	s.windows[key] = w
+	return w.attempts, nil
+}
+
+func TestAttemptLimiter(t *testing.T) {
+	clock := &stubClock{now: time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)}
+	limiter := NewAttemptLimiter(&stubStore{windows: map[string]windowState{}}, clock, 3, time.Minute)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		if ok, err := limiter.Allow(ctx, "a"); err != nil || !ok {
+			t.Fatalf("attempt %d: expected to be allowed, got %v (%v)", i+1, ok, err)
+		}
+	}
+	if ok, _ := limiter.Allow(ctx, "a"); ok {
+		t.Fatalf("expected attempt over the limit to be rejected")
+	}
+	if ok, _ := limiter.Allow(ctx, "b"); !ok {
+		t.Fatalf("expected other keys to be counted separately")
+	}
+
+	clock.now = clock.now.Add(time.Minute)
+	if ok, _ := limiter.Allow(ctx, "a"); !ok {
+		t.Fatalf("expected a new window to allow attempts again")
+	}
+}
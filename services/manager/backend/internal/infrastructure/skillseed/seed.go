@@ -0,0 +1,70 @@
+// Package skillseed provides the initial skill catalog shipped with the service.
+package skillseed
+
+import (
+	_ "embed"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/sky0621/techcv/manager/backend/internal/domain/skill"
+)
+
+//go:embed skills.yaml
+var seedYAML []byte
+
+type entry struct {
+	Name     string   `yaml:"name"`
+	Category string   `yaml:"category"`
+	Aliases  []string `yaml:"aliases"`
+	Parent   string   `yaml:"parent"`
+}
+
+// Catalog builds the seed catalog. Parents are referenced by canonical name in the
+// dataset and may appear after their children.
+func Catalog() (*skill.Catalog, error) {
+	var entries []entry
+	if err := yaml.Unmarshal(seedYAML, &entries); err != nil {
+		return nil, fmt.Errorf("parse skill seed: %w", err)
+	}
+
+	skills := make([]skill.Skill, 0, len(entries))
+	idsByName := make(map[string]string, len(entries))
+	for _, e := range entries {
+		category, err := skill.NewCategory(e.Category)
+		if err != nil {
+			return nil, fmt.Errorf("skill seed %q: %w", e.Name, err)
+		}
+		s, err := skill.NewSkill(e.Name, category, e.Aliases, "")
+		if err != nil {
+			return nil, fmt.Errorf("skill seed %q: %w", e.Name, err)
+		}
+		if _, exists := idsByName[s.Name()]; exists {
+			return nil, fmt.Errorf("skill seed %q: duplicate name", e.Name)
+		}
+		idsByName[s.Name()] = s.ID()
+		skills = append(skills, s)
+	}
+
+	for i, e := range entries {
+		if e.Parent == "" {
+			continue
+		}
+		parentID, ok := idsByName[e.Parent]
+		if !ok {
+			return nil, fmt.Errorf("skill seed %q: unknown parent %q", e.Name, e.Parent)
+		}
+		s := skills[i]
+		restored, err := skill.RestoreSkill(s.ID(), s.Name(), s.Category(), s.Aliases(), parentID)
+		if err != nil {
+			return nil, fmt.Errorf("skill seed %q: %w", e.Name, err)
+		}
+		skills[i] = restored
+	}
+
+	catalog, err := skill.NewCatalog(skills)
+	if err != nil {
+		return nil, fmt.Errorf("build skill seed catalog: %w", err)
+	}
+	return catalog, nil
+}
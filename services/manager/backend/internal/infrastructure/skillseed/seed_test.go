@@ -0,0 +1,56 @@
+package skillseed_test
+
+import (
+	"testing"
+
+	"github.com/sky0621/techcv/manager/backend/internal/domain/gitstats"
+	"github.com/sky0621/techcv/manager/backend/internal/domain/skill"
+	"github.com/sky0621/techcv/manager/backend/internal/infrastructure/skillseed"
+)
+
+func TestCatalogLoads(t *testing.T) {
+	catalog, err := skillseed.Catalog()
+	if err != nil {
+		t.Fatalf("Catalog() error = %v", err)
+	}
+
+	cases := map[string]string{
+		"golang":     "Go",
+		"Go言語":       "Go",
+		"ReactJS":    "React",
+		"ｎｅｘｔ．ｊｓ":    "Next.js",
+		"postgres":   "PostgreSQL",
+		"k8s":        "Kubernetes",
+		"Typescritp": "TypeScript",
+	}
+	for input, want := range cases {
+		res, ok := catalog.Resolve(input)
+		if !ok {
+			t.Errorf("Resolve(%q) not found", input)
+			continue
+		}
+		if res.Skill.Name() != want {
+			t.Errorf("Resolve(%q) = %q, want %q", input, res.Skill.Name(), want)
+		}
+	}
+
+	next, _ := catalog.Resolve("Next.js")
+	ancestors := catalog.Ancestors(next.Skill.ID())
+	if len(ancestors) != 2 || ancestors[0].Name() != "React" || ancestors[1].Name() != "JavaScript" {
+		t.Fatalf("unexpected ancestors of Next.js: %v", ancestors)
+	}
+}
+
+func TestCatalogCoversGitLanguages(t *testing.T) {
+	catalog, err := skillseed.Catalog()
+	if err != nil {
+		t.Fatalf("Catalog() error = %v", err)
+	}
+
+	for _, name := range gitstats.Languages() {
+		res, ok := catalog.Resolve(name)
+		if !ok || res.Match == skill.MatchFuzzy {
+			t.Errorf("git language %q has no exact catalog entry", name)
+		}
+	}
+}
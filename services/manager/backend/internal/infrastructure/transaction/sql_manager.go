@@ -0,0 +1,61 @@
+package transaction
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+type txContextKey struct{}
+
+// SQLManager runs callbacks inside a database/sql transaction. Repositories pick the
+// transaction up from the context via TxFromContext.
+type SQLManager struct {
+	db *sql.DB
+}
+
+// NewSQLManager constructs a new manager.
+func NewSQLManager(db *sql.DB) *SQLManager {
+	return &SQLManager{db: db}
+}
+
+// WithinTransaction commits when the callback succeeds and rolls back otherwise. A call
+// nested in an existing transaction joins it instead of opening a new one.
+func (m *SQLManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	if _, ok := TxFromContext(ctx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+		if err != nil {
+			if rbErr := tx.Rollback(); rbErr != nil && !errors.Is(rbErr, sql.ErrTxDone) {
+				err = errors.Join(err, fmt.Errorf("rollback transaction: %w", rbErr))
+			}
+		}
+	}()
+
+	if err = fn(context.WithValue(ctx, txContextKey{}, tx)); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+// TxFromContext returns the transaction opened by SQLManager, if any.
+func TxFromContext(ctx context.Context) (*sql.Tx, bool) {
+	tx, ok := ctx.Value(txContextKey{}).(*sql.Tx)
+	return tx, ok
+}
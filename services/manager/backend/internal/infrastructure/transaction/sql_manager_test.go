@@ -0,0 +1,75 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestSQLManagerCommits(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer func() {
+		mock.ExpectClose()
+		if closeErr := db.Close(); closeErr != nil {
+			t.Fatalf("failed to close db: %v", closeErr)
+		}
+	}()
+
+	mock.ExpectBegin()
+	mock.ExpectCommit()
+
+	manager := NewSQLManager(db)
+	err = manager.WithinTransaction(context.Background(), func(ctx context.Context) error {
+		outer, ok := TxFromContext(ctx)
+		if !ok {
+			t.Fatalf("expected transaction in context")
+		}
+		// A nested call joins the outer transaction instead of beginning another one.
+		return manager.WithinTransaction(ctx, func(ctx context.Context) error {
+			if inner, _ := TxFromContext(ctx); inner != outer {
+				t.Fatalf("expected nested call to reuse the transaction")
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestSQLManagerRollsBackOnError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer func() {
+		mock.ExpectClose()
+		if closeErr := db.Close(); closeErr != nil {
+			t.Fatalf("failed to close db: %v", closeErr)
+		}
+	}()
+
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	callbackErr := errors.New("callback failed")
+	err = NewSQLManager(db).WithinTransaction(context.Background(), func(ctx context.Context) error {
+		return callbackErr
+	})
+	if !errors.Is(err, callbackErr) {
+		t.Fatalf("expected callback error, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
@@ -3,14 +3,35 @@ package handler
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/labstack/echo/v4"
 
 	"github.com/sky0621/techcv/manager/backend/internal/domain"
+	"github.com/sky0621/techcv/manager/backend/internal/domain/gitstats"
+	"github.com/sky0621/techcv/manager/backend/internal/domain/skill"
+	httpmiddleware "github.com/sky0621/techcv/manager/backend/internal/interface/http/middleware"
 	openapi "github.com/sky0621/techcv/manager/backend/internal/interface/http/openapi"
 	"github.com/sky0621/techcv/manager/backend/internal/interface/http/response"
 	"github.com/sky0621/techcv/manager/backend/internal/usecase/auth"
+	"github.com/sky0621/techcv/manager/backend/internal/usecase/gitimport"
+	"github.com/sky0621/techcv/manager/backend/internal/usecase/publicurl"
+	"github.com/sky0621/techcv/manager/backend/internal/usecase/skillcatalog"
+)
+
+const (
+	// maxGitStatsBodyBytes bounds the size of an uploaded numstat dump.
+	maxGitStatsBodyBytes = 10 << 20
+	// viewerCookiePrefix starts the names of the cookies carrying the passes of a viewer who
+	// unlocked protected share links, one per link.
+	viewerCookiePrefix = "techcv_viewer_"
 )
 
 // HealthUsecase defines the behavior required by the handler.
@@ -28,25 +49,75 @@ type VerifyUsecase interface {
 	Execute(ctx context.Context, in auth.VerifyInput) (auth.VerifyOutput, error)
 }
 
+// GitImportUsecase defines the git history analysis contract.
+type GitImportUsecase interface {
+	Import(ctx context.Context, userID string, numstat io.Reader) (gitstats.Report, error)
+	AuthorEmails(ctx context.Context, userID string) (gitimport.AuthorEmails, error)
+	SetAuthorEmails(ctx context.Context, userID string, emails []string) (gitimport.AuthorEmails, error)
+}
+
+// SkillCatalogUsecase defines the skill catalog lookup and administration contract.
+type SkillCatalogUsecase interface {
+	List(ctx context.Context) ([]skill.Skill, error)
+	Suggest(ctx context.Context, in skillcatalog.SuggestInput) ([]skill.Suggestion, error)
+	Merge(ctx context.Context, in skillcatalog.MergeInput) (skill.Skill, error)
+}
+
+// PublicURLUsecase defines the public URL management contract.
+type PublicURLUsecase interface {
+	List(ctx context.Context, userID string) ([]domain.PublicURL, error)
+	Get(ctx context.Context, userID string, id uint64) (*domain.PublicURL, error)
+	Create(ctx context.Context, userID string, in publicurl.CreateInput) (*domain.PublicURL, error)
+	Update(ctx context.Context, userID string, id uint64, in publicurl.UpdateInput) (*domain.PublicURL, error)
+	SetPassphrase(ctx context.Context, userID string, id uint64, passphrase string) (*domain.PublicURL, error)
+	SetSlug(ctx context.Context, userID string, id uint64, slug string) (*domain.PublicURL, error)
+	Schedule(ctx context.Context, userID string, id uint64, in publicurl.ScheduleInput) (*domain.PublicURL, error)
+	Activate(ctx context.Context, userID string, id uint64) (*domain.PublicURL, error)
+	Deactivate(ctx context.Context, userID string, id uint64) (*domain.PublicURL, error)
+	Views(ctx context.Context, userID string, id uint64, days int) (*publicurl.ViewStats, error)
+	QRCode(ctx context.Context, userID string, id uint64, opts domain.QRCodeOptions) (*publicurl.QRCodeImage, error)
+	Events(ctx context.Context, userID string, id uint64) (*domain.PublicURL, []domain.PublicURLEvent, error)
+	EventsByAddress(ctx context.Context, address string) (*domain.PublicURL, []domain.PublicURLEvent, error)
+	TakeDown(ctx context.Context, address, note string) (*domain.PublicURL, error)
+	LiftTakeDown(ctx context.Context, address string) (*domain.PublicURL, error)
+	View(ctx context.Context, address string, viewerTokens []string, visit publicurl.Visit) (*domain.PublicURL, error)
+	Unlock(ctx context.Context, address, passphrase, clientIP string) (publicurl.ViewerPass, error)
+	ShareURL(address string) string
+}
+
 // Handler implements the OpenAPI server interface.
 type Handler struct {
-	health   HealthUsecase
-	register RegisterUsecase
-	verify   VerifyUsecase
+	health     HealthUsecase
+	register   RegisterUsecase
+	verify     VerifyUsecase
+	gitImport  GitImportUsecase
+	skills     SkillCatalogUsecase
+	publicURLs PublicURLUsecase
 }
 
 // NewHandler creates a new API handler instance.
-func NewHandler(health HealthUsecase, register RegisterUsecase, verify VerifyUsecase) *Handler {
+func NewHandler(
+	health HealthUsecase,
+	register RegisterUsecase,
+	verify VerifyUsecase,
+	gitImport GitImportUsecase,
+	skills SkillCatalogUsecase,
+	publicURLs PublicURLUsecase,
+) *Handler {
 	return &Handler{
-		health:   health,
-		register: register,
-		verify:   verify,
+		health:     health,
+		register:   register,
+		verify:     verify,
+		gitImport:  gitImport,
+		skills:     skills,
+		publicURLs: publicURLs,
 	}
 }
 
-// Register wires the OpenAPI handlers on the provided Echo group.
-func (h *Handler) Register(router *echo.Group) {
-	openapi.RegisterHandlers(router, h)
+// Register wires the OpenAPI handlers on the provided Echo group, putting the admin
+// endpoints behind adminAuth.
+func (h *Handler) Register(router *echo.Group, adminAuth echo.MiddlewareFunc) {
+	openapi.RegisterHandlers(router, h, openapi.SecurityMiddlewares{"AdminToken": adminAuth})
 }
 
 // GetHealth implements the OpenAPI health endpoint.
@@ -135,3 +206,748 @@ func (h *Handler) PostAuthVerify(c echo.Context) error {
 
 	return response.Success(c, http.StatusOK, payload, meta)
 }
+
+// PostImportsGitStats derives per-technology activity from the signed-in user's uploaded
+// numstat dump.
+func (h *Handler) PostImportsGitStats(c echo.Context) error {
+	userID, err := requireUserID(c)
+	if err != nil {
+		return err
+	}
+
+	c.Request().Body = http.MaxBytesReader(c.Response(), c.Request().Body, maxGitStatsBodyBytes)
+
+	var req openapi.GitStatsRequest
+	if err := c.Bind(&req); err != nil {
+		return invalidJSONError()
+	}
+
+	report, err := h.gitImport.Import(c.Request().Context(), userID, strings.NewReader(req.Numstat))
+	if err != nil {
+		return err
+	}
+
+	technologies := make([]map[string]interface{}, 0, len(report.Technologies))
+	for _, t := range report.Technologies {
+		technologies = append(technologies, map[string]interface{}{
+			"technology":     t.Technology,
+			"first_used_at":  t.FirstUsedAt,
+			"last_used_at":   t.LastUsedAt,
+			"commits":        t.Commits,
+			"lines_added":    t.LinesAdded,
+			"lines_deleted":  t.LinesDeleted,
+			"suggested_name": nullableString(t.SuggestedName),
+		})
+	}
+
+	data := map[string]interface{}{
+		"total_commits":      report.TotalCommits,
+		"attributed_commits": report.AttributedCommits,
+		"technologies":       technologies,
+	}
+
+	meta := map[string]interface{}{
+		"requestId": c.Response().Header().Get(echo.HeaderXRequestID),
+	}
+
+	return response.Success(c, http.StatusOK, data, meta)
+}
+
+// GetImportsGitAuthorEmails returns the emails the signed-in user's commits are attributed to.
+func (h *Handler) GetImportsGitAuthorEmails(c echo.Context) error {
+	userID, err := requireUserID(c)
+	if err != nil {
+		return err
+	}
+
+	emails, err := h.gitImport.AuthorEmails(c.Request().Context(), userID)
+	if err != nil {
+		return err
+	}
+
+	return respondGitAuthorEmails(c, emails)
+}
+
+// PutImportsGitAuthorEmails replaces the additional emails the signed-in user commits with.
+func (h *Handler) PutImportsGitAuthorEmails(c echo.Context) error {
+	userID, err := requireUserID(c)
+	if err != nil {
+		return err
+	}
+
+	var req openapi.GitAuthorEmailsRequest
+	if err := c.Bind(&req); err != nil {
+		return invalidJSONError()
+	}
+
+	emails, err := h.gitImport.SetAuthorEmails(c.Request().Context(), userID, req.AuthorEmails)
+	if err != nil {
+		return err
+	}
+
+	return respondGitAuthorEmails(c, emails)
+}
+
+// GetSkillsSuggest suggests catalog skills for a partially typed name.
+func (h *Handler) GetSkillsSuggest(c echo.Context) error {
+	in := skillcatalog.SuggestInput{Query: c.QueryParam("q")}
+	if raw := c.QueryParam("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit == 0 {
+			detail := domain.ErrorDetail{Field: "limit", Code: domain.ErrorCodeInvalidSuggestLimit, Message: "件数は1以上50以下で指定してください"}
+			return domain.NewValidation(domain.ErrorCodeInvalidSuggestLimit, "件数の指定が正しくありません").WithDetails(detail)
+		}
+		in.Limit = limit
+	}
+
+	suggestions, err := h.skills.Suggest(c.Request().Context(), in)
+	if err != nil {
+		return err
+	}
+
+	items := make([]map[string]interface{}, 0, len(suggestions))
+	for _, s := range suggestions {
+		var matchedAlias interface{}
+		if s.MatchedAlias != "" {
+			matchedAlias = s.MatchedAlias
+		}
+		items = append(items, map[string]interface{}{
+			"id":            s.Skill.ID(),
+			"name":          s.Skill.Name(),
+			"category":      s.Skill.Category(),
+			"matched_alias": matchedAlias,
+			"usage_count":   s.Usage,
+		})
+	}
+
+	data := map[string]interface{}{
+		"suggestions": items,
+	}
+
+	meta := map[string]interface{}{
+		"requestId": c.Response().Header().Get(echo.HeaderXRequestID),
+	}
+
+	return response.Success(c, http.StatusOK, data, meta)
+}
+
+// GetPublicUrls lists the public URLs of the signed-in user.
+func (h *Handler) GetPublicUrls(c echo.Context) error {
+	userID, err := requireUserID(c)
+	if err != nil {
+		return err
+	}
+
+	urls, err := h.publicURLs.List(c.Request().Context(), userID)
+	if err != nil {
+		return err
+	}
+
+	items := make([]map[string]interface{}, 0, len(urls))
+	for _, u := range urls {
+		items = append(items, h.publicURLPayload(u))
+	}
+
+	data := map[string]interface{}{
+		"public_urls": items,
+	}
+
+	meta := map[string]interface{}{
+		"requestId": c.Response().Header().Get(echo.HeaderXRequestID),
+	}
+
+	return response.Success(c, http.StatusOK, data, meta)
+}
+
+// PostPublicUrls creates a labelled share link for the signed-in user.
+func (h *Handler) PostPublicUrls(c echo.Context) error {
+	userID, err := requireUserID(c)
+	if err != nil {
+		return err
+	}
+
+	var req openapi.PublicURLCreateRequest
+	if err := c.Bind(&req); err != nil {
+		return invalidJSONError()
+	}
+
+	in := publicurl.CreateInput{
+		Label:       req.Label,
+		ActivatesAt: req.ActivatesAt,
+		ExpiresAt:   req.ExpiresAt,
+	}
+	if req.CvVariant != nil {
+		in.CVVariant = *req.CvVariant
+	}
+	if req.Passphrase != nil {
+		in.Passphrase = *req.Passphrase
+	}
+	if req.NotifyFirstView != nil {
+		in.NotifyFirstView = *req.NotifyFirstView
+	}
+	if req.ReplaceExisting != nil {
+		in.ReplaceExisting = *req.ReplaceExisting
+	}
+
+	created, err := h.publicURLs.Create(c.Request().Context(), userID, in)
+	if err != nil {
+		return err
+	}
+
+	return h.respondPublicURL(c, *created)
+}
+
+// GetPublicUrlsId returns one of the signed-in user's share links.
+func (h *Handler) GetPublicUrlsId(c echo.Context) error {
+	userID, id, err := publicURLTarget(c)
+	if err != nil {
+		return err
+	}
+
+	found, err := h.publicURLs.Get(c.Request().Context(), userID, id)
+	if err != nil {
+		return err
+	}
+
+	return h.respondPublicURL(c, *found)
+}
+
+// PatchPublicUrlsId changes the label or CV variant of a share link.
+func (h *Handler) PatchPublicUrlsId(c echo.Context) error {
+	userID, id, err := publicURLTarget(c)
+	if err != nil {
+		return err
+	}
+
+	var req openapi.PublicURLUpdateRequest
+	if err := c.Bind(&req); err != nil {
+		return invalidJSONError()
+	}
+
+	updated, err := h.publicURLs.Update(c.Request().Context(), userID, id, publicurl.UpdateInput{
+		Label:           req.Label,
+		CVVariant:       req.CvVariant,
+		NotifyFirstView: req.NotifyFirstView,
+	})
+	if err != nil {
+		return err
+	}
+
+	return h.respondPublicURL(c, *updated)
+}
+
+// PutPublicUrlsIdPassphrase sets, replaces or removes the passphrase of a share link.
+func (h *Handler) PutPublicUrlsIdPassphrase(c echo.Context) error {
+	userID, id, err := publicURLTarget(c)
+	if err != nil {
+		return err
+	}
+
+	var req openapi.PublicURLPassphraseRequest
+	if err := c.Bind(&req); err != nil {
+		return invalidJSONError()
+	}
+
+	var passphrase string
+	if req.Passphrase != nil {
+		passphrase = *req.Passphrase
+	}
+
+	updated, err := h.publicURLs.SetPassphrase(c.Request().Context(), userID, id, passphrase)
+	if err != nil {
+		return err
+	}
+
+	return h.respondPublicURL(c, *updated)
+}
+
+// PutPublicUrlsIdSlug sets, replaces or removes the vanity slug of a share link.
+func (h *Handler) PutPublicUrlsIdSlug(c echo.Context) error {
+	userID, id, err := publicURLTarget(c)
+	if err != nil {
+		return err
+	}
+
+	var req openapi.PublicURLSlugRequest
+	if err := c.Bind(&req); err != nil {
+		return invalidJSONError()
+	}
+
+	var slug string
+	if req.Slug != nil {
+		slug = *req.Slug
+	}
+
+	updated, err := h.publicURLs.SetSlug(c.Request().Context(), userID, id, slug)
+	if err != nil {
+		return err
+	}
+
+	return h.respondPublicURL(c, *updated)
+}
+
+// PutPublicUrlsIdSchedule sets the viewing window of a share link.
+func (h *Handler) PutPublicUrlsIdSchedule(c echo.Context) error {
+	userID, id, err := publicURLTarget(c)
+	if err != nil {
+		return err
+	}
+
+	var req openapi.PublicURLScheduleRequest
+	if err := c.Bind(&req); err != nil {
+		return invalidJSONError()
+	}
+
+	updated, err := h.publicURLs.Schedule(c.Request().Context(), userID, id, publicurl.ScheduleInput{
+		ActivatesAt: req.ActivatesAt,
+		ExpiresAt:   req.ExpiresAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	return h.respondPublicURL(c, *updated)
+}
+
+// GetPublicUrlsIdViews returns the view analytics of a share link.
+func (h *Handler) GetPublicUrlsIdViews(c echo.Context) error {
+	userID, id, err := publicURLTarget(c)
+	if err != nil {
+		return err
+	}
+
+	var days int
+	if raw := c.QueryParam("days"); raw != "" {
+		days, err = strconv.Atoi(raw)
+		if err != nil || days == 0 {
+			return publicurl.InvalidViewDays()
+		}
+	}
+
+	stats, err := h.publicURLs.Views(c.Request().Context(), userID, id, days)
+	if err != nil {
+		return err
+	}
+
+	daily := make([]map[string]interface{}, 0, len(stats.Daily))
+	for _, d := range stats.Daily {
+		daily = append(daily, map[string]interface{}{
+			"date":            d.Day.Format(time.DateOnly),
+			"views":           d.Views,
+			"unique_visitors": d.UniqueVisitors,
+		})
+	}
+	recent := make([]map[string]interface{}, 0, len(stats.Recent))
+	for _, v := range stats.Recent {
+		recent = append(recent, map[string]interface{}{
+			"viewed_at":        v.ViewedAt,
+			"referrer":         nullableString(v.ReferrerHost),
+			"user_agent_class": v.UserAgentClass,
+			"country":          nullableString(v.Country),
+		})
+	}
+
+	data := map[string]interface{}{
+		"daily":  daily,
+		"recent": recent,
+	}
+
+	meta := map[string]interface{}{
+		"requestId": c.Response().Header().Get(echo.HeaderXRequestID),
+	}
+
+	return response.Success(c, http.StatusOK, data, meta)
+}
+
+// GetPublicUrlsIdQrCode renders the share URL of a link as a QR code image.
+func (h *Handler) GetPublicUrlsIdQrCode(c echo.Context) error {
+	userID, id, err := publicURLTarget(c)
+	if err != nil {
+		return err
+	}
+
+	opts := domain.QRCodeOptions{
+		Format: domain.QRCodeFormat(c.QueryParam("format")),
+		Level:  domain.QRCodeLevel(strings.ToUpper(c.QueryParam("error_correction"))),
+	}
+	if raw := c.QueryParam("size"); raw != "" {
+		opts.Size, err = strconv.Atoi(raw)
+		if err != nil || opts.Size == 0 {
+			return publicurl.InvalidQRCodeOption("size", "public_url.qr_code_size", "size must be between 128 and 2048")
+		}
+	}
+	if raw := c.QueryParam("logo"); raw != "" {
+		opts.Logo, err = strconv.ParseBool(raw)
+		if err != nil {
+			return publicurl.InvalidQRCodeOption("logo", "public_url.qr_code_logo", "logo must be true or false")
+		}
+	}
+
+	image, err := h.publicURLs.QRCode(c.Request().Context(), userID, id, opts)
+	if err != nil {
+		return err
+	}
+
+	contentType := "image/png"
+	if image.Format == domain.QRCodeFormatSVG {
+		contentType = "image/svg+xml"
+	}
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`inline; filename="techcv-%d.%s"`, id, image.Format))
+	return c.Blob(http.StatusOK, contentType, image.Data)
+}
+
+// GetPublicUrlsIdEvents returns the activation history of a share link.
+func (h *Handler) GetPublicUrlsIdEvents(c echo.Context) error {
+	userID, id, err := publicURLTarget(c)
+	if err != nil {
+		return err
+	}
+
+	link, events, err := h.publicURLs.Events(c.Request().Context(), userID, id)
+	if err != nil {
+		return err
+	}
+
+	return h.respondPublicURLEvents(c, *link, events)
+}
+
+// PostPublicUrlsIdActivate turns a share link back on.
+func (h *Handler) PostPublicUrlsIdActivate(c echo.Context) error {
+	userID, id, err := publicURLTarget(c)
+	if err != nil {
+		return err
+	}
+
+	updated, err := h.publicURLs.Activate(c.Request().Context(), userID, id)
+	if err != nil {
+		return err
+	}
+
+	return h.respondPublicURL(c, *updated)
+}
+
+// PostPublicUrlsIdDeactivate revokes a single share link.
+func (h *Handler) PostPublicUrlsIdDeactivate(c echo.Context) error {
+	userID, id, err := publicURLTarget(c)
+	if err != nil {
+		return err
+	}
+
+	updated, err := h.publicURLs.Deactivate(c.Request().Context(), userID, id)
+	if err != nil {
+		return err
+	}
+
+	return h.respondPublicURL(c, *updated)
+}
+
+// GetSharedKey returns the CV behind a share link to anyone holding the link.
+func (h *Handler) GetSharedKey(c echo.Context) error {
+	var viewerTokens []string
+	for _, cookie := range c.Cookies() {
+		if strings.HasPrefix(cookie.Name, viewerCookiePrefix) {
+			viewerTokens = append(viewerTokens, cookie.Value)
+		}
+	}
+
+	visit := publicurl.Visit{
+		IP:        c.RealIP(),
+		UserAgent: c.Request().UserAgent(),
+		Referrer:  c.Request().Referer(),
+	}
+	found, err := h.publicURLs.View(c.Request().Context(), c.Param("key"), viewerTokens, visit)
+	var moved *publicurl.MovedError
+	if errors.As(err, &moved) {
+		return c.Redirect(http.StatusTemporaryRedirect, movedLocation(c, moved.Address))
+	}
+	if err != nil {
+		return err
+	}
+
+	data := map[string]interface{}{
+		"shared_cv": map[string]interface{}{
+			"url_key":    found.URLKey,
+			"slug":       nullableString(found.Slug),
+			"cv_variant": nullableString(found.CVVariant),
+			"expires_at": found.ExpiresAt,
+		},
+	}
+
+	meta := map[string]interface{}{
+		"requestId": c.Response().Header().Get(echo.HeaderXRequestID),
+	}
+
+	return response.Success(c, http.StatusOK, data, meta)
+}
+
+// PostSharedKeyUnlock checks the passphrase of a share link and sets the viewer cookie.
+func (h *Handler) PostSharedKeyUnlock(c echo.Context) error {
+	var req openapi.SharedUnlockRequest
+	if err := c.Bind(&req); err != nil {
+		return invalidJSONError()
+	}
+
+	pass, err := h.publicURLs.Unlock(c.Request().Context(), c.Param("key"), req.Passphrase, c.RealIP())
+	var moved *publicurl.MovedError
+	if errors.As(err, &moved) {
+		return c.Redirect(http.StatusTemporaryRedirect, movedLocation(c, moved.Address))
+	}
+	if err != nil {
+		return err
+	}
+
+	// The pass is bound to the link, so the cookie is sent to every share link address: the
+	// link stays unlocked under its key, its slug and any address it is redirected to.
+	c.SetCookie(&http.Cookie{
+		Name:     viewerCookiePrefix + strconv.FormatUint(pass.LinkID, 10),
+		Value:    pass.Token,
+		Path:     sharedPath(c),
+		Expires:  pass.ExpiresAt,
+		HttpOnly: true,
+		Secure:   c.Scheme() == "https",
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	data := map[string]interface{}{
+		"expires_at": pass.ExpiresAt,
+	}
+
+	meta := map[string]interface{}{
+		"requestId": c.Response().Header().Get(echo.HeaderXRequestID),
+	}
+
+	return response.Success(c, http.StatusOK, data, meta)
+}
+
+// GetAdminSkills lists the managed skill catalog.
+func (h *Handler) GetAdminSkills(c echo.Context) error {
+	skills, err := h.skills.List(c.Request().Context())
+	if err != nil {
+		return err
+	}
+
+	items := make([]map[string]interface{}, 0, len(skills))
+	for _, s := range skills {
+		items = append(items, skillPayload(s))
+	}
+
+	data := map[string]interface{}{
+		"skills": items,
+	}
+
+	meta := map[string]interface{}{
+		"requestId": c.Response().Header().Get(echo.HeaderXRequestID),
+	}
+
+	return response.Success(c, http.StatusOK, data, meta)
+}
+
+// PostAdminSkillsMerge folds a duplicate skill into a canonical one.
+func (h *Handler) PostAdminSkillsMerge(c echo.Context) error {
+	var req openapi.SkillMergeRequest
+	if err := c.Bind(&req); err != nil {
+		return invalidJSONError()
+	}
+
+	merged, err := h.skills.Merge(c.Request().Context(), skillcatalog.MergeInput{
+		SourceID: req.SourceId,
+		TargetID: req.TargetId,
+	})
+	if err != nil {
+		return err
+	}
+
+	data := map[string]interface{}{
+		"skill": skillPayload(merged),
+	}
+
+	meta := map[string]interface{}{
+		"requestId": c.Response().Header().Get(echo.HeaderXRequestID),
+	}
+
+	return response.Success(c, http.StatusOK, data, meta)
+}
+
+// GetAdminPublicUrlsKeyEvents returns any user's share link with its activation history.
+func (h *Handler) GetAdminPublicUrlsKeyEvents(c echo.Context) error {
+	link, events, err := h.publicURLs.EventsByAddress(c.Request().Context(), c.Param("key"))
+	if err != nil {
+		return err
+	}
+
+	return h.respondPublicURLEvents(c, *link, events)
+}
+
+// PostAdminPublicUrlsKeyTakedown turns off any user's share link.
+func (h *Handler) PostAdminPublicUrlsKeyTakedown(c echo.Context) error {
+	var req openapi.PublicURLTakedownRequest
+	if err := c.Bind(&req); err != nil {
+		return invalidJSONError()
+	}
+
+	updated, err := h.publicURLs.TakeDown(c.Request().Context(), c.Param("key"), req.Note)
+	if err != nil {
+		return err
+	}
+
+	return h.respondPublicURL(c, *updated)
+}
+
+// DeleteAdminPublicUrlsKeyTakedown lets the owner turn a taken down share link back on.
+func (h *Handler) DeleteAdminPublicUrlsKeyTakedown(c echo.Context) error {
+	updated, err := h.publicURLs.LiftTakeDown(c.Request().Context(), c.Param("key"))
+	if err != nil {
+		return err
+	}
+
+	return h.respondPublicURL(c, *updated)
+}
+
+func (h *Handler) publicURLPayload(u domain.PublicURL) map[string]interface{} {
+	return map[string]interface{}{
+		"id":                u.ID,
+		"url_key":           u.URLKey,
+		"url":               h.publicURLs.ShareURL(u.Address()),
+		"slug":              nullableString(u.Slug),
+		"label":             u.Label,
+		"cv_variant":        nullableString(u.CVVariant),
+		"has_passphrase":    u.Protected(),
+		"is_active":         u.IsActive,
+		"activates_at":      u.ActivatesAt,
+		"expires_at":        u.ExpiresAt,
+		"notify_first_view": u.NotifyFirstView,
+		"first_viewed_at":   u.FirstViewedAt,
+		"created_at":        u.CreatedAt,
+		"updated_at":        u.UpdatedAt,
+	}
+}
+
+func (h *Handler) respondPublicURL(c echo.Context, u domain.PublicURL) error {
+	data := map[string]interface{}{
+		"public_url": h.publicURLPayload(u),
+	}
+
+	meta := map[string]interface{}{
+		"requestId": c.Response().Header().Get(echo.HeaderXRequestID),
+	}
+
+	return response.Success(c, http.StatusOK, data, meta)
+}
+
+func (h *Handler) respondPublicURLEvents(c echo.Context, link domain.PublicURL, events []domain.PublicURLEvent) error {
+	items := make([]map[string]interface{}, 0, len(events))
+	for _, e := range events {
+		items = append(items, map[string]interface{}{
+			"id":            e.ID,
+			"reason":        e.Reason,
+			"active":        e.Active,
+			"actor":         e.Actor,
+			"actor_user_id": nullableString(e.ActorUserID),
+			"note":          nullableString(e.Note),
+			"occurred_at":   e.OccurredAt,
+		})
+	}
+
+	data := map[string]interface{}{
+		"public_url": h.publicURLPayload(link),
+		"events":     items,
+	}
+
+	meta := map[string]interface{}{
+		"requestId": c.Response().Header().Get(echo.HeaderXRequestID),
+	}
+
+	return response.Success(c, http.StatusOK, data, meta)
+}
+
+// publicURLTarget returns the authenticated user and the public URL ID in the path.
+func publicURLTarget(c echo.Context) (string, uint64, error) {
+	userID, err := requireUserID(c)
+	if err != nil {
+		return "", 0, err
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return "", 0, domain.NewValidation(domain.ErrorCodeInvalidPublicURLID, "公開URLのIDが正しくありません").WithDetails(
+			domain.ErrorDetail{Field: "id", Code: domain.ErrorCodeInvalidPublicURLID, Message: "IDは正の整数で指定してください"},
+		)
+	}
+	return userID, id, nil
+}
+
+// movedLocation returns the request path with the share link address replaced by the link's
+// current one. Temporary redirects keep the method, so unlock attempts follow them too.
+func movedLocation(c echo.Context, address string) string {
+	path := c.Request().URL.Path
+	previous := "/shared/" + c.Param("key")
+	if i := strings.LastIndex(path, previous); i >= 0 {
+		path = path[:i] + "/shared/" + url.PathEscape(address) + path[i+len(previous):]
+	}
+	return path
+}
+
+// sharedPath returns the request path up to and including the /shared/ segment that all
+// share link addresses live under.
+func sharedPath(c echo.Context) string {
+	path := c.Request().URL.Path
+	if i := strings.LastIndex(path, "/shared/"); i >= 0 {
+		return path[:i+len("/shared/")]
+	}
+	return path
+}
+
+func nullableString(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+func invalidJSONError() error {
+	return domain.NewValidation(domain.ErrorCodeInvalidRequest, "リクエスト形式が正しくありません").WithDetails(
+		domain.ErrorDetail{Field: "body", Code: domain.ErrorCodeInvalidJSON, Message: "JSONの解析に失敗しました"},
+	)
+}
+
+func respondGitAuthorEmails(c echo.Context, emails gitimport.AuthorEmails) error {
+	data := map[string]interface{}{
+		"git_author_emails": map[string]interface{}{
+			"account_email": emails.Account,
+			"author_emails": emails.Additional,
+		},
+	}
+
+	meta := map[string]interface{}{
+		"requestId": c.Response().Header().Get(echo.HeaderXRequestID),
+	}
+
+	return response.Success(c, http.StatusOK, data, meta)
+}
+
+// requireUserID returns the authenticated user or an unauthenticated error.
+func requireUserID(c echo.Context) (string, error) {
+	userID, ok := httpmiddleware.CurrentUserID(c)
+	if !ok {
+		return "", domain.NewUnauthorized(domain.ErrorCodeUnauthenticated, "ログインが必要です")
+	}
+	return userID, nil
+}
+
+func skillPayload(s skill.Skill) map[string]interface{} {
+	var parentID interface{}
+	if s.ParentID() != "" {
+		parentID = s.ParentID()
+	}
+	aliases := s.Aliases()
+	if aliases == nil {
+		aliases = []string{}
+	}
+	return map[string]interface{}{
+		"id":        s.ID(),
+		"name":      s.Name(),
+		"category":  s.Category(),
+		"aliases":   aliases,
+		"parent_id": parentID,
+	}
+}
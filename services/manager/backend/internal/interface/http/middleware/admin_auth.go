@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/sky0621/techcv/manager/backend/internal/domain"
+)
+
+const bearerPrefix = "Bearer "
+
+// AdminAuth requires the admin API token as a bearer token. It is attached to the admin
+// routes themselves rather than matched by path, so that no spelling of an admin path can
+// bypass it. An empty token disables the admin endpoints entirely.
+func AdminAuth(token string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			header := c.Request().Header.Get(echo.HeaderAuthorization)
+			presented, ok := strings.CutPrefix(header, bearerPrefix)
+			if token == "" || !ok || subtle.ConstantTimeCompare([]byte(presented), []byte(token)) != 1 {
+				return domain.NewUnauthorized(domain.ErrorCodeAdminUnauthorized, "管理者トークンが正しくありません")
+			}
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/sky0621/techcv/manager/backend/internal/domain"
+)
+
+const userIDContextKey = "techcv.userID"
+
+// Authenticator resolves bearer tokens into user identifiers. An error means the token
+// could not be checked, not that it is invalid.
+type Authenticator interface {
+	Authenticate(ctx context.Context, token string) (string, bool, error)
+}
+
+// Authenticate attaches the user identified by the bearer token to the request. Requests
+// without a valid token pass through anonymously; handlers decide whether they need a user.
+// A token that cannot be checked fails the request rather than passing it through.
+func Authenticate(authenticator Authenticator) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			header := c.Request().Header.Get(echo.HeaderAuthorization)
+			if token, ok := strings.CutPrefix(header, bearerPrefix); ok && token != "" {
+				userID, found, err := authenticator.Authenticate(c.Request().Context(), token)
+				if err != nil {
+					return domain.NewInternal(domain.ErrorCodeSessionLookupFailed, "ログイン状態の確認に失敗しました", err)
+				}
+				if found {
+					c.Set(userIDContextKey, userID)
+				}
+			}
+			return next(c)
+		}
+	}
+}
+
+// CurrentUserID returns the authenticated user of the request, if any.
+func CurrentUserID(c echo.Context) (string, bool) {
+	userID, ok := c.Get(userIDContextKey).(string)
+	return userID, ok && userID != ""
+}
@@ -35,6 +35,33 @@ type ErrorDetail struct {
 
 type ErrorResponse interface{}
 
+type GitAuthorEmails struct {
+	AccountEmail string   `json:"account_email"`
+	AuthorEmails []string `json:"author_emails"`
+}
+
+type GitAuthorEmailsRequest struct {
+	AuthorEmails []string `json:"author_emails"`
+}
+
+type GitAuthorEmailsSuccessData struct {
+	GitAuthorEmails interface{} `json:"git_author_emails"`
+}
+
+type GitAuthorEmailsSuccessResponse interface{}
+
+type GitStatsRequest struct {
+	Numstat string `json:"numstat"`
+}
+
+type GitStatsSuccessData struct {
+	AttributedCommits int           `json:"attributed_commits"`
+	Technologies      []interface{} `json:"technologies"`
+	TotalCommits      int           `json:"total_commits"`
+}
+
+type GitStatsSuccessResponse interface{}
+
 type HealthStatus struct {
 	CheckedAt time.Time `json:"checked_at"`
 	Status    string    `json:"status"`
@@ -42,6 +69,105 @@ type HealthStatus struct {
 
 type HealthSuccessResponse interface{}
 
+type PublicURL struct {
+	ActivatesAt     *time.Time `json:"activates_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+	CvVariant       *string    `json:"cv_variant"`
+	ExpiresAt       *time.Time `json:"expires_at"`
+	FirstViewedAt   *time.Time `json:"first_viewed_at"`
+	HasPassphrase   bool       `json:"has_passphrase"`
+	Id              int        `json:"id"`
+	IsActive        bool       `json:"is_active"`
+	Label           string     `json:"label"`
+	NotifyFirstView bool       `json:"notify_first_view"`
+	Slug            *string    `json:"slug"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+	Url             string     `json:"url"`
+	UrlKey          string     `json:"url_key"`
+}
+
+type PublicURLCreateRequest struct {
+	ActivatesAt     *time.Time `json:"activates_at"`
+	CvVariant       *string    `json:"cv_variant"`
+	ExpiresAt       *time.Time `json:"expires_at"`
+	Label           string     `json:"label"`
+	NotifyFirstView *bool      `json:"notify_first_view"`
+	Passphrase      *string    `json:"passphrase"`
+	ReplaceExisting *bool      `json:"replace_existing"`
+}
+
+type PublicURLDailyViews struct {
+	Date           string `json:"date"`
+	UniqueVisitors int    `json:"unique_visitors"`
+	Views          int    `json:"views"`
+}
+
+type PublicURLEvent struct {
+	Active      bool      `json:"active"`
+	Actor       string    `json:"actor"`
+	ActorUserId *string   `json:"actor_user_id"`
+	Id          int       `json:"id"`
+	Note        *string   `json:"note"`
+	OccurredAt  time.Time `json:"occurred_at"`
+	Reason      string    `json:"reason"`
+}
+
+type PublicURLEventsSuccessData struct {
+	Events    []interface{} `json:"events"`
+	PublicUrl interface{}   `json:"public_url"`
+}
+
+type PublicURLEventsSuccessResponse interface{}
+
+type PublicURLListSuccessData struct {
+	PublicUrls []interface{} `json:"public_urls"`
+}
+
+type PublicURLListSuccessResponse interface{}
+
+type PublicURLPassphraseRequest struct {
+	Passphrase *string `json:"passphrase"`
+}
+
+type PublicURLScheduleRequest struct {
+	ActivatesAt *time.Time `json:"activates_at"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+}
+
+type PublicURLSlugRequest struct {
+	Slug *string `json:"slug"`
+}
+
+type PublicURLSuccessData struct {
+	PublicUrl interface{} `json:"public_url"`
+}
+
+type PublicURLSuccessResponse interface{}
+
+type PublicURLTakedownRequest struct {
+	Note string `json:"note"`
+}
+
+type PublicURLUpdateRequest struct {
+	CvVariant       *string `json:"cv_variant"`
+	Label           *string `json:"label"`
+	NotifyFirstView *bool   `json:"notify_first_view"`
+}
+
+type PublicURLView struct {
+	Country        *string   `json:"country"`
+	Referrer       *string   `json:"referrer"`
+	UserAgentClass string    `json:"user_agent_class"`
+	ViewedAt       time.Time `json:"viewed_at"`
+}
+
+type PublicURLViewsSuccessData struct {
+	Daily  []interface{} `json:"daily"`
+	Recent []interface{} `json:"recent"`
+}
+
+type PublicURLViewsSuccessResponse interface{}
+
 type RegisterRequest struct {
 	Email                string `json:"email"`
 	Password             string `json:"password"`
@@ -62,6 +188,78 @@ type ResponseEnvelope struct {
 	Status string       `json:"status"`
 }
 
+type SharedCV struct {
+	CvVariant *string    `json:"cv_variant"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	Slug      *string    `json:"slug"`
+	UrlKey    string     `json:"url_key"`
+}
+
+type SharedCVSuccessData struct {
+	SharedCv interface{} `json:"shared_cv"`
+}
+
+type SharedCVSuccessResponse interface{}
+
+type SharedUnlockRequest struct {
+	Passphrase string `json:"passphrase"`
+}
+
+type SharedUnlockSuccessData struct {
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type SharedUnlockSuccessResponse interface{}
+
+type Skill struct {
+	Aliases  []string `json:"aliases"`
+	Category string   `json:"category"`
+	Id       string   `json:"id"`
+	Name     string   `json:"name"`
+	ParentId *string  `json:"parent_id"`
+}
+
+type SkillListSuccessData struct {
+	Skills []interface{} `json:"skills"`
+}
+
+type SkillListSuccessResponse interface{}
+
+type SkillMergeRequest struct {
+	SourceId string `json:"source_id"`
+	TargetId string `json:"target_id"`
+}
+
+type SkillMergeSuccessData struct {
+	Skill interface{} `json:"skill"`
+}
+
+type SkillMergeSuccessResponse interface{}
+
+type SkillSuggestSuccessData struct {
+	Suggestions []interface{} `json:"suggestions"`
+}
+
+type SkillSuggestSuccessResponse interface{}
+
+type SkillSuggestion struct {
+	Category     string  `json:"category"`
+	Id           string  `json:"id"`
+	MatchedAlias *string `json:"matched_alias"`
+	Name         string  `json:"name"`
+	UsageCount   int     `json:"usage_count"`
+}
+
+type TechnologyActivity struct {
+	Commits       int       `json:"commits"`
+	FirstUsedAt   time.Time `json:"first_used_at"`
+	LastUsedAt    time.Time `json:"last_used_at"`
+	LinesAdded    int       `json:"lines_added"`
+	LinesDeleted  int       `json:"lines_deleted"`
+	SuggestedName *string   `json:"suggested_name"`
+	Technology    string    `json:"technology"`
+}
+
 type VerifyRequest struct {
 	Token string `json:"token"`
 }
@@ -75,20 +273,82 @@ type VerifySuccessData struct {
 type VerifySuccessResponse interface{}
 
 type ServerInterface interface {
+	DeleteAdminPublicUrlsKeyTakedown(ctx echo.Context) error
+	GetAdminPublicUrlsKeyEvents(ctx echo.Context) error
+	GetAdminSkills(ctx echo.Context) error
 	GetHealth(ctx echo.Context) error
+	GetImportsGitAuthorEmails(ctx echo.Context) error
+	GetPublicUrls(ctx echo.Context) error
+	GetPublicUrlsId(ctx echo.Context) error
+	GetPublicUrlsIdEvents(ctx echo.Context) error
+	GetPublicUrlsIdQrCode(ctx echo.Context) error
+	GetPublicUrlsIdViews(ctx echo.Context) error
+	GetSharedKey(ctx echo.Context) error
+	GetSkillsSuggest(ctx echo.Context) error
+	PatchPublicUrlsId(ctx echo.Context) error
+	PostAdminPublicUrlsKeyTakedown(ctx echo.Context) error
+	PostAdminSkillsMerge(ctx echo.Context) error
 	PostAuthRegister(ctx echo.Context) error
 	PostAuthVerify(ctx echo.Context) error
+	PostImportsGitStats(ctx echo.Context) error
+	PostPublicUrls(ctx echo.Context) error
+	PostPublicUrlsIdActivate(ctx echo.Context) error
+	PostPublicUrlsIdDeactivate(ctx echo.Context) error
+	PostSharedKeyUnlock(ctx echo.Context) error
+	PutImportsGitAuthorEmails(ctx echo.Context) error
+	PutPublicUrlsIdPassphrase(ctx echo.Context) error
+	PutPublicUrlsIdSchedule(ctx echo.Context) error
+	PutPublicUrlsIdSlug(ctx echo.Context) error
 }
 
-func RegisterHandlers(g *echo.Group, si ServerInterface) {
+// SecurityMiddlewares maps each security scheme of the spec to the middleware enforcing it.
+type SecurityMiddlewares map[string]echo.MiddlewareFunc
+
+// RegisterHandlers registers the routes on g, each behind the middlewares of its security
+// schemes. It panics when a scheme has no middleware, so that no route is left open.
+func RegisterHandlers(g *echo.Group, si ServerInterface, security SecurityMiddlewares) {
 	if g == nil {
 		panic("nil echo group")
 	}
 	if si == nil {
 		panic("nil server implementation")
 	}
+	secured := func(schemes ...string) []echo.MiddlewareFunc {
+		middlewares := make([]echo.MiddlewareFunc, 0, len(schemes))
+		for _, scheme := range schemes {
+			middleware, ok := security[scheme]
+			if !ok || middleware == nil {
+				panic("no middleware for security scheme " + scheme)
+			}
+			middlewares = append(middlewares, middleware)
+		}
+		return middlewares
+	}
 
+	g.DELETE("/admin/public-urls/:key/takedown", si.DeleteAdminPublicUrlsKeyTakedown, secured("AdminToken")...)
+	g.GET("/admin/public-urls/:key/events", si.GetAdminPublicUrlsKeyEvents, secured("AdminToken")...)
+	g.GET("/admin/skills", si.GetAdminSkills, secured("AdminToken")...)
 	g.GET("/health", si.GetHealth)
+	g.GET("/imports/git-author-emails", si.GetImportsGitAuthorEmails)
+	g.GET("/public-urls", si.GetPublicUrls)
+	g.GET("/public-urls/:id", si.GetPublicUrlsId)
+	g.GET("/public-urls/:id/events", si.GetPublicUrlsIdEvents)
+	g.GET("/public-urls/:id/qr-code", si.GetPublicUrlsIdQrCode)
+	g.GET("/public-urls/:id/views", si.GetPublicUrlsIdViews)
+	g.GET("/shared/:key", si.GetSharedKey)
+	g.GET("/skills/suggest", si.GetSkillsSuggest)
+	g.PATCH("/public-urls/:id", si.PatchPublicUrlsId)
+	g.POST("/admin/public-urls/:key/takedown", si.PostAdminPublicUrlsKeyTakedown, secured("AdminToken")...)
+	g.POST("/admin/skills/merge", si.PostAdminSkillsMerge, secured("AdminToken")...)
 	g.POST("/auth/register", si.PostAuthRegister)
 	g.POST("/auth/verify", si.PostAuthVerify)
+	g.POST("/imports/git-stats", si.PostImportsGitStats)
+	g.POST("/public-urls", si.PostPublicUrls)
+	g.POST("/public-urls/:id/activate", si.PostPublicUrlsIdActivate)
+	g.POST("/public-urls/:id/deactivate", si.PostPublicUrlsIdDeactivate)
+	g.POST("/shared/:key/unlock", si.PostSharedKeyUnlock)
+	g.PUT("/imports/git-author-emails", si.PutImportsGitAuthorEmails)
+	g.PUT("/public-urls/:id/passphrase", si.PutPublicUrlsIdPassphrase)
+	g.PUT("/public-urls/:id/schedule", si.PutPublicUrlsIdSchedule)
+	g.PUT("/public-urls/:id/slug", si.PutPublicUrlsIdSlug)
 }
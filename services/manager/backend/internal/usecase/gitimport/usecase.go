@@ -0,0 +1,243 @@
+// Package gitimport provides use cases for importing skill evidence from git history.
+package gitimport
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/sky0621/techcv/manager/backend/internal/domain"
+	"github.com/sky0621/techcv/manager/backend/internal/domain/gitstats"
+	"github.com/sky0621/techcv/manager/backend/internal/domain/skill"
+	"github.com/sky0621/techcv/manager/backend/internal/domain/user"
+)
+
+// maxAuthorEmails bounds how many emails besides the account email a user can configure.
+const maxAuthorEmails = 20
+
+// Clock abstracts the source of current time for easier testing.
+type Clock interface {
+	Now() time.Time
+}
+
+// TransactionManager executes operations within a transaction boundary.
+type TransactionManager interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// AnalyzeInput carries a `git log --numstat` dump and the emails the user commits with.
+type AnalyzeInput struct {
+	Numstat      io.Reader
+	AuthorEmails []string
+}
+
+// AuthorEmails are the emails a user's commits are attributed to: the account email and
+// the additional emails the user configured.
+type AuthorEmails struct {
+	Account    string
+	Additional []string
+}
+
+// All returns the account email followed by the additional emails.
+func (a AuthorEmails) All() []string {
+	return append([]string{a.Account}, a.Additional...)
+}
+
+// Usecase derives per-technology activity from git history.
+type Usecase struct {
+	catalogs skill.CatalogRepository
+	usage    skill.UsageRepository
+	authors  user.GitAuthorEmailRepository
+	tx       TransactionManager
+	clock    Clock
+}
+
+// New constructs a new Usecase instance. The importer CLI only analyzes dumps and may pass
+// nil for usage, authors, tx and clock.
+func New(catalogs skill.CatalogRepository, usage skill.UsageRepository, authors user.GitAuthorEmailRepository, tx TransactionManager, clock Clock) *Usecase {
+	return &Usecase{catalogs: catalogs, usage: usage, authors: authors, tx: tx, clock: clock}
+}
+
+// Import analyzes the numstat dump of the signed-in user, attributing commits to the
+// account email and the additional author emails the user configured.
+func (u *Usecase) Import(ctx context.Context, userID string, numstat io.Reader) (gitstats.Report, error) {
+	emails, err := u.AuthorEmails(ctx, userID)
+	if err != nil {
+		return gitstats.Report{}, err
+	}
+
+	report, used, err := u.analyze(ctx, AnalyzeInput{Numstat: numstat, AuthorEmails: emails.All()})
+	if err != nil {
+		return gitstats.Report{}, err
+	}
+	if len(used) > 0 {
+		if err := u.usage.Record(ctx, userID, used, u.clock.Now()); err != nil {
+			return gitstats.Report{}, domain.NewInternal(domain.ErrorCodeSkillUsageUpdateFailed, "スキルの利用状況の更新に失敗しました", err)
+		}
+	}
+	return report, nil
+}
+
+// AuthorEmails returns the emails the user's commits are attributed to.
+func (u *Usecase) AuthorEmails(ctx context.Context, userID string) (AuthorEmails, error) {
+	account, err := u.authors.GetEmail(ctx, userID)
+	if err != nil {
+		if domain.IsAppError(err) {
+			return AuthorEmails{}, err
+		}
+		return AuthorEmails{}, domain.NewInternal(domain.ErrorCodeUserLookupFailed, "ユーザー情報の取得に失敗しました", err)
+	}
+
+	additional, err := u.authors.GitAuthorEmails(ctx, userID)
+	if err != nil {
+		return AuthorEmails{}, domain.NewInternal(domain.ErrorCodeAuthorEmailFetchFailed, "作成者メールアドレスの取得に失敗しました", err)
+	}
+
+	emails := AuthorEmails{Account: account.String(), Additional: make([]string, 0, len(additional))}
+	for _, e := range additional {
+		emails.Additional = append(emails.Additional, e.String())
+	}
+	return emails, nil
+}
+
+// SetAuthorEmails replaces the additional emails the user commits with. The account email
+// always counts and is dropped from the list, as are duplicates.
+func (u *Usecase) SetAuthorEmails(ctx context.Context, userID string, raw []string) (AuthorEmails, error) {
+	if len(raw) > maxAuthorEmails {
+		detail := domain.ErrorDetail{Field: "author_emails", Code: domain.ErrorCodeTooManyAuthorEmails, Message: "作成者メールアドレスは20件以内で指定してください"}
+		return AuthorEmails{}, domain.NewValidation(domain.ErrorCodeTooManyAuthorEmails, "作成者メールアドレスが多すぎます").WithDetails(detail)
+	}
+
+	emails, err := parseAuthorEmails(raw)
+	if err != nil {
+		return AuthorEmails{}, err
+	}
+
+	var result AuthorEmails
+	err = u.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		account, err := u.authors.GetEmail(ctx, userID)
+		if err != nil {
+			if domain.IsAppError(err) {
+				return err
+			}
+			return domain.NewInternal(domain.ErrorCodeUserLookupFailed, "ユーザー情報の取得に失敗しました", err)
+		}
+
+		seen := map[string]struct{}{account.String(): {}}
+		additional := make([]user.Email, 0, len(emails))
+		for _, e := range emails {
+			if _, dup := seen[e.String()]; dup {
+				continue
+			}
+			seen[e.String()] = struct{}{}
+			additional = append(additional, e)
+		}
+
+		if err := u.authors.ReplaceGitAuthorEmails(ctx, userID, additional, u.clock.Now()); err != nil {
+			return domain.NewInternal(domain.ErrorCodeAuthorEmailSaveFailed, "作成者メールアドレスの保存に失敗しました", err)
+		}
+
+		result = AuthorEmails{Account: account.String(), Additional: make([]string, 0, len(additional))}
+		for _, e := range additional {
+			result.Additional = append(result.Additional, e.String())
+		}
+		return nil
+	})
+	if err != nil {
+		return AuthorEmails{}, err
+	}
+	return result, nil
+}
+
+// Analyze parses the numstat dump and attributes its commits to the given author emails.
+// Technologies are reported under their canonical names in the skill catalog. Unlike
+// Import, it does not count towards suggestion ranking.
+//
+// A technology that only resembles a catalog entry keeps its inferred name and carries the
+// entry's name as a suggestion; fuzzy matches are never applied automatically.
+func (u *Usecase) Analyze(ctx context.Context, in AnalyzeInput) (gitstats.Report, error) {
+	report, _, err := u.analyze(ctx, in)
+	return report, err
+}
+
+// analyze builds the report and returns the identifiers of the catalog skills found in it.
+func (u *Usecase) analyze(ctx context.Context, in AnalyzeInput) (gitstats.Report, []string, error) {
+	authors, err := normalizeAuthorEmails(in.AuthorEmails)
+	if err != nil {
+		return gitstats.Report{}, nil, err
+	}
+
+	commits, err := gitstats.ParseNumstat(in.Numstat)
+	if err != nil {
+		return gitstats.Report{}, nil, err
+	}
+
+	catalog, err := u.catalogs.Load(ctx)
+	if err != nil {
+		return gitstats.Report{}, nil, domain.NewInternal(domain.ErrorCodeSkillCatalogLoadFailed, "スキルカタログの取得に失敗しました", err)
+	}
+
+	report := gitstats.Analyze(commits, authors, canonicalizer(catalog))
+
+	var used []string
+	for i, t := range report.Technologies {
+		res, ok := catalog.Resolve(t.Technology)
+		if !ok {
+			continue
+		}
+		switch res.Match {
+		case skill.MatchCanonical:
+			used = append(used, res.Skill.ID())
+		case skill.MatchFuzzy:
+			report.Technologies[i].SuggestedName = res.Skill.Name()
+		}
+	}
+	return report, used, nil
+}
+
+// canonicalizer maps inferred technology names onto catalog entries. Only exact and alias
+// matches are used; names unknown to the catalog are reported as inferred.
+func canonicalizer(catalog *skill.Catalog) gitstats.Canonicalizer {
+	return func(technology string) string {
+		res, ok := catalog.Resolve(technology)
+		if !ok || res.Match == skill.MatchFuzzy {
+			return technology
+		}
+		return res.Skill.Name()
+	}
+}
+
+func normalizeAuthorEmails(raw []string) ([]string, error) {
+	if len(raw) == 0 {
+		detail := domain.ErrorDetail{Field: "author_emails", Code: domain.ErrorCodeAuthorEmailRequired, Message: "コミットの作成者メールアドレスを指定してください"}
+		return nil, domain.NewValidation(domain.ErrorCodeAuthorEmailRequired, "コミットの作成者メールアドレスを指定してください").WithDetails(detail)
+	}
+
+	parsed, err := parseAuthorEmails(raw)
+	if err != nil {
+		return nil, err
+	}
+	emails := make([]string, 0, len(parsed))
+	for _, email := range parsed {
+		emails = append(emails, email.String())
+	}
+	return emails, nil
+}
+
+func parseAuthorEmails(raw []string) ([]user.Email, error) {
+	emails := make([]user.Email, 0, len(raw))
+	for i, value := range raw {
+		email, err := user.NewEmail(value)
+		if err != nil {
+			detail := domain.ErrorDetail{
+				Field:   fmt.Sprintf("author_emails[%d]", i),
+				Code:    domain.ErrorCodeInvalidEmailFormat,
+				Message: "メールアドレスの形式が正しくありません",
+			}
+			return nil, domain.NewValidation(domain.ErrorCodeInvalidEmailFormat, "メールアドレスの形式が正しくありません").WithDetails(detail)
+		}
+		emails = append(emails, email)
+	}
+	return emails, nil
+}
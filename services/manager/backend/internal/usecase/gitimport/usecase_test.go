@@ -0,0 +1,273 @@
+package gitimport
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sky0621/techcv/manager/backend/internal/domain"
+	"github.com/sky0621/techcv/manager/backend/internal/domain/skill"
+	"github.com/sky0621/techcv/manager/backend/internal/domain/user"
+	"github.com/sky0621/techcv/manager/backend/internal/infrastructure/persistence/memory"
+	"github.com/sky0621/techcv/manager/backend/internal/infrastructure/transaction"
+)
+
+const userID = "0190a0b2-0000-7000-8000-000000000001"
+
+type stubAuthorEmailRepository struct {
+	account    string
+	additional []user.Email
+}
+
+func (s *stubAuthorEmailRepository) GetEmail(_ context.Context, id string) (user.Email, error) {
+	if id != userID {
+		return user.Email{}, domain.NewNotFound(domain.ErrorCodeUserNotFound, "ユーザーが見つかりません")
+	}
+	return user.NewEmail(s.account)
+}
+
+func (s *stubAuthorEmailRepository) GitAuthorEmails(context.Context, string) ([]user.Email, error) {
+	return s.additional, nil
+}
+
+func (s *stubAuthorEmailRepository) ReplaceGitAuthorEmails(_ context.Context, _ string, emails []user.Email, _ time.Time) error {
+	s.additional = emails
+	return nil
+}
+
+type fixedClock struct{}
+
+func (fixedClock) Now() time.Time {
+	return time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+}
+
+type stubCatalogRepository struct {
+	catalog *skill.Catalog
+	loadErr error
+}
+
+func (s *stubCatalogRepository) Load(context.Context) (*skill.Catalog, error) {
+	if s.loadErr != nil {
+		return nil, s.loadErr
+	}
+	return s.catalog, nil
+}
+
+func (s *stubCatalogRepository) Save(context.Context, *skill.Catalog) error {
+	return nil
+}
+
+func (s *stubCatalogRepository) Revision(context.Context) (uint64, error) {
+	return 0, nil
+}
+
+func (s *stubCatalogRepository) Lock(context.Context) error {
+	return nil
+}
+
+func newCatalogRepository(t *testing.T) *stubCatalogRepository {
+	t.Helper()
+	golang, err := skill.NewSkill("Golang", skill.CategoryLanguage, []string{"Go"}, "")
+	if err != nil {
+		t.Fatalf("NewSkill() error = %v", err)
+	}
+	catalog, err := skill.NewCatalog([]skill.Skill{golang})
+	if err != nil {
+		t.Fatalf("NewCatalog() error = %v", err)
+	}
+	return &stubCatalogRepository{catalog: catalog}
+}
+
+const numstat = `commit abc
+Author: Taro <taro@example.com>
+Date:   2024-03-01 10:00:00 +0900
+
+    Initial commit
+
+20	0	main.go
+`
+
+func TestAnalyze(t *testing.T) {
+	catalogs := newCatalogRepository(t)
+	usage := memory.NewSkillUsageRepository()
+	usecase := New(catalogs, usage, nil, nil, nil)
+
+	report, err := usecase.Analyze(context.Background(), AnalyzeInput{
+		Numstat:      strings.NewReader(numstat),
+		AuthorEmails: []string{"TARO@example.com"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if report.AttributedCommits != 1 {
+		t.Fatalf("unexpected attributed commits: %d", report.AttributedCommits)
+	}
+
+	if len(report.Technologies) != 1 || report.Technologies[0].Technology != "Golang" {
+		t.Fatalf("unexpected technologies: %+v", report.Technologies)
+	}
+
+	counts, _ := usage.Counts(context.Background())
+	if len(counts) != 0 {
+		t.Fatalf("expected an anonymous analysis not to count, got %v", counts)
+	}
+}
+
+func TestAnalyzeSuggestsFuzzyMatches(t *testing.T) {
+	misspelled, err := skill.NewSkill("TypeScrypt", skill.CategoryLanguage, nil, "")
+	if err != nil {
+		t.Fatalf("NewSkill() error = %v", err)
+	}
+	catalog, err := skill.NewCatalog([]skill.Skill{misspelled})
+	if err != nil {
+		t.Fatalf("NewCatalog() error = %v", err)
+	}
+	usecase := New(&stubCatalogRepository{catalog: catalog}, memory.NewSkillUsageRepository(), nil, nil, nil)
+
+	report, err := usecase.Analyze(context.Background(), AnalyzeInput{
+		Numstat:      strings.NewReader(strings.Replace(numstat, "main.go", "main.ts", 1)),
+		AuthorEmails: []string{"taro@example.com"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(report.Technologies) != 1 {
+		t.Fatalf("unexpected technologies: %+v", report.Technologies)
+	}
+	got := report.Technologies[0]
+	if got.Technology != "TypeScript" || got.SuggestedName != "TypeScrypt" {
+		t.Fatalf("expected the inferred name with the catalog name suggested, got %+v", got)
+	}
+}
+
+func TestImportCountsEachUserOnce(t *testing.T) {
+	catalogs := newCatalogRepository(t)
+	usage := memory.NewSkillUsageRepository()
+	usecase := New(catalogs, usage, &stubAuthorEmailRepository{account: "taro@example.com"}, transaction.NewNoopManager(), fixedClock{})
+
+	for range 2 {
+		if _, err := usecase.Import(context.Background(), userID, strings.NewReader(numstat)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	counts, _ := usage.Counts(context.Background())
+	golang := catalogs.catalog.Skills()[0]
+	if counts[golang.ID()] != 1 {
+		t.Fatalf("expected repeated imports to count the user once, got %v", counts)
+	}
+}
+
+func TestAnalyzeValidationErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     AnalyzeInput
+		wantCode  string
+		wantField string
+	}{
+		{
+			name:      "no author emails",
+			input:     AnalyzeInput{Numstat: strings.NewReader(numstat)},
+			wantCode:  domain.ErrorCodeAuthorEmailRequired,
+			wantField: "author_emails",
+		},
+		{
+			name:      "invalid author email",
+			input:     AnalyzeInput{Numstat: strings.NewReader(numstat), AuthorEmails: []string{"taro@example.com", "nope"}},
+			wantCode:  domain.ErrorCodeInvalidEmailFormat,
+			wantField: "author_emails[1]",
+		},
+		{
+			name:      "malformed numstat",
+			input:     AnalyzeInput{Numstat: strings.NewReader("garbage\n"), AuthorEmails: []string{"taro@example.com"}},
+			wantCode:  domain.ErrorCodeInvalidNumstat,
+			wantField: "numstat",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(newCatalogRepository(t), memory.NewSkillUsageRepository(), nil, nil, nil).Analyze(context.Background(), tt.input)
+
+			var appErr *domain.AppError
+			if err == nil || !errors.As(err, &appErr) {
+				t.Fatalf("expected app error, got %v", err)
+			}
+			if appErr.Code != tt.wantCode {
+				t.Fatalf("unexpected code: got %s, want %s", appErr.Code, tt.wantCode)
+			}
+			if len(appErr.Details) != 1 || appErr.Details[0].Field != tt.wantField {
+				t.Fatalf("unexpected details: %+v", appErr.Details)
+			}
+		})
+	}
+}
+
+func TestImportUsesConfiguredAuthorEmails(t *testing.T) {
+	const dump = `commit abc
+Author: Taro <taro@example.com>
+Date:   2024-03-01 10:00:00 +0900
+
+    Account email
+
+20	0	main.go
+
+commit def
+Author: Taro <taro@work.example.com>
+Date:   2024-03-02 10:00:00 +0900
+
+    Work email
+
+5	0	main.go
+
+commit ghi
+Author: Hanako <hanako@example.com>
+Date:   2024-03-03 10:00:00 +0900
+
+    Someone else
+
+7	0	main.go
+`
+	authors := &stubAuthorEmailRepository{account: "taro@example.com"}
+	usecase := New(newCatalogRepository(t), memory.NewSkillUsageRepository(), authors, transaction.NewNoopManager(), fixedClock{})
+
+	emails, err := usecase.SetAuthorEmails(context.Background(), userID, []string{"Taro@Work.example.com", "taro@example.com", "taro@work.example.com"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if emails.Account != "taro@example.com" || !reflect.DeepEqual(emails.Additional, []string{"taro@work.example.com"}) {
+		t.Fatalf("expected the account email and duplicates to be dropped, got %+v", emails)
+	}
+
+	report, err := usecase.Import(context.Background(), userID, strings.NewReader(dump))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.TotalCommits != 3 || report.AttributedCommits != 2 {
+		t.Fatalf("expected the user's two commits to be attributed, got %d of %d", report.AttributedCommits, report.TotalCommits)
+	}
+}
+
+func TestSetAuthorEmailsValidation(t *testing.T) {
+	usecase := New(newCatalogRepository(t), memory.NewSkillUsageRepository(), &stubAuthorEmailRepository{account: "taro@example.com"}, transaction.NewNoopManager(), fixedClock{})
+
+	_, err := usecase.SetAuthorEmails(context.Background(), userID, []string{"nope"})
+	var appErr *domain.AppError
+	if !errors.As(err, &appErr) || appErr.Code != domain.ErrorCodeInvalidEmailFormat {
+		t.Fatalf("expected invalid email, got %v", err)
+	}
+
+	tooMany := make([]string, maxAuthorEmails+1)
+	for i := range tooMany {
+		tooMany[i] = "taro@example.com"
+	}
+	_, err = usecase.SetAuthorEmails(context.Background(), userID, tooMany)
+	if !errors.As(err, &appErr) || appErr.Code != domain.ErrorCodeTooManyAuthorEmails {
+		t.Fatalf("expected too many author emails, got %v", err)
+	}
+}
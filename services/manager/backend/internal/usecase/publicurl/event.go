@@ -0,0 +1,290 @@
+package publicurl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/sky0621/techcv/manager/backend/internal/domain"
+)
+
+const maxTakedownNoteLength = 255
+
+// Events returns one of the user's links with its activation history, oldest first.
+func (u *Usecase) Events(ctx context.Context, userID string, id uint64) (*domain.PublicURL, []domain.PublicURLEvent, error) {
+	link, err := u.Get(ctx, userID, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	events, err := u.events(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	return link, events, nil
+}
+
+// EventsByAddress returns any user's link behind a key or slug with its activation history,
+// for admins looking into a reported link.
+func (u *Usecase) EventsByAddress(ctx context.Context, address string) (*domain.PublicURL, []domain.PublicURLEvent, error) {
+	link, err := u.lookup(ctx, address)
+	if err != nil {
+		return nil, nil, err
+	}
+	events, err := u.events(ctx, link.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return link, events, nil
+}
+
+// TakeDown lets an admin turn off any user's link behind a key or slug. The note explaining
+// why is kept with the event. The owner cannot turn the link back on until an admin lifts
+// the takedown; a link that is off already is taken down all the same. Links taken down
+// already are left as they are.
+func (u *Usecase) TakeDown(ctx context.Context, address, note string) (*domain.PublicURL, error) {
+	note = strings.TrimSpace(note)
+	if note == "" || utf8.RuneCountInString(note) > maxTakedownNoteLength {
+		detail := domain.ErrorDetail{Field: "note", Code: "public_url.note_length", Message: "note must be 1 to 255 characters"}
+		return nil, domain.NewValidation("public_url.invalid_takedown", "invalid public URL takedown").WithDetails(detail)
+	}
+
+	link, err := u.lookup(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.modify(ctx, link.UserID, link.ID, func(ctx context.Context, current domain.PublicURL) error {
+		events, err := u.events(ctx, current.ID)
+		if err != nil {
+			return err
+		}
+		if takenDown(events) {
+			return nil
+		}
+		now := u.clock.Now()
+		if err := u.recordWindow(ctx, current, now); err != nil {
+			return err
+		}
+		if current.IsActive {
+			if err := u.repo.SetActive(ctx, current.UserID, current.ID, false); err != nil {
+				return domain.NewInternal("public_url.update_failed", "failed to update public URL", err)
+			}
+		}
+		return u.recordEvent(ctx, domain.PublicURLEvent{
+			PublicURLID: current.ID,
+			Reason:      domain.PublicURLEventTakenDown,
+			Actor:       domain.PublicURLActorAdmin,
+			Note:        note,
+			OccurredAt:  now,
+		})
+	})
+}
+
+// LiftTakeDown lets an admin allow the owner of a taken down link behind a key or slug to
+// turn it back on. The link itself stays off. Links not taken down are left as they are.
+func (u *Usecase) LiftTakeDown(ctx context.Context, address string) (*domain.PublicURL, error) {
+	link, err := u.lookup(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.modify(ctx, link.UserID, link.ID, func(ctx context.Context, current domain.PublicURL) error {
+		events, err := u.events(ctx, current.ID)
+		if err != nil {
+			return err
+		}
+		if !takenDown(events) {
+			return nil
+		}
+		return u.recordEvent(ctx, domain.PublicURLEvent{
+			PublicURLID: current.ID,
+			Reason:      domain.PublicURLEventTakedownLifted,
+			Actor:       domain.PublicURLActorAdmin,
+			OccurredAt:  u.clock.Now(),
+		})
+	})
+}
+
+// takenDown reports whether the latest takedown in the history has not been lifted.
+func takenDown(events []domain.PublicURLEvent) bool {
+	for i := len(events) - 1; i >= 0; i-- {
+		switch events[i].Reason {
+		case domain.PublicURLEventTakenDown:
+			return true
+		case domain.PublicURLEventTakedownLifted:
+			return false
+		}
+	}
+	return false
+}
+
+// RecordStarts records the start of the viewing window of active links that went live at
+// their activates_at and returns how many were recorded. Each start is recorded once, at
+// the time the window started, even when several instances run the sweep.
+func (u *Usecase) RecordStarts(ctx context.Context) (int, error) {
+	starts, err := u.repo.UnrecordedStarts(ctx, u.clock.Now())
+	if err != nil {
+		return 0, domain.NewInternal("public_url.fetch_failed", "failed to list started public URLs", err)
+	}
+	recorded, errs := u.recordSwept(ctx, starts)
+	if len(errs) > 0 {
+		return recorded, domain.NewInternal("public_url.event_record_failed", "failed to record public URL starts", errors.Join(errs...))
+	}
+	return recorded, nil
+}
+
+// RecordExpiries records the expiry of active links whose viewing window has ended and
+// returns how many were recorded. Each expiry is recorded once, at the time the link
+// expired, even when several instances run the sweep.
+func (u *Usecase) RecordExpiries(ctx context.Context) (int, error) {
+	expiries, err := u.repo.UnrecordedExpiries(ctx, u.clock.Now())
+	if err != nil {
+		return 0, domain.NewInternal("public_url.fetch_failed", "failed to list expired public URLs", err)
+	}
+	recorded, errs := u.recordSwept(ctx, expiries)
+	if len(errs) > 0 {
+		return recorded, domain.NewInternal("public_url.event_record_failed", "failed to record public URL expiries", errors.Join(errs...))
+	}
+	return recorded, nil
+}
+
+// recordSwept records the events found by a sweep, skipping those another instance
+// recorded in the meantime.
+func (u *Usecase) recordSwept(ctx context.Context, events []domain.PublicURLEvent) (int, []error) {
+	recorded := 0
+	var errs []error
+	for _, e := range events {
+		err := u.repo.RecordEvent(ctx, e)
+		if errors.Is(err, domain.ErrPublicURLEventRecorded) {
+			continue
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("record %s of public URL %d: %w", e.Reason, e.PublicURLID, err))
+			continue
+		}
+		recorded++
+	}
+	return recorded, errs
+}
+
+func (u *Usecase) events(ctx context.Context, id uint64) ([]domain.PublicURLEvent, error) {
+	events, err := u.repo.Events(ctx, id)
+	if err != nil {
+		return nil, domain.NewInternal("public_url.events_fetch_failed", "failed to fetch public URL events", err)
+	}
+	return events, nil
+}
+
+// lookup finds any user's link by a key or slug, whether or not it can be viewed.
+func (u *Usecase) lookup(ctx context.Context, address string) (*domain.PublicURL, error) {
+	found, _, err := u.find(ctx, address, u.clock.Now())
+	if err != nil {
+		return nil, domain.NewInternal("public_url.fetch_failed", "failed to fetch public URL", err)
+	}
+	if found == nil {
+		return nil, domain.NewNotFound("public_url.not_found", "public URL not found")
+	}
+	return found, nil
+}
+
+// replaceAll turns off the user's links ahead of a replacement being issued.
+func (u *Usecase) replaceAll(ctx context.Context, userID string) error {
+	links, err := u.repo.List(ctx, userID)
+	if err != nil {
+		return domain.NewInternal("public_url.list_failed", "failed to list public URLs", err)
+	}
+	now := u.clock.Now()
+	for _, link := range links {
+		if err := u.recordWindow(ctx, link, now); err != nil {
+			return err
+		}
+	}
+	if err := u.repo.DeactivateAll(ctx, userID); err != nil {
+		return domain.NewInternal("public_url.deactivate_failed", "failed to deactivate existing public URLs", err)
+	}
+
+	for _, link := range links {
+		if !link.IsActive {
+			continue
+		}
+		if err := u.recordEvent(ctx, ownerEvent(userID, link.ID, domain.PublicURLEventRegenerated, false, now)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recordWindow records the start and the expiry of an active link's viewing window that
+// have passed but may not have been swept yet. It runs before every change to a link, so
+// that they come before the change in the history and the start is not missed: the start
+// sweep skips links changed since their window started.
+func (u *Usecase) recordWindow(ctx context.Context, link domain.PublicURL, now time.Time) error {
+	if !link.IsActive {
+		return nil
+	}
+	if link.ActivatesAt != nil && !now.Before(*link.ActivatesAt) {
+		events, err := u.events(ctx, link.ID)
+		if err != nil {
+			return err
+		}
+		if !changedSince(events, *link.ActivatesAt) {
+			if err := u.recordOnce(ctx, systemEvent(link.ID, domain.PublicURLEventStarted, true, *link.ActivatesAt)); err != nil {
+				return err
+			}
+		}
+	}
+	if link.ExpiresAt != nil && !now.Before(*link.ExpiresAt) {
+		return u.recordOnce(ctx, systemEvent(link.ID, domain.PublicURLEventExpired, false, *link.ExpiresAt))
+	}
+	return nil
+}
+
+// changedSince reports whether an event other than an expiry was recorded at or after t.
+func changedSince(events []domain.PublicURLEvent, t time.Time) bool {
+	for _, e := range events {
+		if e.Reason != domain.PublicURLEventExpired && !e.OccurredAt.Before(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// recordOnce records a system event unless a sweep recorded it already.
+func (u *Usecase) recordOnce(ctx context.Context, event domain.PublicURLEvent) error {
+	err := u.repo.RecordEvent(ctx, event)
+	if err != nil && !errors.Is(err, domain.ErrPublicURLEventRecorded) {
+		return domain.NewInternal("public_url.event_record_failed", "failed to record public URL event", err)
+	}
+	return nil
+}
+
+func (u *Usecase) recordEvent(ctx context.Context, event domain.PublicURLEvent) error {
+	if err := u.repo.RecordEvent(ctx, event); err != nil {
+		return domain.NewInternal("public_url.event_record_failed", "failed to record public URL event", err)
+	}
+	return nil
+}
+
+func ownerEvent(userID string, id uint64, reason domain.PublicURLEventReason, active bool, now time.Time) domain.PublicURLEvent {
+	return domain.PublicURLEvent{
+		PublicURLID: id,
+		Reason:      reason,
+		Active:      active,
+		Actor:       domain.PublicURLActorOwner,
+		ActorUserID: userID,
+		OccurredAt:  now,
+	}
+}
+
+func systemEvent(id uint64, reason domain.PublicURLEventReason, active bool, at time.Time) domain.PublicURLEvent {
+	return domain.PublicURLEvent{
+		PublicURLID: id,
+		Reason:      reason,
+		Active:      active,
+		Actor:       domain.PublicURLActorSystem,
+		OccurredAt:  at,
+	}
+}
@@ -0,0 +1,293 @@
+package publicurl
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sky0621/techcv/manager/backend/internal/domain"
+)
+
+func TestEventsRecordOwnerChanges(t *testing.T) {
+	repo := &mockRepository{}
+	repo.put(domain.PublicURL{ID: 1, UserID: ownerID, URLKey: "company-a", IsActive: true})
+	repo.put(domain.PublicURL{ID: 2, UserID: ownerID, URLKey: "company-b", IsActive: false})
+	usecase := newTestUsecase(repo, nil)
+
+	created, err := usecase.Create(context.Background(), ownerID, CreateInput{Label: "Company C", ReplaceExisting: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := usecase.Deactivate(context.Background(), ownerID, created.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := usecase.Activate(context.Background(), ownerID, created.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, replaced, err := usecase.Events(context.Background(), ownerID, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(replaced) != 1 || replaced[0].Reason != domain.PublicURLEventRegenerated || replaced[0].Active {
+		t.Fatalf("expected the replaced link to be recorded as regenerated, got %+v", replaced)
+	}
+	if _, untouched, _ := usecase.Events(context.Background(), ownerID, 2); len(untouched) != 0 {
+		t.Fatalf("expected no event for a link that was off already, got %+v", untouched)
+	}
+
+	_, events, err := usecase.Events(context.Background(), ownerID, created.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []domain.PublicURLEventReason{domain.PublicURLEventCreated, domain.PublicURLEventRevoked, domain.PublicURLEventActivated}
+	wantActive := []bool{true, false, true}
+	if len(events) != len(want) {
+		t.Fatalf("expected %d events, got %+v", len(want), events)
+	}
+	for i, e := range events {
+		if e.Reason != want[i] || e.Active != wantActive[i] || e.Actor != domain.PublicURLActorOwner || e.ActorUserID != ownerID ||
+			!e.OccurredAt.Equal(testNow) {
+			t.Fatalf("unexpected event %d: %+v", i, e)
+		}
+	}
+}
+
+func TestEventsOfOtherUsersLink(t *testing.T) {
+	repo := &mockRepository{}
+	repo.put(domain.PublicURL{ID: 1, UserID: "someone-else", URLKey: "key", IsActive: true})
+	usecase := newTestUsecase(repo, nil)
+
+	_, _, err := usecase.Events(context.Background(), ownerID, 1)
+
+	var appErr *domain.AppError
+	if !errors.As(err, &appErr) || appErr.Code != "public_url.not_found" {
+		t.Fatalf("expected not found, got %v", err)
+	}
+}
+
+func TestTakeDown(t *testing.T) {
+	repo := &mockRepository{slugs: map[string]*mockSlug{"taro-yamada": {id: 1}}}
+	repo.put(domain.PublicURL{ID: 1, UserID: ownerID, URLKey: "key", Slug: "taro-yamada", IsActive: true})
+	usecase := newTestUsecase(repo, nil)
+
+	taken, err := usecase.TakeDown(context.Background(), "Taro-Yamada", "  impersonation report  ")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if taken.IsActive {
+		t.Fatalf("expected the link to be turned off")
+	}
+	if _, err := usecase.TakeDown(context.Background(), "key", "second report"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	link, events, err := usecase.EventsByAddress(context.Background(), "key")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if link.ID != 1 || len(events) != 1 {
+		t.Fatalf("expected a single takedown of link 1, got %+v %+v", link, events)
+	}
+	got := events[0]
+	if got.Reason != domain.PublicURLEventTakenDown || got.Actor != domain.PublicURLActorAdmin || got.ActorUserID != "" || got.Note != "impersonation report" {
+		t.Fatalf("unexpected event: %+v", got)
+	}
+}
+
+func TestTakeDownKeepsOwnerFromReactivating(t *testing.T) {
+	repo := &mockRepository{}
+	repo.put(domain.PublicURL{ID: 1, UserID: ownerID, URLKey: "live", IsActive: true})
+	repo.put(domain.PublicURL{ID: 2, UserID: ownerID, URLKey: "off", IsActive: false})
+	usecase := newTestUsecase(repo, nil)
+
+	for _, link := range []struct {
+		id      uint64
+		address string
+	}{{1, "live"}, {2, "off"}} {
+		if _, err := usecase.TakeDown(context.Background(), link.address, "phishing report"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		_, err := usecase.Activate(context.Background(), ownerID, link.id)
+		var appErr *domain.AppError
+		if !errors.As(err, &appErr) || appErr.Code != "public_url.taken_down" {
+			t.Fatalf("expected link %d to stay taken down, got %v", link.id, err)
+		}
+		if repo.stored[link.id].IsActive {
+			t.Fatalf("expected link %d to stay off", link.id)
+		}
+
+		if _, err := usecase.LiftTakeDown(context.Background(), link.address); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		activated, err := usecase.Activate(context.Background(), ownerID, link.id)
+		if err != nil {
+			t.Fatalf("expected the owner to turn link %d back on after the takedown was lifted, got %v", link.id, err)
+		}
+		if !activated.IsActive {
+			t.Fatalf("expected link %d to be on", link.id)
+		}
+	}
+
+	_, events, _ := usecase.Events(context.Background(), ownerID, 2)
+	want := []domain.PublicURLEventReason{domain.PublicURLEventTakenDown, domain.PublicURLEventTakedownLifted, domain.PublicURLEventActivated}
+	if len(events) != len(want) {
+		t.Fatalf("expected %d events, got %+v", len(want), events)
+	}
+	for i, e := range events {
+		if e.Reason != want[i] {
+			t.Fatalf("unexpected event %d: %+v", i, e)
+		}
+	}
+}
+
+func TestTakeDownRejectsMissingNote(t *testing.T) {
+	repo := &mockRepository{}
+	repo.put(domain.PublicURL{ID: 1, UserID: ownerID, URLKey: "key", IsActive: true})
+	usecase := newTestUsecase(repo, nil)
+
+	_, err := usecase.TakeDown(context.Background(), "key", "   ")
+
+	var appErr *domain.AppError
+	if !errors.As(err, &appErr) || appErr.Code != "public_url.invalid_takedown" {
+		t.Fatalf("expected invalid takedown, got %v", err)
+	}
+	if !repo.stored[1].IsActive {
+		t.Fatalf("expected the link to stay on")
+	}
+}
+
+func TestRecordExpiries(t *testing.T) {
+	expired := testNow.Add(-time.Hour)
+	later := testNow.Add(time.Hour)
+	repo := &mockRepository{}
+	repo.put(domain.PublicURL{ID: 1, UserID: ownerID, URLKey: "expired", IsActive: true, ExpiresAt: &expired})
+	repo.put(domain.PublicURL{ID: 2, UserID: ownerID, URLKey: "revoked", IsActive: false, ExpiresAt: &expired})
+	repo.put(domain.PublicURL{ID: 3, UserID: ownerID, URLKey: "live", IsActive: true, ExpiresAt: &later})
+	usecase := newTestUsecase(repo, nil)
+
+	recorded, err := usecase.RecordExpiries(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if recorded != 1 {
+		t.Fatalf("expected one expiry, got %d", recorded)
+	}
+	if again, _ := usecase.RecordExpiries(context.Background()); again != 0 {
+		t.Fatalf("expected the expiry to be recorded once, got %d more", again)
+	}
+
+	_, events, _ := usecase.Events(context.Background(), ownerID, 1)
+	if len(events) != 1 || events[0].Reason != domain.PublicURLEventExpired || events[0].Active ||
+		events[0].Actor != domain.PublicURLActorSystem || !events[0].OccurredAt.Equal(expired) {
+		t.Fatalf("expected an expiry at the link's expires_at, got %+v", events)
+	}
+}
+
+func TestRecordStarts(t *testing.T) {
+	started := testNow.Add(-time.Hour)
+	later := testNow.Add(time.Hour)
+	repo := &mockRepository{}
+	repo.put(domain.PublicURL{ID: 1, UserID: ownerID, URLKey: "started", IsActive: true, ActivatesAt: &started})
+	repo.put(domain.PublicURL{ID: 2, UserID: ownerID, URLKey: "revoked", IsActive: false, ActivatesAt: &started})
+	repo.put(domain.PublicURL{ID: 3, UserID: ownerID, URLKey: "scheduled", IsActive: true, ActivatesAt: &later})
+	usecase := newTestUsecase(repo, nil)
+
+	recorded, err := usecase.RecordStarts(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if recorded != 1 {
+		t.Fatalf("expected one start, got %d", recorded)
+	}
+	if again, _ := usecase.RecordStarts(context.Background()); again != 0 {
+		t.Fatalf("expected the start to be recorded once, got %d more", again)
+	}
+
+	_, events, _ := usecase.Events(context.Background(), ownerID, 1)
+	if len(events) != 1 || events[0].Reason != domain.PublicURLEventStarted || !events[0].Active ||
+		events[0].Actor != domain.PublicURLActorSystem || !events[0].OccurredAt.Equal(started) {
+		t.Fatalf("expected a start at the link's activates_at, got %+v", events)
+	}
+}
+
+func TestDeactivateRecordsMissedWindowFirst(t *testing.T) {
+	started := testNow.Add(-2 * time.Hour)
+	expired := testNow.Add(-time.Hour)
+	repo := &mockRepository{}
+	repo.put(domain.PublicURL{ID: 1, UserID: ownerID, URLKey: "key", IsActive: true, ActivatesAt: &started, ExpiresAt: &expired})
+	usecase := newTestUsecase(repo, nil)
+
+	if _, err := usecase.Deactivate(context.Background(), ownerID, 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if recorded, _ := usecase.RecordStarts(context.Background()); recorded != 0 {
+		t.Fatalf("expected the sweep to find nothing left to record, got %d", recorded)
+	}
+
+	_, events, _ := usecase.Events(context.Background(), ownerID, 1)
+	want := []domain.PublicURLEventReason{domain.PublicURLEventStarted, domain.PublicURLEventExpired, domain.PublicURLEventRevoked}
+	wantAt := []time.Time{started, expired, testNow}
+	if len(events) != len(want) {
+		t.Fatalf("expected %d events, got %+v", len(want), events)
+	}
+	for i, e := range events {
+		if e.Reason != want[i] || !e.OccurredAt.Equal(wantAt[i]) {
+			t.Fatalf("unexpected event %d: %+v", i, e)
+		}
+	}
+}
+
+func TestScheduleLaterStartIsRecordedWhenItStarts(t *testing.T) {
+	repo := &mockRepository{}
+	repo.put(domain.PublicURL{ID: 1, UserID: ownerID, URLKey: "key", IsActive: true})
+	usecase := newTestUsecase(repo, nil)
+
+	start := testNow.Add(24 * time.Hour)
+	if _, err := usecase.Schedule(context.Background(), ownerID, 1, ScheduleInput{ActivatesAt: &start}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	usecase.clock = fakeClock{now: start.Add(time.Minute)}
+	if recorded, err := usecase.RecordStarts(context.Background()); err != nil || recorded != 1 {
+		t.Fatalf("expected the new window's start to be recorded, got %d, %v", recorded, err)
+	}
+
+	_, events, _ := usecase.Events(context.Background(), ownerID, 1)
+	if len(events) != 2 {
+		t.Fatalf("expected the change and the start, got %+v", events)
+	}
+	if events[0].Reason != domain.PublicURLEventRescheduled || events[0].Active || !events[0].OccurredAt.Equal(testNow) {
+		t.Fatalf("expected the link to be recorded as off until its window starts, got %+v", events[0])
+	}
+	if events[1].Reason != domain.PublicURLEventStarted || !events[1].Active || !events[1].OccurredAt.Equal(start) {
+		t.Fatalf("expected the link to be recorded as live when its window starts, got %+v", events[1])
+	}
+}
+
+func TestScheduleRecordsReturnOfExpiredLink(t *testing.T) {
+	expired := testNow.Add(-time.Hour)
+	repo := &mockRepository{}
+	repo.put(domain.PublicURL{ID: 1, UserID: ownerID, URLKey: "key", IsActive: true, ExpiresAt: &expired})
+	usecase := newTestUsecase(repo, nil)
+
+	extended := testNow.Add(7 * 24 * time.Hour)
+	if _, err := usecase.Schedule(context.Background(), ownerID, 1, ScheduleInput{ExpiresAt: &extended}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := usecase.Schedule(context.Background(), ownerID, 1, ScheduleInput{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, events, _ := usecase.Events(context.Background(), ownerID, 1)
+	if len(events) != 2 {
+		t.Fatalf("expected the expiry and the return, got %+v", events)
+	}
+	if events[0].Reason != domain.PublicURLEventExpired || events[0].Active || !events[0].OccurredAt.Equal(expired) {
+		t.Fatalf("expected the missed expiry to be recorded first, got %+v", events[0])
+	}
+	if events[1].Reason != domain.PublicURLEventRescheduled || !events[1].Active || events[1].ActorUserID != ownerID {
+		t.Fatalf("unexpected event: %+v", events[1])
+	}
+}
@@ -0,0 +1,80 @@
+package publicurl
+
+import (
+	"context"
+
+	"github.com/sky0621/techcv/manager/backend/internal/domain"
+)
+
+const (
+	defaultQRCodeSize = 512
+	minQRCodeSize     = 128
+	maxQRCodeSize     = 2048
+)
+
+// QRCodeImage is a rendered QR code.
+type QRCodeImage struct {
+	Format domain.QRCodeFormat
+	Data   []byte
+}
+
+// QRCode renders the share URL of one of the user's links as a QR code. Zero options
+// select a 512 pixel PNG at level M, or at level H when the logo is placed. The logo
+// covers part of the code, so it requires level Q or H.
+func (u *Usecase) QRCode(ctx context.Context, userID string, id uint64, opts domain.QRCodeOptions) (*QRCodeImage, error) {
+	opts, err := normalizeQRCodeOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	link, err := u.Get(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := u.qrCodes.Render(u.ShareURL(link.Address()), opts)
+	if err != nil {
+		return nil, domain.NewInternal("public_url.qr_code_failed", "failed to render public URL QR code", err)
+	}
+	return &QRCodeImage{Format: opts.Format, Data: data}, nil
+}
+
+func normalizeQRCodeOptions(opts domain.QRCodeOptions) (domain.QRCodeOptions, error) {
+	switch opts.Format {
+	case "":
+		opts.Format = domain.QRCodeFormatPNG
+	case domain.QRCodeFormatPNG, domain.QRCodeFormatSVG:
+	default:
+		return opts, InvalidQRCodeOption("format", "public_url.qr_code_format", "format must be png or svg")
+	}
+
+	if opts.Size == 0 {
+		opts.Size = defaultQRCodeSize
+	}
+	if opts.Size < minQRCodeSize || opts.Size > maxQRCodeSize {
+		return opts, InvalidQRCodeOption("size", "public_url.qr_code_size", "size must be between 128 and 2048")
+	}
+
+	switch opts.Level {
+	case "":
+		opts.Level = domain.QRCodeLevelMedium
+		if opts.Logo {
+			opts.Level = domain.QRCodeLevelHigh
+		}
+	case domain.QRCodeLevelLow, domain.QRCodeLevelMedium:
+		if opts.Logo {
+			return opts, InvalidQRCodeOption("error_correction", "public_url.qr_code_logo_level", "error_correction must be Q or H when the logo is placed")
+		}
+	case domain.QRCodeLevelQuartile, domain.QRCodeLevelHigh:
+	default:
+		return opts, InvalidQRCodeOption("error_correction", "public_url.qr_code_level", "error_correction must be one of L, M, Q or H")
+	}
+	return opts, nil
+}
+
+// InvalidQRCodeOption reports a QR code option that cannot be used. It is exported so that
+// options which fail to parse are reported the same way as those which fail validation.
+func InvalidQRCodeOption(field, code, message string) error {
+	detail := domain.ErrorDetail{Field: field, Code: code, Message: message}
+	return domain.NewValidation("public_url.invalid_qr_code", "invalid QR code options").WithDetails(detail)
+}
@@ -0,0 +1,67 @@
+package publicurl
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/sky0621/techcv/manager/backend/internal/domain"
+)
+
+func TestQRCodeEncodesShareURL(t *testing.T) {
+	repo := &mockRepository{}
+	repo.put(domain.PublicURL{ID: 1, UserID: ownerID, URLKey: "key", Slug: "taro-yamada", IsActive: true})
+	usecase := newTestUsecase(repo, nil)
+	renderer := usecase.qrCodes.(*fakeQRCodeRenderer)
+
+	image, err := usecase.QRCode(context.Background(), ownerID, 1, domain.QRCodeOptions{Logo: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if image.Format != domain.QRCodeFormatPNG || string(image.Data) != "image of https://cv.example.com/cv/taro-yamada" {
+		t.Fatalf("unexpected image: %+v", image)
+	}
+	want := domain.QRCodeOptions{Format: domain.QRCodeFormatPNG, Size: 512, Level: domain.QRCodeLevelHigh, Logo: true}
+	if renderer.opts[0] != want {
+		t.Fatalf("expected defaults %+v, got %+v", want, renderer.opts[0])
+	}
+}
+
+func TestQRCodeRejectsInvalidOptions(t *testing.T) {
+	repo := &mockRepository{}
+	repo.put(domain.PublicURL{ID: 1, UserID: ownerID, URLKey: "key", IsActive: true})
+	usecase := newTestUsecase(repo, nil)
+
+	tests := map[string]domain.QRCodeOptions{
+		"format":           {Format: "gif"},
+		"size":             {Size: 64},
+		"error_correction": {Level: "X"},
+		"logo at level M":  {Level: domain.QRCodeLevelMedium, Logo: true},
+	}
+
+	for name, opts := range tests {
+		_, err := usecase.QRCode(context.Background(), ownerID, 1, opts)
+
+		var appErr *domain.AppError
+		if !errors.As(err, &appErr) || appErr.Code != "public_url.invalid_qr_code" {
+			t.Errorf("%s: expected invalid options error, got %v", name, err)
+		}
+	}
+	if renderer := usecase.qrCodes.(*fakeQRCodeRenderer); len(renderer.contents) != 0 {
+		t.Fatalf("expected nothing to be rendered, got %v", renderer.contents)
+	}
+}
+
+func TestQRCodeOfOtherUsersLink(t *testing.T) {
+	repo := &mockRepository{}
+	repo.put(domain.PublicURL{ID: 1, UserID: "someone-else", URLKey: "key", IsActive: true})
+	usecase := newTestUsecase(repo, nil)
+
+	_, err := usecase.QRCode(context.Background(), ownerID, 1, domain.QRCodeOptions{})
+
+	var appErr *domain.AppError
+	if !errors.As(err, &appErr) || appErr.Code != "public_url.not_found" {
+		t.Fatalf("expected not found, got %v", err)
+	}
+}
@@ -0,0 +1,86 @@
+package publicurl
+
+import (
+	"strings"
+
+	"github.com/sky0621/techcv/manager/backend/internal/domain"
+)
+
+const (
+	minSlugLength = 3
+	// maxSlugLength stays below the 32 characters of generated keys so that a slug can never
+	// be mistaken for another link's key.
+	maxSlugLength = 30
+)
+
+// reservedSlugs are taken by the publisher's own pages or would be confusing as a CV address.
+var reservedSlugs = map[string]struct{}{
+	"about": {}, "account": {}, "admin": {}, "api": {}, "app": {}, "assets": {}, "auth": {},
+	"contact": {}, "cv": {}, "dashboard": {}, "docs": {}, "edit": {}, "help": {}, "home": {},
+	"index": {}, "login": {}, "logout": {}, "me": {}, "new": {}, "null": {}, "official": {},
+	"privacy": {}, "public": {}, "public-urls": {}, "register": {}, "root": {}, "settings": {},
+	"shared": {}, "signin": {}, "signup": {}, "static": {}, "support": {}, "system": {},
+	"techcv": {}, "terms": {}, "undefined": {}, "unlock": {}, "www": {},
+}
+
+// blockedSlugWords are offensive words that may not appear as a word of a slug. Words are
+// matched whole, so that names such as "scunthorpe" stay available.
+var blockedSlugWords = map[string]struct{}{
+	"arse": {}, "asshole": {}, "bastard": {}, "bitch": {}, "bollocks": {}, "bullshit": {},
+	"cock": {}, "cunt": {}, "fag": {}, "faggot": {}, "fuck": {}, "fucker": {},
+	"fucking": {}, "motherfucker": {}, "nazi": {}, "nigger": {}, "porn": {}, "pussy": {},
+	"rape": {}, "retard": {}, "shit": {}, "slut": {}, "twat": {}, "wank": {}, "whore": {},
+	"baka": {}, "chinko": {}, "kichigai": {}, "kuso": {}, "manko": {},
+}
+
+// normalizeSlug lowercases a requested slug and checks it against the slug rules: 3 to 30
+// characters of a-z, 0-9 and single hyphens, starting and ending with a letter or digit, and
+// neither reserved nor offensive. Lowercasing makes slugs unique regardless of case.
+func normalizeSlug(raw string) (string, error) {
+	slug := strings.ToLower(strings.TrimSpace(raw))
+
+	if len(slug) < minSlugLength || len(slug) > maxSlugLength {
+		return "", invalidSlug("public_url.slug_length", "slug must be 3 to 30 characters")
+	}
+	if !validSlugCharacters(slug) {
+		return "", invalidSlug("public_url.slug_characters", "slug may only contain a-z, 0-9 and single hyphens between them")
+	}
+	if _, ok := reservedSlugs[slug]; ok {
+		return "", invalidSlug("public_url.slug_reserved", "slug is reserved")
+	}
+	if containsBlockedWord(slug) {
+		return "", invalidSlug("public_url.slug_not_allowed", "slug contains a word that is not allowed")
+	}
+	return slug, nil
+}
+
+func validSlugCharacters(slug string) bool {
+	if slug[0] == '-' || slug[len(slug)-1] == '-' || strings.Contains(slug, "--") {
+		return false
+	}
+	for i := 0; i < len(slug); i++ {
+		c := slug[i]
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') && c != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+// containsBlockedWord checks each hyphen-separated word as well as the slug with its hyphens
+// removed, which catches words split up to slip past the check.
+func containsBlockedWord(slug string) bool {
+	words := strings.Split(slug, "-")
+	words = append(words, strings.Join(words, ""))
+	for _, word := range words {
+		if _, ok := blockedSlugWords[word]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func invalidSlug(code, message string) error {
+	detail := domain.ErrorDetail{Field: "slug", Code: code, Message: message}
+	return domain.NewValidation("public_url.invalid_slug", "invalid public URL slug").WithDetails(detail)
+}
@@ -0,0 +1,46 @@
+package publicurl
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/sky0621/techcv/manager/backend/internal/domain"
+)
+
+func TestNormalizeSlug(t *testing.T) {
+	valid := map[string]string{
+		"taro-yamada":  "taro-yamada",
+		" Taro-Yamada": "taro-yamada",
+		"abc":          "abc",
+		"dev-2024":     "dev-2024",
+		"scunthorpe":   "scunthorpe",
+	}
+	for raw, want := range valid {
+		got, err := normalizeSlug(raw)
+		if err != nil || got != want {
+			t.Errorf("normalizeSlug(%q) = %q, %v; want %q", raw, got, err, want)
+		}
+	}
+
+	invalid := map[string]string{
+		"ab":                    "public_url.slug_length",
+		strings.Repeat("a", 31): "public_url.slug_length",
+		"taro_yamada":           "public_url.slug_characters",
+		"-taro":                 "public_url.slug_characters",
+		"taro-":                 "public_url.slug_characters",
+		"taro--yamada":          "public_url.slug_characters",
+		"tarō":                  "public_url.slug_characters",
+		"admin":                 "public_url.slug_reserved",
+		"Shared":                "public_url.slug_reserved",
+		"taro-shit":             "public_url.slug_not_allowed",
+		"fu-ck":                 "public_url.slug_not_allowed",
+	}
+	for raw, code := range invalid {
+		_, err := normalizeSlug(raw)
+		var appErr *domain.AppError
+		if !errors.As(err, &appErr) || appErr.Code != "public_url.invalid_slug" || appErr.Details[0].Code != code {
+			t.Errorf("normalizeSlug(%q) error = %v, want %s", raw, err, code)
+		}
+	}
+}
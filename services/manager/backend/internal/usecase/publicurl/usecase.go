@@ -5,79 +5,737 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/sky0621/techcv/manager/backend/internal/domain"
+	"github.com/sky0621/techcv/manager/backend/internal/domain/user"
+)
+
+const (
+	// maxKeyAttempts bounds how many fresh keys Create tries when a key is already taken.
+	maxKeyAttempts      = 5
+	maxLabelLength      = 100
+	maxCVVariantLength  = 64
+	minPassphraseLength = 8
+	// maxPassphraseBytes is bcrypt's input limit; longer passphrases would be truncated silently.
+	maxPassphraseBytes = 72
 )
 
 // Repository defines the persistence operations required by the public URL use case.
 type Repository interface {
-	Create(ctx context.Context, urlKey string) (uint64, error)
-	GetActive(ctx context.Context) (*domain.PublicURL, error)
-	List(ctx context.Context) ([]domain.PublicURL, error)
-	Deactivate(ctx context.Context, id uint64) error
+	// Create returns domain.ErrPublicURLKeyConflict when the URL key is already taken.
+	Create(ctx context.Context, u domain.PublicURL) (uint64, error)
+	Get(ctx context.Context, userID string, id uint64) (*domain.PublicURL, error)
+	GetByKey(ctx context.Context, urlKey string) (*domain.PublicURL, error)
+	// GetBySlug also matches retired slugs whose redirect has not ended at now; retired
+	// reports such a match.
+	GetBySlug(ctx context.Context, slug string, now time.Time) (link *domain.PublicURL, retired bool, err error)
+	List(ctx context.Context, userID string) ([]domain.PublicURL, error)
+	SetActive(ctx context.Context, userID string, id uint64, active bool) error
+	DeactivateAll(ctx context.Context, userID string) error
+	UpdateDetails(ctx context.Context, userID string, id uint64, label, cvVariant string, notifyFirstView bool) error
+	// SetPassphrase stores the passphrase hash; an empty hash removes the protection.
+	SetPassphrase(ctx context.Context, userID string, id uint64, passphraseHash string) error
+	SetSchedule(ctx context.Context, userID string, id uint64, activatesAt, expiresAt *time.Time) error
+	// ClaimSlug returns domain.ErrPublicURLSlugTaken when the slug is in use or still redirecting.
+	ClaimSlug(ctx context.Context, id uint64, slug string) error
+	// ReleaseSlug frees one of the URL's own retired slugs, or any slug whose redirect ended at now.
+	ReleaseSlug(ctx context.Context, id uint64, slug string, now time.Time) error
+	// RetireSlug turns the URL's current slug into a redirect lasting until redirectUntil.
+	RetireSlug(ctx context.Context, id uint64, redirectUntil time.Time) error
+	// LockOwner serializes concurrent changes to the user's URLs until the transaction ends.
+	LockOwner(ctx context.Context, userID string) error
+	ListExpiring(ctx context.Context, now, until time.Time) ([]domain.ExpiringPublicURL, error)
+	// ClaimExpiryReminder reports false when the reminder was already claimed or the expiry changed.
+	ClaimExpiryReminder(ctx context.Context, id uint64, expiresAt, now time.Time) (bool, error)
+	ReleaseExpiryReminder(ctx context.Context, id uint64) error
+	RecordView(ctx context.Context, view domain.PublicURLView) error
+	// MarkFirstViewed keeps an earlier first view when one was recorded already.
+	MarkFirstViewed(ctx context.Context, id uint64, viewedAt time.Time) error
+	// DailyViews omits days without views.
+	DailyViews(ctx context.Context, id uint64, since time.Time) ([]domain.PublicURLDailyViews, error)
+	RecentViews(ctx context.Context, id uint64, limit int) ([]domain.PublicURLView, error)
+	ListFirstViewed(ctx context.Context) ([]domain.FirstViewedPublicURL, error)
+	// ClaimFirstViewNotification reports false when the notification was already claimed.
+	ClaimFirstViewNotification(ctx context.Context, id uint64, now time.Time) (bool, error)
+	ReleaseFirstViewNotification(ctx context.Context, id uint64) error
+	// RecordEvent returns domain.ErrPublicURLEventRecorded when the event was recorded already.
+	RecordEvent(ctx context.Context, event domain.PublicURLEvent) error
+	// Events returns the URL's events oldest first.
+	Events(ctx context.Context, id uint64) ([]domain.PublicURLEvent, error)
+	// UnrecordedStarts returns the missing start events of active URLs whose window started
+	// at now.
+	UnrecordedStarts(ctx context.Context, now time.Time) ([]domain.PublicURLEvent, error)
+	// UnrecordedExpiries returns the missing expiry events of active URLs expired at now.
+	UnrecordedExpiries(ctx context.Context, now time.Time) ([]domain.PublicURLEvent, error)
+}
+
+// TransactionManager executes operations within a transaction boundary.
+type TransactionManager interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// Clock abstracts the source of current time for easier testing.
+type Clock interface {
+	Now() time.Time
+}
+
+// Mailer notifies owners about their public URLs.
+type Mailer interface {
+	SendPublicURLExpiryReminder(ctx context.Context, email user.Email, shareURL string, expiresAt time.Time) error
+	SendPublicURLFirstView(ctx context.Context, email user.Email, label, shareURL string, viewedAt time.Time) error
+}
+
+// ViewerTokens issues and checks the short-lived proofs that a viewer entered a link's
+// passphrase. A token must stop verifying once the link's passphrase changes.
+type ViewerTokens interface {
+	Issue(link domain.PublicURL) (string, time.Time)
+	Verify(link domain.PublicURL, token string) bool
+}
+
+// AttemptLimiter throttles passphrase guesses.
+type AttemptLimiter interface {
+	// Allow records an attempt for key and reports whether it is within the limit.
+	Allow(ctx context.Context, key string) (bool, error)
+}
+
+// VisitorHasher derives the hashes that tell visitors of a link apart within a UTC day.
+type VisitorHasher interface {
+	Hash(linkID uint64, day time.Time, ip, userAgent string) []byte
+}
+
+// GeoLocator finds the country of an IP address.
+type GeoLocator interface {
+	// Country returns an ISO 3166-1 alpha-2 code, or an empty string when unknown.
+	Country(ip netip.Addr) string
+}
+
+// QRCodeRenderer renders QR codes of share URLs.
+type QRCodeRenderer interface {
+	Render(content string, opts domain.QRCodeOptions) ([]byte, error)
+}
+
+// Config holds the settings of the public URL use case.
+type Config struct {
+	// PublisherBase is the URL of the publisher's public CV page that URL keys are appended
+	// to, e.g. https://techcv.example.com/cv.
+	PublisherBase string
+	// ReminderLead is how long before expiry the owner is reminded.
+	ReminderLead time.Duration
+	// SlugRedirectPeriod is how long a replaced slug keeps redirecting to its URL. No one
+	// else can claim the slug meanwhile.
+	SlugRedirectPeriod time.Duration
+}
+
+// CreateInput describes a new share link.
+type CreateInput struct {
+	Label     string
+	CVVariant string
+	// Passphrase protects the link when set.
+	Passphrase string
+	// NotifyFirstView emails the owner when the link is first viewed.
+	NotifyFirstView bool
+	ActivatesAt     *time.Time
+	ExpiresAt       *time.Time
+	// ReplaceExisting deactivates the user's other links in the same transaction.
+	ReplaceExisting bool
+}
+
+// UpdateInput changes the descriptive fields of a link; nil leaves a field unchanged.
+type UpdateInput struct {
+	Label           *string
+	CVVariant       *string
+	NotifyFirstView *bool
+}
+
+// ScheduleInput is the viewing window to set on a URL; nil leaves a side open.
+type ScheduleInput struct {
+	ActivatesAt *time.Time
+	ExpiresAt   *time.Time
+}
+
+// ViewerPass is the proof handed to a viewer who entered the passphrase of a link.
+type ViewerPass struct {
+	LinkID    uint64
+	Token     string
+	ExpiresAt time.Time
+}
+
+// MovedError is returned when a URL is looked up by a replaced slug that still redirects.
+type MovedError struct {
+	// Address is where the URL is shared now.
+	Address string
+}
+
+// Error implements the error interface.
+func (e *MovedError) Error() string {
+	return "public URL moved to " + e.Address
 }
 
 // Usecase orchestrates public URL management.
 type Usecase struct {
-	repo   Repository
-	keygen func() (string, error)
+	repo          Repository
+	tx            TransactionManager
+	clock         Clock
+	mailer        Mailer
+	viewerTokens  ViewerTokens
+	attempts      AttemptLimiter
+	visitors      VisitorHasher
+	geo           GeoLocator
+	qrCodes       QRCodeRenderer
+	keygen        func() (string, error)
+	publisherBase string
+	publisherHost string
+	reminderLead  time.Duration
+	slugRedirect  time.Duration
 }
 
 // New constructs a new Usecase instance.
-func New(repo Repository) *Usecase {
+func New(
+	repo Repository,
+	tx TransactionManager,
+	clock Clock,
+	mailer Mailer,
+	viewerTokens ViewerTokens,
+	attempts AttemptLimiter,
+	visitors VisitorHasher,
+	geo GeoLocator,
+	qrCodes QRCodeRenderer,
+	cfg Config,
+) *Usecase {
+	var publisherHost string
+	if base, err := url.Parse(cfg.PublisherBase); err == nil {
+		publisherHost = normalizeHost(base.Hostname())
+	}
+
 	return &Usecase{
-		repo:   repo,
-		keygen: generateKey,
+		repo:          repo,
+		tx:            tx,
+		clock:         clock,
+		mailer:        mailer,
+		viewerTokens:  viewerTokens,
+		attempts:      attempts,
+		visitors:      visitors,
+		geo:           geo,
+		qrCodes:       qrCodes,
+		keygen:        generateKey,
+		publisherBase: strings.TrimRight(cfg.PublisherBase, "/"),
+		publisherHost: publisherHost,
+		reminderLead:  cfg.ReminderLead,
+		slugRedirect:  cfg.SlugRedirectPeriod,
 	}
 }
 
-// List returns the stored public URLs.
-func (u *Usecase) List(ctx context.Context) ([]domain.PublicURL, error) {
-	urls, err := u.repo.List(ctx)
+// ShareURL returns the full shareable URL for a public URL address, see domain.PublicURL.Address.
+func (u *Usecase) ShareURL(address string) string {
+	return u.publisherBase + "/" + url.PathEscape(address)
+}
+
+// List returns the public URLs owned by the user.
+func (u *Usecase) List(ctx context.Context, userID string) ([]domain.PublicURL, error) {
+	urls, err := u.repo.List(ctx, userID)
 	if err != nil {
 		return nil, domain.NewInternal("public_url.list_failed", "failed to list public URLs", err)
 	}
 	return urls, nil
 }
 
-// GetActive returns the currently active public URL, if one exists.
-func (u *Usecase) GetActive(ctx context.Context) (*domain.PublicURL, error) {
-	url, err := u.repo.GetActive(ctx)
+// Get returns one of the user's public URLs.
+func (u *Usecase) Get(ctx context.Context, userID string, id uint64) (*domain.PublicURL, error) {
+	found, err := u.repo.Get(ctx, userID, id)
 	if err != nil {
-		return nil, domain.NewInternal("public_url.fetch_failed", "failed to fetch active public URL", err)
+		return nil, domain.NewInternal("public_url.fetch_failed", "failed to fetch public URL", err)
+	}
+	if found == nil {
+		return nil, domain.NewNotFound("public_url.not_found", "public URL not found")
 	}
-	return url, nil
+	return found, nil
 }
 
-// Generate deactivates the current URL (if any) and issues a new random key.
-func (u *Usecase) Generate(ctx context.Context) (*domain.PublicURL, error) {
-	key, err := u.keygen()
+// Create issues a new active link with a fresh random key. The user's other links stay
+// active unless ReplaceExisting is set, in which case they are deactivated atomically.
+func (u *Usecase) Create(ctx context.Context, userID string, in CreateInput) (*domain.PublicURL, error) {
+	label, err := normalizeLabel(in.Label)
+	if err != nil {
+		return nil, err
+	}
+	variant, err := normalizeCVVariant(in.CVVariant)
 	if err != nil {
-		return nil, domain.NewInternal("public_url.key_generation_failed", "failed to generate public URL key", err)
+		return nil, err
+	}
+	schedule := ScheduleInput{ActivatesAt: in.ActivatesAt, ExpiresAt: in.ExpiresAt}
+	if err := u.validateSchedule(schedule); err != nil {
+		return nil, err
 	}
+	passphraseHash, err := hashPassphrase(in.Passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	var created *domain.PublicURL
+	err = u.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := u.lockOwner(ctx, userID); err != nil {
+			return err
+		}
+
+		if in.ReplaceExisting {
+			if err := u.replaceAll(ctx, userID); err != nil {
+				return err
+			}
+		}
 
-	active, err := u.repo.GetActive(ctx)
+		id, err := u.create(ctx, domain.PublicURL{
+			UserID:          userID,
+			Label:           label,
+			CVVariant:       variant,
+			PassphraseHash:  passphraseHash,
+			NotifyFirstView: in.NotifyFirstView,
+			ActivatesAt:     schedule.ActivatesAt,
+			ExpiresAt:       schedule.ExpiresAt,
+		})
+		if err != nil {
+			return err
+		}
+
+		if created, err = u.reload(ctx, userID, id); err != nil {
+			return err
+		}
+		now := u.clock.Now()
+		return u.recordEvent(ctx, ownerEvent(userID, id, domain.PublicURLEventCreated, created.LiveAt(now), now))
+	})
 	if err != nil {
-		return nil, domain.NewInternal("public_url.fetch_failed", "failed to fetch active public URL", err)
+		return nil, err
 	}
 
-	if active != nil {
-		if deactivateErr := u.repo.Deactivate(ctx, active.ID); deactivateErr != nil {
-			return nil, domain.NewInternal("public_url.deactivate_failed", "failed to deactivate existing public URL", deactivateErr)
+	return created, nil
+}
+
+// Update changes the label, CV variant or first view notification of one of the user's links.
+func (u *Usecase) Update(ctx context.Context, userID string, id uint64, in UpdateInput) (*domain.PublicURL, error) {
+	var label, variant *string
+	if in.Label != nil {
+		normalized, err := normalizeLabel(*in.Label)
+		if err != nil {
+			return nil, err
 		}
+		label = &normalized
+	}
+	if in.CVVariant != nil {
+		normalized, err := normalizeCVVariant(*in.CVVariant)
+		if err != nil {
+			return nil, err
+		}
+		variant = &normalized
 	}
 
-	if _, createErr := u.repo.Create(ctx, key); createErr != nil {
-		return nil, domain.NewInternal("public_url.create_failed", "failed to create public URL", createErr)
+	return u.modify(ctx, userID, id, func(ctx context.Context, current domain.PublicURL) error {
+		if label != nil {
+			current.Label = *label
+		}
+		if variant != nil {
+			current.CVVariant = *variant
+		}
+		if in.NotifyFirstView != nil {
+			current.NotifyFirstView = *in.NotifyFirstView
+		}
+		if err := u.repo.UpdateDetails(ctx, userID, id, current.Label, current.CVVariant, current.NotifyFirstView); err != nil {
+			return domain.NewInternal("public_url.update_failed", "failed to update public URL", err)
+		}
+		return nil
+	})
+}
+
+// SetPassphrase protects one of the user's links with a passphrase, replacing any previous
+// one; an empty passphrase makes the link open again. Viewers unlocked with an earlier
+// passphrase must enter the new one.
+func (u *Usecase) SetPassphrase(ctx context.Context, userID string, id uint64, passphrase string) (*domain.PublicURL, error) {
+	passphraseHash, err := hashPassphrase(passphrase)
+	if err != nil {
+		return nil, err
 	}
 
-	created, err := u.repo.GetActive(ctx)
+	return u.modify(ctx, userID, id, func(ctx context.Context, _ domain.PublicURL) error {
+		if err := u.repo.SetPassphrase(ctx, userID, id, passphraseHash); err != nil {
+			return domain.NewInternal("public_url.update_failed", "failed to update public URL", err)
+		}
+		return nil
+	})
+}
+
+// SetSlug gives one of the user's links a vanity slug, replacing its current one; an empty
+// slug removes it. A replaced slug keeps redirecting to the link for the configured period.
+func (u *Usecase) SetSlug(ctx context.Context, userID string, id uint64, slug string) (*domain.PublicURL, error) {
+	var normalized string
+	if strings.TrimSpace(slug) != "" {
+		var err error
+		if normalized, err = normalizeSlug(slug); err != nil {
+			return nil, err
+		}
+	}
+
+	return u.modify(ctx, userID, id, func(ctx context.Context, current domain.PublicURL) error {
+		if current.Slug == normalized {
+			return nil
+		}
+
+		now := u.clock.Now()
+		if err := u.repo.RetireSlug(ctx, id, now.Add(u.slugRedirect)); err != nil {
+			return domain.NewInternal("public_url.slug_update_failed", "failed to update public URL slug", err)
+		}
+		if normalized == "" {
+			return nil
+		}
+
+		if err := u.repo.ReleaseSlug(ctx, id, normalized, now); err != nil {
+			return domain.NewInternal("public_url.slug_update_failed", "failed to update public URL slug", err)
+		}
+		err := u.repo.ClaimSlug(ctx, id, normalized)
+		if errors.Is(err, domain.ErrPublicURLSlugTaken) {
+			return invalidSlug("public_url.slug_taken", "slug is already taken")
+		}
+		if err != nil {
+			return domain.NewInternal("public_url.slug_update_failed", "failed to update public URL slug", err)
+		}
+		return nil
+	})
+}
+
+// Activate turns one of the user's links back on without touching the others. Links taken
+// down by an admin stay off until the takedown is lifted.
+func (u *Usecase) Activate(ctx context.Context, userID string, id uint64) (*domain.PublicURL, error) {
+	return u.setActive(ctx, userID, id, true)
+}
+
+// Deactivate revokes one of the user's links without touching the others.
+func (u *Usecase) Deactivate(ctx context.Context, userID string, id uint64) (*domain.PublicURL, error) {
+	return u.setActive(ctx, userID, id, false)
+}
+
+func (u *Usecase) setActive(ctx context.Context, userID string, id uint64, active bool) (*domain.PublicURL, error) {
+	return u.modify(ctx, userID, id, func(ctx context.Context, current domain.PublicURL) error {
+		if current.IsActive == active {
+			return nil
+		}
+		if active {
+			events, err := u.events(ctx, id)
+			if err != nil {
+				return err
+			}
+			if takenDown(events) {
+				return domain.NewValidation("public_url.taken_down", "public URL was taken down by an admin")
+			}
+		}
+		now := u.clock.Now()
+		if err := u.recordWindow(ctx, current, now); err != nil {
+			return err
+		}
+		if err := u.repo.SetActive(ctx, userID, id, active); err != nil {
+			return domain.NewInternal("public_url.update_failed", "failed to update public URL", err)
+		}
+		reason := domain.PublicURLEventRevoked
+		if active {
+			reason = domain.PublicURLEventActivated
+		}
+		current.IsActive = active
+		return u.recordEvent(ctx, ownerEvent(userID, id, reason, current.LiveAt(now), now))
+	})
+}
+
+// Resolve returns the URL behind a key or slug when it can be viewed now. Inactive, scheduled
+// and expired URLs are reported as not found so that their existence is not disclosed. A
+// replaced slug that still redirects yields a *MovedError.
+func (u *Usecase) Resolve(ctx context.Context, address string) (*domain.PublicURL, error) {
+	now := u.clock.Now()
+	found, retired, err := u.find(ctx, address, now)
 	if err != nil {
-		return nil, domain.NewInternal("public_url.fetch_failed", "failed to fetch active public URL", err)
+		return nil, domain.NewInternal("public_url.fetch_failed", "failed to fetch public URL", err)
+	}
+	if found == nil || !found.LiveAt(now) {
+		return nil, domain.NewNotFound("public_url.not_found", "public URL not found")
+	}
+	if retired {
+		return nil, &MovedError{Address: found.Address()}
 	}
+	return found, nil
+}
 
-	return created, nil
+// View returns the link behind a key or slug for a viewer and records the view. Protected
+// links additionally require one of the viewer's tokens to have been issued by Unlock for
+// the link.
+func (u *Usecase) View(ctx context.Context, address string, viewerTokens []string, visit Visit) (*domain.PublicURL, error) {
+	found, err := u.Resolve(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	if found.Protected() && !slices.ContainsFunc(viewerTokens, func(token string) bool {
+		return token != "" && u.viewerTokens.Verify(*found, token)
+	}) {
+		return nil, domain.NewUnauthorized("public_url.passphrase_required", "passphrase required")
+	}
+	if err := u.recordView(ctx, *found, visit); err != nil {
+		return nil, err
+	}
+	return found, nil
+}
+
+// Unlock checks a viewer's passphrase and returns the pass that lets them view the link.
+// Attempts are rate limited per link and client address, so that guessing from one address
+// does not lock other viewers out.
+func (u *Usecase) Unlock(ctx context.Context, address, passphrase, clientIP string) (ViewerPass, error) {
+	found, err := u.Resolve(ctx, address)
+	if err != nil {
+		return ViewerPass{}, err
+	}
+	if found.Protected() {
+		allowed, err := u.attempts.Allow(ctx, fmt.Sprintf("public_url:%d:%s", found.ID, clientIP))
+		if err != nil {
+			return ViewerPass{}, domain.NewInternal("public_url.unlock_failed", "failed to check passphrase attempts", err)
+		}
+		if !allowed {
+			return ViewerPass{}, domain.NewTooManyRequests("public_url.too_many_attempts", "too many passphrase attempts, try again later")
+		}
+		if !found.PassphraseMatches(passphrase) {
+			return ViewerPass{}, domain.NewUnauthorized("public_url.passphrase_mismatch", "passphrase does not match")
+		}
+	}
+
+	token, expiresAt := u.viewerTokens.Issue(*found)
+	return ViewerPass{LinkID: found.ID, Token: token, ExpiresAt: expiresAt}, nil
+}
+
+// Schedule sets or extends the viewing window of one of the user's links. Changing the
+// expiry re-arms the expiry reminder. A change to an active link is recorded when it moves
+// the start of the window or changes whether the link can be viewed now; a link that goes
+// live later is recorded by the sweep when its new window starts.
+func (u *Usecase) Schedule(ctx context.Context, userID string, id uint64, in ScheduleInput) (*domain.PublicURL, error) {
+	if err := u.validateSchedule(in); err != nil {
+		return nil, err
+	}
+
+	return u.modify(ctx, userID, id, func(ctx context.Context, current domain.PublicURL) error {
+		now := u.clock.Now()
+		if err := u.recordWindow(ctx, current, now); err != nil {
+			return err
+		}
+		if err := u.repo.SetSchedule(ctx, userID, id, in.ActivatesAt, in.ExpiresAt); err != nil {
+			return domain.NewInternal("public_url.schedule_failed", "failed to update public URL schedule", err)
+		}
+		if !current.IsActive {
+			return nil
+		}
+
+		wasLive, moved := current.LiveAt(now), !sameTime(current.ActivatesAt, in.ActivatesAt)
+		current.ActivatesAt, current.ExpiresAt = in.ActivatesAt, in.ExpiresAt
+		live := current.LiveAt(now)
+		if live == wasLive && !moved {
+			return nil
+		}
+		return u.recordEvent(ctx, ownerEvent(userID, id, domain.PublicURLEventRescheduled, live, now))
+	})
+}
+
+// sameTime reports whether two optional instants are both unset or equal.
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+func (u *Usecase) validateSchedule(in ScheduleInput) error {
+	if in.ExpiresAt == nil {
+		return nil
+	}
+	if !in.ExpiresAt.After(u.clock.Now()) {
+		detail := domain.ErrorDetail{Field: "expires_at", Code: "public_url.expiry_not_in_future", Message: "expires_at must be in the future"}
+		return domain.NewValidation("public_url.invalid_schedule", "invalid public URL schedule").WithDetails(detail)
+	}
+	if in.ActivatesAt != nil && !in.ExpiresAt.After(*in.ActivatesAt) {
+		detail := domain.ErrorDetail{Field: "expires_at", Code: "public_url.expiry_before_activation", Message: "expires_at must be after activates_at"}
+		return domain.NewValidation("public_url.invalid_schedule", "invalid public URL schedule").WithDetails(detail)
+	}
+	return nil
+}
+
+// SendExpiryReminders emails the owners of URLs that expire within the reminder lead and
+// returns how many reminders were sent. Each expiry is reminded once, even when several
+// instances run the sweep; failed deliveries are retried by the next sweep.
+func (u *Usecase) SendExpiryReminders(ctx context.Context) (int, error) {
+	now := u.clock.Now()
+	expiring, err := u.repo.ListExpiring(ctx, now, now.Add(u.reminderLead))
+	if err != nil {
+		return 0, domain.NewInternal("public_url.fetch_failed", "failed to list expiring public URLs", err)
+	}
+
+	sent := 0
+	var errs []error
+	for _, e := range expiring {
+		ok, err := u.sendExpiryReminder(ctx, e, now)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if ok {
+			sent++
+		}
+	}
+	if len(errs) > 0 {
+		return sent, domain.NewInternal("public_url.reminder_failed", "failed to send public URL expiry reminders", errors.Join(errs...))
+	}
+	return sent, nil
+}
+
+func (u *Usecase) sendExpiryReminder(ctx context.Context, e domain.ExpiringPublicURL, now time.Time) (bool, error) {
+	claimed, err := u.repo.ClaimExpiryReminder(ctx, e.ID, *e.ExpiresAt, now)
+	if err != nil {
+		return false, fmt.Errorf("claim reminder for public URL %d: %w", e.ID, err)
+	}
+	if !claimed {
+		return false, nil
+	}
+
+	email, err := user.NewEmail(e.OwnerEmail)
+	if err == nil {
+		err = u.mailer.SendPublicURLExpiryReminder(ctx, email, u.ShareURL(e.Address()), *e.ExpiresAt)
+	}
+	if err != nil {
+		if releaseErr := u.repo.ReleaseExpiryReminder(ctx, e.ID); releaseErr != nil {
+			err = errors.Join(err, releaseErr)
+		}
+		return false, fmt.Errorf("send reminder for public URL %d: %w", e.ID, err)
+	}
+	return true, nil
+}
+
+// find looks a URL up by its key, or by a current or still redirecting slug.
+func (u *Usecase) find(ctx context.Context, address string, now time.Time) (*domain.PublicURL, bool, error) {
+	found, err := u.repo.GetByKey(ctx, address)
+	if err != nil || found != nil {
+		return found, false, err
+	}
+	return u.repo.GetBySlug(ctx, strings.ToLower(address), now)
+}
+
+// modify applies fn to one of the user's links under the owner lock and returns the link
+// as stored afterwards.
+func (u *Usecase) modify(
+	ctx context.Context,
+	userID string,
+	id uint64,
+	fn func(ctx context.Context, current domain.PublicURL) error,
+) (*domain.PublicURL, error) {
+	var updated *domain.PublicURL
+	err := u.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := u.lockOwner(ctx, userID); err != nil {
+			return err
+		}
+
+		current, err := u.Get(ctx, userID, id)
+		if err != nil {
+			return err
+		}
+		if err := fn(ctx, *current); err != nil {
+			return err
+		}
+
+		updated, err = u.reload(ctx, userID, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+func (u *Usecase) lockOwner(ctx context.Context, userID string) error {
+	if err := u.repo.LockOwner(ctx, userID); err != nil {
+		return domain.NewInternal("public_url.lock_failed", "failed to lock public URLs", err)
+	}
+	return nil
+}
+
+// reload fetches a link that was just written in the current transaction.
+func (u *Usecase) reload(ctx context.Context, userID string, id uint64) (*domain.PublicURL, error) {
+	found, err := u.repo.Get(ctx, userID, id)
+	if err != nil {
+		return nil, domain.NewInternal("public_url.fetch_failed", "failed to fetch public URL", err)
+	}
+	if found == nil {
+		return nil, domain.NewInternal("public_url.fetch_failed", "failed to fetch public URL", fmt.Errorf("public URL %d not found after write", id))
+	}
+	return found, nil
+}
+
+// create inserts the link with a fresh key, drawing another key when one is already taken.
+func (u *Usecase) create(ctx context.Context, link domain.PublicURL) (uint64, error) {
+	for attempt := 1; ; attempt++ {
+		key, err := u.keygen()
+		if err != nil {
+			return 0, domain.NewInternal("public_url.key_generation_failed", "failed to generate public URL key", err)
+		}
+
+		link.URLKey = key
+		id, err := u.repo.Create(ctx, link)
+		if errors.Is(err, domain.ErrPublicURLKeyConflict) && attempt < maxKeyAttempts {
+			continue
+		}
+		if err != nil {
+			return 0, domain.NewInternal("public_url.create_failed", "failed to create public URL", err)
+		}
+		return id, nil
+	}
+}
+
+func normalizeLabel(raw string) (string, error) {
+	label := strings.TrimSpace(raw)
+	if label == "" {
+		detail := domain.ErrorDetail{Field: "label", Code: "public_url.label_required", Message: "label is required"}
+		return "", domain.NewValidation("public_url.invalid_label", "invalid public URL label").WithDetails(detail)
+	}
+	if utf8.RuneCountInString(label) > maxLabelLength {
+		detail := domain.ErrorDetail{Field: "label", Code: "public_url.label_too_long", Message: "label must be at most 100 characters"}
+		return "", domain.NewValidation("public_url.invalid_label", "invalid public URL label").WithDetails(detail)
+	}
+	return label, nil
+}
+
+func normalizeCVVariant(raw string) (string, error) {
+	variant := strings.TrimSpace(raw)
+	if utf8.RuneCountInString(variant) > maxCVVariantLength {
+		detail := domain.ErrorDetail{Field: "cv_variant", Code: "public_url.cv_variant_too_long", Message: "cv_variant must be at most 64 characters"}
+		return "", domain.NewValidation("public_url.invalid_cv_variant", "invalid CV variant").WithDetails(detail)
+	}
+	return variant, nil
+}
+
+// hashPassphrase validates a new passphrase and returns its hash; an empty passphrase yields
+// an empty hash.
+func hashPassphrase(passphrase string) (string, error) {
+	if passphrase == "" {
+		return "", nil
+	}
+	if utf8.RuneCountInString(passphrase) < minPassphraseLength {
+		detail := domain.ErrorDetail{Field: "passphrase", Code: "public_url.passphrase_too_short", Message: "passphrase must be at least 8 characters"}
+		return "", domain.NewValidation("public_url.invalid_passphrase", "invalid passphrase").WithDetails(detail)
+	}
+	if len(passphrase) > maxPassphraseBytes {
+		detail := domain.ErrorDetail{Field: "passphrase", Code: "public_url.passphrase_too_long", Message: "passphrase must be at most 72 bytes"}
+		return "", domain.NewValidation("public_url.invalid_passphrase", "invalid passphrase").WithDetails(detail)
+	}
+
+	hashed, err := domain.HashPublicURLPassphrase(passphrase)
+	if err != nil {
+		return "", domain.NewInternal("public_url.passphrase_hash_failed", "failed to hash passphrase", err)
+	}
+	return hashed, nil
 }
 
 func generateKey() (string, error) {
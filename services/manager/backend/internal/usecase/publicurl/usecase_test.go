@@ -3,62 +3,484 @@ package publicurl
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net/netip"
+	"sort"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/sky0621/techcv/manager/backend/internal/domain"
+	"github.com/sky0621/techcv/manager/backend/internal/domain/user"
 )
 
+const ownerID = "0190c8a1-b2c3-7d4e-8f00-112233445566"
+
+// mockRepository keeps public URLs in memory and records how it was called.
 type mockRepository struct {
-	listResult         []domain.PublicURL
-	listErr            error
-	getActiveResponses []*domain.PublicURL
-	getActiveErr       error
-	getActiveCalls     int
-	createdKeys        []string
-	createErr          error
-	deactivatedIDs     []uint64
-	deactivateErr      error
-}
-
-func (m *mockRepository) Create(ctx context.Context, urlKey string) (uint64, error) {
+	userIDs     []string
+	listResult  []domain.PublicURL
+	listErr     error
+	createdKeys []string
+	createErrs  []error
+	createErr   error
+	stored      map[uint64]*domain.PublicURL
+	locks       int
+	schedules   []ScheduleInput
+	expiring    []domain.ExpiringPublicURL
+	claimed     map[uint64]bool
+	released    []uint64
+	slugs       map[string]*mockSlug
+	views       []domain.PublicURLView
+	notified    map[uint64]bool
+	events      []domain.PublicURLEvent
+}
+
+// mockSlug is a slug reservation; until is set once the slug has been replaced.
+type mockSlug struct {
+	id    uint64
+	until *time.Time
+}
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c fakeClock) Now() time.Time {
+	return c.now
+}
+
+type sentReminder struct {
+	email     string
+	shareURL  string
+	expiresAt time.Time
+}
+
+type sentFirstView struct {
+	email    string
+	label    string
+	shareURL string
+	viewedAt time.Time
+}
+
+type fakeMailer struct {
+	sent       []sentReminder
+	firstViews []sentFirstView
+	err        error
+}
+
+func (m *fakeMailer) SendPublicURLExpiryReminder(ctx context.Context, email user.Email, shareURL string, expiresAt time.Time) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.sent = append(m.sent, sentReminder{email: email.String(), shareURL: shareURL, expiresAt: expiresAt})
+	return nil
+}
+
+func (m *fakeMailer) SendPublicURLFirstView(ctx context.Context, email user.Email, label, shareURL string, viewedAt time.Time) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.firstViews = append(m.firstViews, sentFirstView{email: email.String(), label: label, shareURL: shareURL, viewedAt: viewedAt})
+	return nil
+}
+
+// fakeViewerTokens binds tokens to the link and its passphrase hash like the real signer.
+type fakeViewerTokens struct{}
+
+func (fakeViewerTokens) Issue(link domain.PublicURL) (string, time.Time) {
+	return fmt.Sprintf("%d:%s", link.ID, link.PassphraseHash), testNow.Add(time.Hour)
+}
+
+func (fakeViewerTokens) Verify(link domain.PublicURL, token string) bool {
+	return token == fmt.Sprintf("%d:%s", link.ID, link.PassphraseHash)
+}
+
+type fakeLimiter struct {
+	remaining int
+	keys      []string
+}
+
+func (l *fakeLimiter) Allow(_ context.Context, key string) (bool, error) {
+	l.keys = append(l.keys, key)
+	if l.remaining == 0 {
+		return false, nil
+	}
+	l.remaining--
+	return true, nil
+}
+
+// fakeVisitorHasher keeps its input readable so tests can tell visitors apart.
+type fakeVisitorHasher struct{}
+
+func (fakeVisitorHasher) Hash(linkID uint64, day time.Time, ip, userAgent string) []byte {
+	return []byte(fmt.Sprintf("%d|%s|%s|%s", linkID, day.Format(time.DateOnly), ip, userAgent))
+}
+
+type fakeGeoLocator map[string]string
+
+func (g fakeGeoLocator) Country(ip netip.Addr) string {
+	return g[ip.String()]
+}
+
+type fakeQRCodeRenderer struct {
+	contents []string
+	opts     []domain.QRCodeOptions
+}
+
+func (r *fakeQRCodeRenderer) Render(content string, opts domain.QRCodeOptions) ([]byte, error) {
+	r.contents = append(r.contents, content)
+	r.opts = append(r.opts, opts)
+	return []byte("image of " + content), nil
+}
+
+var testNow = time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+
+func newTestUsecase(repo *mockRepository, mailer *fakeMailer) *Usecase {
+	if mailer == nil {
+		mailer = &fakeMailer{}
+	}
+	return New(
+		repo,
+		&fakeTransactionManager{},
+		fakeClock{now: testNow},
+		mailer,
+		fakeViewerTokens{},
+		&fakeLimiter{remaining: 10},
+		fakeVisitorHasher{},
+		fakeGeoLocator{"192.0.2.1": "JP"},
+		&fakeQRCodeRenderer{},
+		Config{
+			PublisherBase:      "https://cv.example.com/cv/",
+			ReminderLead:       24 * time.Hour,
+			SlugRedirectPeriod: 30 * 24 * time.Hour,
+		},
+	)
+}
+
+type fakeTransactionManager struct {
+	calls int
+}
+
+func (f *fakeTransactionManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	f.calls++
+	return fn(ctx)
+}
+
+func (m *mockRepository) Create(ctx context.Context, u domain.PublicURL) (uint64, error) {
+	m.userIDs = append(m.userIDs, u.UserID)
+	if len(m.createErrs) > 0 {
+		err := m.createErrs[0]
+		m.createErrs = m.createErrs[1:]
+		return 0, err
+	}
 	if m.createErr != nil {
 		return 0, m.createErr
 	}
-	m.createdKeys = append(m.createdKeys, urlKey)
-	return uint64(len(m.createdKeys)), nil
+	m.createdKeys = append(m.createdKeys, u.URLKey)
+	u.ID = uint64(100 + len(m.createdKeys))
+	u.IsActive = true
+	m.put(u)
+	return u.ID, nil
 }
 
-func (m *mockRepository) GetActive(ctx context.Context) (*domain.PublicURL, error) {
-	if m.getActiveErr != nil {
-		return nil, m.getActiveErr
+func (m *mockRepository) put(u domain.PublicURL) {
+	if m.stored == nil {
+		m.stored = make(map[uint64]*domain.PublicURL)
 	}
-	if m.getActiveCalls >= len(m.getActiveResponses) {
+	m.stored[u.ID] = &u
+}
+
+func (m *mockRepository) Get(ctx context.Context, userID string, id uint64) (*domain.PublicURL, error) {
+	m.userIDs = append(m.userIDs, userID)
+	u, ok := m.stored[id]
+	if !ok || u.UserID != userID {
 		return nil, nil
 	}
-	result := m.getActiveResponses[m.getActiveCalls]
-	m.getActiveCalls++
-	if result == nil {
-		return nil, nil
+	copied := *u
+	return &copied, nil
+}
+
+func (m *mockRepository) GetByKey(ctx context.Context, urlKey string) (*domain.PublicURL, error) {
+	for _, u := range m.stored {
+		if u.URLKey == urlKey {
+			return u, nil
+		}
 	}
-	return result, nil
+	return nil, nil
 }
 
-func (m *mockRepository) List(ctx context.Context) ([]domain.PublicURL, error) {
+func (m *mockRepository) List(ctx context.Context, userID string) ([]domain.PublicURL, error) {
+	m.userIDs = append(m.userIDs, userID)
 	if m.listErr != nil {
 		return nil, m.listErr
 	}
-	return m.listResult, nil
+	if m.listResult != nil {
+		return m.listResult, nil
+	}
+	var result []domain.PublicURL
+	for _, u := range m.stored {
+		if u.UserID == userID {
+			result = append(result, *u)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockRepository) SetActive(ctx context.Context, userID string, id uint64, active bool) error {
+	m.userIDs = append(m.userIDs, userID)
+	m.stored[id].IsActive = active
+	return nil
+}
+
+func (m *mockRepository) DeactivateAll(ctx context.Context, userID string) error {
+	m.userIDs = append(m.userIDs, userID)
+	for _, u := range m.stored {
+		if u.UserID == userID {
+			u.IsActive = false
+		}
+	}
+	return nil
+}
+
+func (m *mockRepository) UpdateDetails(ctx context.Context, userID string, id uint64, label, cvVariant string, notifyFirstView bool) error {
+	m.userIDs = append(m.userIDs, userID)
+	m.stored[id].Label, m.stored[id].CVVariant, m.stored[id].NotifyFirstView = label, cvVariant, notifyFirstView
+	return nil
+}
+
+func (m *mockRepository) SetPassphrase(ctx context.Context, userID string, id uint64, passphraseHash string) error {
+	m.userIDs = append(m.userIDs, userID)
+	m.stored[id].PassphraseHash = passphraseHash
+	return nil
+}
+
+func (m *mockRepository) SetSchedule(ctx context.Context, userID string, id uint64, activatesAt, expiresAt *time.Time) error {
+	m.userIDs = append(m.userIDs, userID)
+	m.schedules = append(m.schedules, ScheduleInput{ActivatesAt: activatesAt, ExpiresAt: expiresAt})
+	m.stored[id].ActivatesAt, m.stored[id].ExpiresAt = activatesAt, expiresAt
+	return nil
+}
+
+func (m *mockRepository) GetBySlug(ctx context.Context, slug string, now time.Time) (*domain.PublicURL, bool, error) {
+	entry, ok := m.slugs[slug]
+	if !ok || (entry.until != nil && !entry.until.After(now)) {
+		return nil, false, nil
+	}
+	copied := *m.stored[entry.id]
+	return &copied, entry.until != nil, nil
+}
+
+func (m *mockRepository) ClaimSlug(ctx context.Context, id uint64, slug string) error {
+	if m.slugs == nil {
+		m.slugs = make(map[string]*mockSlug)
+	}
+	if _, ok := m.slugs[slug]; ok {
+		return domain.ErrPublicURLSlugTaken
+	}
+	m.slugs[slug] = &mockSlug{id: id}
+	m.stored[id].Slug = slug
+	return nil
+}
+
+func (m *mockRepository) ReleaseSlug(ctx context.Context, id uint64, slug string, now time.Time) error {
+	if entry, ok := m.slugs[slug]; ok && (entry.id == id || (entry.until != nil && !entry.until.After(now))) {
+		delete(m.slugs, slug)
+	}
+	return nil
+}
+
+func (m *mockRepository) RetireSlug(ctx context.Context, id uint64, redirectUntil time.Time) error {
+	for _, entry := range m.slugs {
+		if entry.id == id && entry.until == nil {
+			until := redirectUntil
+			entry.until = &until
+		}
+	}
+	m.stored[id].Slug = ""
+	return nil
+}
+
+func (m *mockRepository) LockOwner(ctx context.Context, userID string) error {
+	m.userIDs = append(m.userIDs, userID)
+	m.locks++
+	return nil
+}
+
+func (m *mockRepository) ListExpiring(ctx context.Context, now, until time.Time) ([]domain.ExpiringPublicURL, error) {
+	return m.expiring, nil
+}
+
+func (m *mockRepository) ClaimExpiryReminder(ctx context.Context, id uint64, expiresAt, now time.Time) (bool, error) {
+	if m.claimed == nil {
+		m.claimed = make(map[uint64]bool)
+	}
+	if m.claimed[id] {
+		return false, nil
+	}
+	m.claimed[id] = true
+	return true, nil
+}
+
+func (m *mockRepository) ReleaseExpiryReminder(ctx context.Context, id uint64) error {
+	m.released = append(m.released, id)
+	delete(m.claimed, id)
+	return nil
+}
+
+func (m *mockRepository) RecordView(ctx context.Context, view domain.PublicURLView) error {
+	m.views = append(m.views, view)
+	return nil
+}
+
+func (m *mockRepository) MarkFirstViewed(ctx context.Context, id uint64, viewedAt time.Time) error {
+	if m.stored[id].FirstViewedAt == nil {
+		m.stored[id].FirstViewedAt = &viewedAt
+	}
+	return nil
+}
+
+func (m *mockRepository) DailyViews(ctx context.Context, id uint64, since time.Time) ([]domain.PublicURLDailyViews, error) {
+	var result []domain.PublicURLDailyViews
+	visitors := make(map[string]map[string]bool)
+	for _, v := range m.views {
+		if v.PublicURLID != id || v.ViewedAt.Before(since) || v.UserAgentClass == domain.UserAgentBot {
+			continue
+		}
+		day := v.ViewedAt.UTC().Truncate(24 * time.Hour)
+		if len(result) == 0 || !result[len(result)-1].Day.Equal(day) {
+			result = append(result, domain.PublicURLDailyViews{Day: day})
+			visitors[day.String()] = make(map[string]bool)
+		}
+		visitors[day.String()][string(v.VisitorHash)] = true
+		result[len(result)-1].Views++
+		result[len(result)-1].UniqueVisitors = len(visitors[day.String()])
+	}
+	return result, nil
+}
+
+func (m *mockRepository) RecentViews(ctx context.Context, id uint64, limit int) ([]domain.PublicURLView, error) {
+	var result []domain.PublicURLView
+	for i := len(m.views) - 1; i >= 0 && len(result) < limit; i-- {
+		if m.views[i].PublicURLID == id {
+			result = append(result, m.views[i])
+		}
+	}
+	return result, nil
+}
+
+func (m *mockRepository) RecordEvent(ctx context.Context, event domain.PublicURLEvent) error {
+	if m.recorded(event) {
+		return domain.ErrPublicURLEventRecorded
+	}
+	event.ID = uint64(len(m.events) + 1)
+	m.events = append(m.events, event)
+	return nil
+}
+
+func (m *mockRepository) Events(ctx context.Context, id uint64) ([]domain.PublicURLEvent, error) {
+	var result []domain.PublicURLEvent
+	for _, e := range m.events {
+		if e.PublicURLID == id {
+			result = append(result, e)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool { return result[i].OccurredAt.Before(result[j].OccurredAt) })
+	return result, nil
+}
+
+func (m *mockRepository) UnrecordedStarts(ctx context.Context, now time.Time) ([]domain.PublicURLEvent, error) {
+	var result []domain.PublicURLEvent
+	for _, u := range m.stored {
+		if !u.IsActive || u.ActivatesAt == nil || u.ActivatesAt.After(now) {
+			continue
+		}
+		changed := false
+		for _, e := range m.events {
+			if e.PublicURLID == u.ID && e.Reason != domain.PublicURLEventExpired && !e.OccurredAt.Before(*u.ActivatesAt) {
+				changed = true
+			}
+		}
+		if !changed {
+			result = append(result, domain.PublicURLEvent{
+				PublicURLID: u.ID,
+				Reason:      domain.PublicURLEventStarted,
+				Active:      true,
+				Actor:       domain.PublicURLActorSystem,
+				OccurredAt:  *u.ActivatesAt,
+			})
+		}
+	}
+	return result, nil
 }
 
-func (m *mockRepository) Deactivate(ctx context.Context, id uint64) error {
-	if m.deactivateErr != nil {
-		return m.deactivateErr
+func (m *mockRepository) UnrecordedExpiries(ctx context.Context, now time.Time) ([]domain.PublicURLEvent, error) {
+	var result []domain.PublicURLEvent
+	for _, u := range m.stored {
+		if !u.IsActive || u.ExpiresAt == nil || u.ExpiresAt.After(now) {
+			continue
+		}
+		event := domain.PublicURLEvent{
+			PublicURLID: u.ID,
+			Reason:      domain.PublicURLEventExpired,
+			Actor:       domain.PublicURLActorSystem,
+			OccurredAt:  *u.ExpiresAt,
+		}
+		if !m.recorded(event) {
+			result = append(result, event)
+		}
 	}
-	m.deactivatedIDs = append(m.deactivatedIDs, id)
+	return result, nil
+}
+
+func (m *mockRepository) recorded(event domain.PublicURLEvent) bool {
+	for _, e := range m.events {
+		if e.PublicURLID == event.PublicURLID && e.Reason == event.Reason && e.OccurredAt.Equal(event.OccurredAt) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *mockRepository) ListFirstViewed(ctx context.Context) ([]domain.FirstViewedPublicURL, error) {
+	var result []domain.FirstViewedPublicURL
+	for _, u := range m.stored {
+		if u.NotifyFirstView && u.FirstViewedAt != nil && !m.notified[u.ID] {
+			result = append(result, domain.FirstViewedPublicURL{PublicURL: *u, OwnerEmail: "owner@example.com"})
+		}
+	}
+	return result, nil
+}
+
+func (m *mockRepository) ClaimFirstViewNotification(ctx context.Context, id uint64, now time.Time) (bool, error) {
+	if m.notified == nil {
+		m.notified = make(map[uint64]bool)
+	}
+	if m.notified[id] {
+		return false, nil
+	}
+	m.notified[id] = true
+	return true, nil
+}
+
+func (m *mockRepository) ReleaseFirstViewNotification(ctx context.Context, id uint64) error {
+	m.released = append(m.released, id)
+	delete(m.notified, id)
 	return nil
 }
 
+func assertScopedToOwner(t *testing.T, repo *mockRepository) {
+	t.Helper()
+	if len(repo.userIDs) == 0 {
+		t.Fatalf("expected repository calls")
+	}
+	for _, id := range repo.userIDs {
+		if id != ownerID {
+			t.Fatalf("expected every repository call to be scoped to %s, got %+v", ownerID, repo.userIDs)
+		}
+	}
+}
+
 func TestList(t *testing.T) {
 	expected := []domain.PublicURL{
 		{ID: 1, URLKey: "first"},
@@ -69,9 +491,9 @@ func TestList(t *testing.T) {
 		listResult: expected,
 	}
 
-	usecase := New(repo)
+	usecase := newTestUsecase(repo, nil)
 
-	results, err := usecase.List(context.Background())
+	results, err := usecase.List(context.Background(), ownerID)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -87,66 +509,86 @@ func TestList(t *testing.T) {
 	}
 }
 
-func TestGenerateWithExistingActive(t *testing.T) {
-	now := time.Now()
-	existing := &domain.PublicURL{
-		ID:        1,
-		URLKey:    "existing",
-		IsActive:  true,
-		CreatedAt: now.Add(-time.Hour),
-		UpdatedAt: now.Add(-time.Minute),
-	}
-	created := &domain.PublicURL{
-		ID:        2,
-		URLKey:    "generated-key",
-		IsActive:  true,
-		CreatedAt: now,
-		UpdatedAt: now,
-	}
-
-	repo := &mockRepository{
-		getActiveResponses: []*domain.PublicURL{existing, created},
-	}
+func TestCreateKeepsOtherLinksActive(t *testing.T) {
+	repo := &mockRepository{}
+	repo.put(domain.PublicURL{ID: 1, UserID: ownerID, URLKey: "company-a", Label: "Company A", IsActive: true})
+	tx := &fakeTransactionManager{}
 
-	usecase := New(repo)
+	usecase := newTestUsecase(repo, nil)
+	usecase.tx = tx
 	usecase.keygen = func() (string, error) {
 		return "generated-key", nil
 	}
 
-	result, err := usecase.Generate(context.Background())
+	result, err := usecase.Create(context.Background(), ownerID, CreateInput{Label: "  Agent B ", CVVariant: "backend"})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
-	if result == nil {
-		t.Fatalf("expected result, got nil")
+	if result.ID != 101 || result.URLKey != "generated-key" || result.Label != "Agent B" || result.CVVariant != "backend" {
+		t.Fatalf("expected the inserted row, got %+v", result)
+	}
+	if !repo.stored[1].IsActive {
+		t.Fatalf("expected the existing link to stay active")
 	}
+	if tx.calls != 1 || repo.locks != 1 {
+		t.Fatalf("expected one locked transaction, got %d transactions and %d locks", tx.calls, repo.locks)
+	}
+
+	assertScopedToOwner(t, repo)
+}
 
-	if result.URLKey != created.URLKey {
-		t.Fatalf("unexpected URL key: got %q, want %q", result.URLKey, created.URLKey)
+func TestCreateReplacingExisting(t *testing.T) {
+	repo := &mockRepository{}
+	repo.put(domain.PublicURL{ID: 1, UserID: ownerID, URLKey: "company-a", Label: "Company A", IsActive: true})
+
+	usecase := newTestUsecase(repo, nil)
+
+	result, err := usecase.Create(context.Background(), ownerID, CreateInput{Label: "Company B", ReplaceExisting: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if repo.stored[1].IsActive {
+		t.Fatalf("expected the existing link to be deactivated")
 	}
+	if !result.IsActive {
+		t.Fatalf("expected the new link to be active, got %+v", result)
+	}
+}
 
-	if len(repo.deactivatedIDs) != 1 || repo.deactivatedIDs[0] != existing.ID {
-		t.Fatalf("expected deactivate to be called with %d, got %+v", existing.ID, repo.deactivatedIDs)
+func TestCreateRejectsInvalidLabel(t *testing.T) {
+	cases := map[string]string{
+		"   ":                    "public_url.label_required",
+		strings.Repeat("あ", 101): "public_url.label_too_long",
 	}
+	for label, code := range cases {
+		repo := &mockRepository{}
+		usecase := newTestUsecase(repo, nil)
 
-	if len(repo.createdKeys) != 1 || repo.createdKeys[0] != "generated-key" {
-		t.Fatalf("expected create to be called with generated-key, got %+v", repo.createdKeys)
+		_, err := usecase.Create(context.Background(), ownerID, CreateInput{Label: label})
+
+		var appErr *domain.AppError
+		if !errors.As(err, &appErr) || len(appErr.Details) != 1 || appErr.Details[0].Code != code {
+			t.Fatalf("expected %s, got %v", code, err)
+		}
+		if len(repo.createdKeys) != 0 {
+			t.Fatalf("expected no insert, got %+v", repo.createdKeys)
+		}
 	}
 }
 
-func TestGenerateCreateError(t *testing.T) {
+func TestCreateError(t *testing.T) {
 	repo := &mockRepository{
-		getActiveResponses: []*domain.PublicURL{nil},
-		createErr:          errors.New("insert failed"),
+		createErr: errors.New("insert failed"),
 	}
 
-	usecase := New(repo)
+	usecase := newTestUsecase(repo, nil)
 	usecase.keygen = func() (string, error) {
 		return "key", nil
 	}
 
-	_, err := usecase.Generate(context.Background())
+	_, err := usecase.Create(context.Background(), ownerID, CreateInput{Label: "Company A"})
 	if err == nil {
 		t.Fatalf("expected error but got nil")
 	}
@@ -160,3 +602,432 @@ func TestGenerateCreateError(t *testing.T) {
 		t.Fatalf("unexpected error code: got %q, want %q", appErr.Code, "public_url.create_failed")
 	}
 }
+
+func TestCreateRetriesKeyConflict(t *testing.T) {
+	repo := &mockRepository{
+		createErrs: []error{domain.ErrPublicURLKeyConflict, domain.ErrPublicURLKeyConflict},
+	}
+
+	usecase := newTestUsecase(repo, nil)
+	keys := []string{"taken-1", "taken-2", "fresh"}
+	usecase.keygen = func() (string, error) {
+		key := keys[0]
+		keys = keys[1:]
+		return key, nil
+	}
+
+	result, err := usecase.Create(context.Background(), ownerID, CreateInput{Label: "Company A"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result == nil || result.URLKey != "fresh" {
+		t.Fatalf("expected the retried key, got %+v", result)
+	}
+}
+
+func TestCreateGivesUpAfterRepeatedKeyConflicts(t *testing.T) {
+	conflicts := make([]error, maxKeyAttempts)
+	for i := range conflicts {
+		conflicts[i] = domain.ErrPublicURLKeyConflict
+	}
+	repo := &mockRepository{createErrs: conflicts}
+
+	usecase := newTestUsecase(repo, nil)
+	usecase.keygen = func() (string, error) {
+		return "taken", nil
+	}
+
+	_, err := usecase.Create(context.Background(), ownerID, CreateInput{Label: "Company A"})
+
+	var appErr *domain.AppError
+	if !errors.As(err, &appErr) || appErr.Code != "public_url.create_failed" {
+		t.Fatalf("expected create failed error, got %v", err)
+	}
+	if len(repo.createErrs) != 0 {
+		t.Fatalf("expected %d attempts, %d conflicts left", maxKeyAttempts, len(repo.createErrs))
+	}
+}
+
+func TestDeactivateLeavesOtherLinks(t *testing.T) {
+	repo := &mockRepository{}
+	repo.put(domain.PublicURL{ID: 1, UserID: ownerID, URLKey: "company-a", IsActive: true})
+	repo.put(domain.PublicURL{ID: 2, UserID: ownerID, URLKey: "agent-b", IsActive: true})
+
+	usecase := newTestUsecase(repo, nil)
+
+	result, err := usecase.Deactivate(context.Background(), ownerID, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.IsActive || !repo.stored[2].IsActive {
+		t.Fatalf("expected only link 1 to be deactivated, got %+v and %+v", result, repo.stored[2])
+	}
+
+	reactivated, err := usecase.Activate(context.Background(), ownerID, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reactivated.IsActive {
+		t.Fatalf("expected link 1 to be active again, got %+v", reactivated)
+	}
+
+	assertScopedToOwner(t, repo)
+}
+
+func TestDeactivateOtherUsersLink(t *testing.T) {
+	repo := &mockRepository{}
+	repo.put(domain.PublicURL{ID: 1, UserID: "0190c8a1-b2c3-7d4e-8f00-ffffffffffff", URLKey: "theirs", IsActive: true})
+
+	usecase := newTestUsecase(repo, nil)
+
+	_, err := usecase.Deactivate(context.Background(), ownerID, 1)
+
+	var appErr *domain.AppError
+	if !errors.As(err, &appErr) || appErr.Code != "public_url.not_found" {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+	if !repo.stored[1].IsActive {
+		t.Fatalf("expected the other user's link to stay active")
+	}
+}
+
+func TestUpdateKeepsOmittedFields(t *testing.T) {
+	repo := &mockRepository{}
+	repo.put(domain.PublicURL{ID: 1, UserID: ownerID, URLKey: "company-a", Label: "Company A", CVVariant: "backend", IsActive: true})
+
+	usecase := newTestUsecase(repo, nil)
+
+	label := "Company A (2nd round)"
+	result, err := usecase.Update(context.Background(), ownerID, 1, UpdateInput{Label: &label})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Label != label || result.CVVariant != "backend" {
+		t.Fatalf("unexpected update: %+v", result)
+	}
+}
+
+func TestShareURL(t *testing.T) {
+	usecase := newTestUsecase(&mockRepository{}, nil)
+
+	if got := usecase.ShareURL("abc123"); got != "https://cv.example.com/cv/abc123" {
+		t.Fatalf("unexpected share URL: %s", got)
+	}
+}
+
+func TestResolveRespectsWindow(t *testing.T) {
+	past, future := testNow.Add(-time.Hour), testNow.Add(time.Hour)
+	repo := &mockRepository{stored: map[uint64]*domain.PublicURL{
+		1: {ID: 1, URLKey: "open", IsActive: true},
+		2: {ID: 2, URLKey: "windowed", IsActive: true, ActivatesAt: &past, ExpiresAt: &future},
+		3: {ID: 3, URLKey: "scheduled", IsActive: true, ActivatesAt: &future},
+		4: {ID: 4, URLKey: "expired", IsActive: true, ExpiresAt: &past},
+		5: {ID: 5, URLKey: "inactive"},
+	}}
+	usecase := newTestUsecase(repo, nil)
+
+	cases := map[string]bool{
+		"open":      true,
+		"windowed":  true,
+		"scheduled": false,
+		"expired":   false,
+		"inactive":  false,
+		"missing":   false,
+	}
+	for key, live := range cases {
+		result, err := usecase.Resolve(context.Background(), key)
+		if live {
+			if err != nil || result == nil || result.URLKey != key {
+				t.Errorf("Resolve(%q) = %+v, %v; want the URL", key, result, err)
+			}
+			continue
+		}
+		var appErr *domain.AppError
+		if !errors.As(err, &appErr) || appErr.Code != "public_url.not_found" {
+			t.Errorf("Resolve(%q) error = %v, want not found", key, err)
+		}
+	}
+}
+
+func TestCreateWithPassphrase(t *testing.T) {
+	repo := &mockRepository{}
+	usecase := newTestUsecase(repo, nil)
+
+	result, err := usecase.Create(context.Background(), ownerID, CreateInput{Label: "Company A", Passphrase: "open sesame"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !result.Protected() || result.PassphraseHash == "open sesame" {
+		t.Fatalf("expected a hashed passphrase, got %q", result.PassphraseHash)
+	}
+	if !result.PassphraseMatches("open sesame") || result.PassphraseMatches("open sesame!") {
+		t.Fatalf("expected only the original passphrase to match")
+	}
+}
+
+func TestSetPassphraseValidatesAndRemoves(t *testing.T) {
+	repo := &mockRepository{}
+	repo.put(domain.PublicURL{ID: 3, UserID: ownerID, URLKey: "key", IsActive: true, PassphraseHash: "old"})
+	usecase := newTestUsecase(repo, nil)
+
+	for _, passphrase := range []string{"short", strings.Repeat("x", maxPassphraseBytes+1)} {
+		_, err := usecase.SetPassphrase(context.Background(), ownerID, 3, passphrase)
+		var appErr *domain.AppError
+		if !errors.As(err, &appErr) || appErr.Code != "public_url.invalid_passphrase" {
+			t.Fatalf("SetPassphrase(%q) error = %v, want invalid passphrase", passphrase, err)
+		}
+	}
+	if repo.stored[3].PassphraseHash != "old" {
+		t.Fatalf("expected invalid passphrases to leave the link unchanged")
+	}
+
+	result, err := usecase.SetPassphrase(context.Background(), ownerID, 3, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Protected() {
+		t.Fatalf("expected the passphrase to be removed")
+	}
+	assertScopedToOwner(t, repo)
+}
+
+func TestViewRequiresViewerTokenForProtectedLinks(t *testing.T) {
+	hash, err := domain.HashPublicURLPassphrase("open sesame")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	protected := domain.PublicURL{ID: 1, URLKey: "protected", IsActive: true, PassphraseHash: hash}
+	repo := &mockRepository{stored: map[uint64]*domain.PublicURL{
+		1: &protected,
+		2: {ID: 2, URLKey: "open", IsActive: true},
+	}}
+	usecase := newTestUsecase(repo, nil)
+
+	if _, err := usecase.View(context.Background(), "open", nil, Visit{}); err != nil {
+		t.Fatalf("expected open link to be viewable, got %v", err)
+	}
+
+	token, _ := fakeViewerTokens{}.Issue(protected)
+	stale, _ := fakeViewerTokens{}.Issue(domain.PublicURL{ID: 1, PassphraseHash: "previous"})
+	for _, viewerToken := range []string{"", "forged", stale} {
+		_, err := usecase.View(context.Background(), "protected", []string{viewerToken}, Visit{})
+		var appErr *domain.AppError
+		if !errors.As(err, &appErr) || appErr.Code != "public_url.passphrase_required" {
+			t.Fatalf("View with token %q error = %v, want passphrase required", viewerToken, err)
+		}
+	}
+
+	if _, err := usecase.View(context.Background(), "protected", []string{stale, token}, Visit{}); err != nil {
+		t.Fatalf("expected valid viewer token to be accepted, got %v", err)
+	}
+}
+
+func TestUnlock(t *testing.T) {
+	hash, err := domain.HashPublicURLPassphrase("open sesame")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	protected := domain.PublicURL{ID: 1, URLKey: "protected", IsActive: true, PassphraseHash: hash}
+	repo := &mockRepository{stored: map[uint64]*domain.PublicURL{1: &protected}}
+	limiter := &fakeLimiter{remaining: 2}
+	usecase := newTestUsecase(repo, nil)
+	usecase.attempts = limiter
+
+	_, err = usecase.Unlock(context.Background(), "protected", "wrong guess", "203.0.113.7")
+	var appErr *domain.AppError
+	if !errors.As(err, &appErr) || appErr.Code != "public_url.passphrase_mismatch" {
+		t.Fatalf("expected passphrase mismatch, got %v", err)
+	}
+
+	pass, err := usecase.Unlock(context.Background(), "protected", "open sesame", "203.0.113.7")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !(fakeViewerTokens{}).Verify(protected, pass.Token) || !pass.ExpiresAt.Equal(testNow.Add(time.Hour)) {
+		t.Fatalf("unexpected pass: %+v", pass)
+	}
+
+	_, err = usecase.Unlock(context.Background(), "protected", "open sesame", "203.0.113.7")
+	if !errors.As(err, &appErr) || appErr.Code != "public_url.too_many_attempts" {
+		t.Fatalf("expected attempts to be limited, got %v", err)
+	}
+	for _, key := range limiter.keys {
+		if key != "public_url:1:203.0.113.7" {
+			t.Fatalf("expected attempts to be counted per link and client, got %v", limiter.keys)
+		}
+	}
+
+	_, err = usecase.Unlock(context.Background(), "missing", "open sesame", "203.0.113.7")
+	if !errors.As(err, &appErr) || appErr.Code != "public_url.not_found" {
+		t.Fatalf("expected not found, got %v", err)
+	}
+}
+
+func TestSetSlugRedirectsReplacedSlug(t *testing.T) {
+	repo := &mockRepository{}
+	repo.put(domain.PublicURL{ID: 1, UserID: ownerID, URLKey: "key", IsActive: true})
+	usecase := newTestUsecase(repo, nil)
+
+	result, err := usecase.SetSlug(context.Background(), ownerID, 1, " Taro-Yamada ")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Slug != "taro-yamada" || usecase.ShareURL(result.Address()) != "https://cv.example.com/cv/taro-yamada" {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+
+	if _, err := usecase.SetSlug(context.Background(), ownerID, 1, "taro"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	found, err := usecase.Resolve(context.Background(), "TARO")
+	if err != nil || found.ID != 1 {
+		t.Fatalf("expected the new slug to resolve case-insensitively, got %+v, %v", found, err)
+	}
+	if found, err := usecase.Resolve(context.Background(), "key"); err != nil || found.ID != 1 {
+		t.Fatalf("expected the key to keep working, got %+v, %v", found, err)
+	}
+
+	_, err = usecase.Resolve(context.Background(), "taro-yamada")
+	var moved *MovedError
+	if !errors.As(err, &moved) || moved.Address != "taro" {
+		t.Fatalf("expected the replaced slug to redirect to the new one, got %v", err)
+	}
+
+	usecase.clock = fakeClock{now: testNow.Add(30 * 24 * time.Hour)}
+	_, err = usecase.Resolve(context.Background(), "taro-yamada")
+	var appErr *domain.AppError
+	if !errors.As(err, &appErr) || appErr.Code != "public_url.not_found" {
+		t.Fatalf("expected the redirect to end after the configured period, got %v", err)
+	}
+}
+
+func TestSetSlugRejectsTakenSlug(t *testing.T) {
+	const otherUserID = "0190c8a1-b2c3-7d4e-8f00-998877665544"
+	repo := &mockRepository{}
+	repo.put(domain.PublicURL{ID: 1, UserID: ownerID, URLKey: "mine", IsActive: true})
+	repo.put(domain.PublicURL{ID: 2, UserID: otherUserID, URLKey: "theirs", IsActive: true})
+	usecase := newTestUsecase(repo, nil)
+
+	if _, err := usecase.SetSlug(context.Background(), otherUserID, 2, "taro"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := usecase.SetSlug(context.Background(), otherUserID, 2, ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err := usecase.SetSlug(context.Background(), ownerID, 1, "TARO")
+	var appErr *domain.AppError
+	if !errors.As(err, &appErr) || appErr.Code != "public_url.invalid_slug" || appErr.Details[0].Code != "public_url.slug_taken" {
+		t.Fatalf("expected a slug still redirecting elsewhere to be taken, got %v", err)
+	}
+
+	if _, err := usecase.SetSlug(context.Background(), otherUserID, 2, "taro"); err != nil {
+		t.Fatalf("expected a link to reclaim its own replaced slug, got %v", err)
+	}
+	if _, err := usecase.SetSlug(context.Background(), otherUserID, 2, ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	usecase.clock = fakeClock{now: testNow.Add(30 * 24 * time.Hour)}
+	result, err := usecase.SetSlug(context.Background(), ownerID, 1, "taro")
+	if err != nil || result.Slug != "taro" {
+		t.Fatalf("expected the slug to be free once its redirect ended, got %+v, %v", result, err)
+	}
+}
+
+func TestScheduleExtendsExpiry(t *testing.T) {
+	repo := &mockRepository{}
+	repo.put(domain.PublicURL{ID: 7, UserID: ownerID, URLKey: "active", IsActive: true})
+	usecase := newTestUsecase(repo, nil)
+
+	expiresAt := testNow.Add(72 * time.Hour)
+	result, err := usecase.Schedule(context.Background(), ownerID, 7, ScheduleInput{ExpiresAt: &expiresAt})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.ExpiresAt == nil || !result.ExpiresAt.Equal(expiresAt) || result.ActivatesAt != nil {
+		t.Fatalf("unexpected schedule: %+v", result)
+	}
+	if repo.locks != 1 {
+		t.Fatalf("expected the owner to be locked once, got %d", repo.locks)
+	}
+
+	assertScopedToOwner(t, repo)
+}
+
+func TestScheduleRejectsInvalidWindow(t *testing.T) {
+	past := testNow.Add(-time.Minute)
+	later := testNow.Add(48 * time.Hour)
+	sooner := testNow.Add(24 * time.Hour)
+
+	cases := map[string]ScheduleInput{
+		"public_url.expiry_not_in_future":     {ExpiresAt: &past},
+		"public_url.expiry_before_activation": {ActivatesAt: &later, ExpiresAt: &sooner},
+	}
+	for code, in := range cases {
+		repo := &mockRepository{}
+		usecase := newTestUsecase(repo, nil)
+
+		_, err := usecase.Schedule(context.Background(), ownerID, 1, in)
+
+		var appErr *domain.AppError
+		if !errors.As(err, &appErr) || appErr.Code != "public_url.invalid_schedule" {
+			t.Fatalf("%s: expected invalid schedule error, got %v", code, err)
+		}
+		if len(appErr.Details) != 1 || appErr.Details[0].Code != code {
+			t.Fatalf("%s: unexpected details: %+v", code, appErr.Details)
+		}
+		if len(repo.schedules) != 0 {
+			t.Fatalf("%s: expected no update, got %+v", code, repo.schedules)
+		}
+	}
+}
+
+func TestSendExpiryRemindersOncePerExpiry(t *testing.T) {
+	expiresAt := testNow.Add(6 * time.Hour)
+	repo := &mockRepository{expiring: []domain.ExpiringPublicURL{{
+		PublicURL:  domain.PublicURL{ID: 9, URLKey: "soon", IsActive: true, ExpiresAt: &expiresAt},
+		OwnerEmail: "owner@example.com",
+	}}}
+	mailer := &fakeMailer{}
+	usecase := newTestUsecase(repo, mailer)
+
+	for range 2 {
+		if _, err := usecase.SendExpiryReminders(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if len(mailer.sent) != 1 {
+		t.Fatalf("expected one reminder, got %+v", mailer.sent)
+	}
+	got := mailer.sent[0]
+	if got.email != "owner@example.com" || got.shareURL != "https://cv.example.com/cv/soon" || !got.expiresAt.Equal(expiresAt) {
+		t.Fatalf("unexpected reminder: %+v", got)
+	}
+}
+
+func TestSendExpiryRemindersReleasesClaimOnFailure(t *testing.T) {
+	expiresAt := testNow.Add(time.Hour)
+	repo := &mockRepository{expiring: []domain.ExpiringPublicURL{{
+		PublicURL:  domain.PublicURL{ID: 4, URLKey: "soon", IsActive: true, ExpiresAt: &expiresAt},
+		OwnerEmail: "owner@example.com",
+	}}}
+	usecase := newTestUsecase(repo, &fakeMailer{err: errors.New("smtp down")})
+
+	sent, err := usecase.SendExpiryReminders(context.Background())
+
+	var appErr *domain.AppError
+	if !errors.As(err, &appErr) || appErr.Code != "public_url.reminder_failed" || sent != 0 {
+		t.Fatalf("expected reminder failure, got %d, %v", sent, err)
+	}
+	if len(repo.released) != 1 || repo.released[0] != 4 {
+		t.Fatalf("expected the claim to be released, got %+v", repo.released)
+	}
+}
@@ -0,0 +1,215 @@
+package publicurl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/sky0621/techcv/manager/backend/internal/domain"
+	"github.com/sky0621/techcv/manager/backend/internal/domain/user"
+)
+
+const (
+	defaultViewDays = 30
+	maxViewDays     = 90
+	// recentViewLimit is how many of the latest views Views returns.
+	recentViewLimit       = 20
+	maxReferrerHostLength = 255
+)
+
+// botMarkers are lowercase user agent fragments of crawlers, link previews and scripts.
+var botMarkers = []string{
+	"bot", "crawl", "spider", "slurp", "preview", "facebookexternalhit", "whatsapp",
+	"headless", "curl", "wget", "python-requests", "go-http-client", "okhttp",
+}
+
+// Visit describes the request of a viewer opening a link.
+type Visit struct {
+	IP        string
+	UserAgent string
+	// Referrer is the Referer header of the request; only its host is recorded.
+	Referrer string
+}
+
+// ViewStats summarizes how one link has been viewed.
+type ViewStats struct {
+	// Daily has an entry for every UTC day of the requested period, oldest first,
+	// including days without views.
+	Daily []domain.PublicURLDailyViews
+	// Recent holds the latest views, newest first, bots included.
+	Recent []domain.PublicURLView
+}
+
+// Views returns the daily view counts of one of the user's links over the last days UTC
+// days, today included, together with its latest views. A zero days selects 30 days.
+func (u *Usecase) Views(ctx context.Context, userID string, id uint64, days int) (*ViewStats, error) {
+	if days == 0 {
+		days = defaultViewDays
+	}
+	if days < 0 || days > maxViewDays {
+		return nil, InvalidViewDays()
+	}
+
+	if _, err := u.Get(ctx, userID, id); err != nil {
+		return nil, err
+	}
+
+	now := u.clock.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	since := today.AddDate(0, 0, 1-days)
+
+	counts, err := u.repo.DailyViews(ctx, id, since)
+	if err != nil {
+		return nil, domain.NewInternal("public_url.views_fetch_failed", "failed to fetch public URL views", err)
+	}
+	recent, err := u.repo.RecentViews(ctx, id, recentViewLimit)
+	if err != nil {
+		return nil, domain.NewInternal("public_url.views_fetch_failed", "failed to fetch public URL views", err)
+	}
+
+	byDay := make(map[string]domain.PublicURLDailyViews, len(counts))
+	for _, c := range counts {
+		byDay[c.Day.Format(time.DateOnly)] = c
+	}
+	daily := make([]domain.PublicURLDailyViews, 0, days)
+	for day := since; !day.After(today); day = day.AddDate(0, 0, 1) {
+		entry := byDay[day.Format(time.DateOnly)]
+		entry.Day = day
+		daily = append(daily, entry)
+	}
+
+	return &ViewStats{Daily: daily, Recent: recent}, nil
+}
+
+// SendFirstViewNotifications emails the owners who asked to hear about the first view of
+// a link and returns how many emails were sent. Each link is notified once, even when
+// several instances run the sweep; failed deliveries are retried by the next sweep.
+func (u *Usecase) SendFirstViewNotifications(ctx context.Context) (int, error) {
+	viewed, err := u.repo.ListFirstViewed(ctx)
+	if err != nil {
+		return 0, domain.NewInternal("public_url.fetch_failed", "failed to list viewed public URLs", err)
+	}
+
+	now := u.clock.Now()
+	sent := 0
+	var errs []error
+	for _, v := range viewed {
+		ok, err := u.sendFirstViewNotification(ctx, v, now)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if ok {
+			sent++
+		}
+	}
+	if len(errs) > 0 {
+		return sent, domain.NewInternal("public_url.notification_failed", "failed to send public URL first view notifications", errors.Join(errs...))
+	}
+	return sent, nil
+}
+
+func (u *Usecase) sendFirstViewNotification(ctx context.Context, v domain.FirstViewedPublicURL, now time.Time) (bool, error) {
+	claimed, err := u.repo.ClaimFirstViewNotification(ctx, v.ID, now)
+	if err != nil {
+		return false, fmt.Errorf("claim first view notification for public URL %d: %w", v.ID, err)
+	}
+	if !claimed {
+		return false, nil
+	}
+
+	email, err := user.NewEmail(v.OwnerEmail)
+	if err == nil {
+		err = u.mailer.SendPublicURLFirstView(ctx, email, v.Label, u.ShareURL(v.Address()), *v.FirstViewedAt)
+	}
+	if err != nil {
+		if releaseErr := u.repo.ReleaseFirstViewNotification(ctx, v.ID); releaseErr != nil {
+			err = errors.Join(err, releaseErr)
+		}
+		return false, fmt.Errorf("send first view notification for public URL %d: %w", v.ID, err)
+	}
+	return true, nil
+}
+
+// recordView stores a view of the link and, unless a bot made it, marks the link as viewed.
+func (u *Usecase) recordView(ctx context.Context, link domain.PublicURL, visit Visit) error {
+	now := u.clock.Now()
+	view := domain.PublicURLView{
+		PublicURLID:    link.ID,
+		ViewedAt:       now,
+		ReferrerHost:   u.referrerHost(visit.Referrer),
+		UserAgentClass: classifyUserAgent(visit.UserAgent),
+		VisitorHash:    u.visitors.Hash(link.ID, now, visit.IP, visit.UserAgent),
+	}
+	if ip, err := netip.ParseAddr(visit.IP); err == nil {
+		view.Country = u.geo.Country(ip)
+	}
+
+	if err := u.repo.RecordView(ctx, view); err != nil {
+		return domain.NewInternal("public_url.view_record_failed", "failed to record public URL view", err)
+	}
+	if view.UserAgentClass == domain.UserAgentBot || link.FirstViewedAt != nil {
+		return nil
+	}
+	if err := u.repo.MarkFirstViewed(ctx, link.ID, now); err != nil {
+		return domain.NewInternal("public_url.view_record_failed", "failed to record public URL view", err)
+	}
+	return nil
+}
+
+// referrerHost reduces a Referer header to its host without a leading "www.". Referrals
+// from the publisher's own pages, such as the passphrase form, are not recorded.
+func (u *Usecase) referrerHost(referrer string) string {
+	parsed, err := url.Parse(referrer)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return ""
+	}
+	host := normalizeHost(parsed.Hostname())
+	if host == "" || host == u.publisherHost || len(host) > maxReferrerHostLength {
+		return ""
+	}
+	return host
+}
+
+func normalizeHost(host string) string {
+	return strings.TrimPrefix(strings.ToLower(host), "www.")
+}
+
+// classifyUserAgent sorts a User-Agent header into a coarse client class.
+func classifyUserAgent(userAgent string) domain.UserAgentClass {
+	ua := strings.ToLower(userAgent)
+	switch {
+	case ua == "":
+		return domain.UserAgentOther
+	case containsAny(ua, botMarkers...):
+		return domain.UserAgentBot
+	case containsAny(ua, "ipad", "tablet") || (strings.Contains(ua, "android") && !strings.Contains(ua, "mobile")):
+		return domain.UserAgentTablet
+	case containsAny(ua, "mobi", "iphone", "ipod", "android"):
+		return domain.UserAgentMobile
+	case containsAny(ua, "windows", "macintosh", "x11", "linux", "cros"):
+		return domain.UserAgentDesktop
+	default:
+		return domain.UserAgentOther
+	}
+}
+
+func containsAny(s string, substrings ...string) bool {
+	for _, sub := range substrings {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
+
+// InvalidViewDays reports a period the views cannot be counted over. It is exported so that
+// a period which fails to parse is reported the same way as one which is out of range.
+func InvalidViewDays() error {
+	detail := domain.ErrorDetail{Field: "days", Code: "public_url.view_days_range", Message: "days must be between 1 and 90"}
+	return domain.NewValidation("public_url.invalid_view_days", "invalid view period").WithDetails(detail)
+}
@@ -0,0 +1,206 @@
+package publicurl
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sky0621/techcv/manager/backend/internal/domain"
+)
+
+const (
+	iPhoneSafari  = "Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.0 Mobile/15E148 Safari/604.1"
+	desktopChrome = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36"
+	slackPreview  = "Slackbot-LinkExpanding 1.0 (+https://api.slack.com/robots)"
+)
+
+func TestViewRecordsCoarseVisit(t *testing.T) {
+	repo := &mockRepository{}
+	repo.put(domain.PublicURL{ID: 1, UserID: ownerID, URLKey: "key", IsActive: true})
+	usecase := newTestUsecase(repo, nil)
+
+	if _, err := usecase.View(context.Background(), "key", nil, Visit{IP: "203.0.113.9", UserAgent: slackPreview}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if repo.stored[1].FirstViewedAt != nil {
+		t.Fatalf("expected a link preview not to count as the first view")
+	}
+
+	visit := Visit{IP: "192.0.2.1", UserAgent: iPhoneSafari, Referrer: "https://www.LinkedIn.com/in/someone?trk=abc"}
+	if _, err := usecase.View(context.Background(), "key", nil, visit); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(repo.views) != 2 {
+		t.Fatalf("expected two recorded views, got %+v", repo.views)
+	}
+	got := repo.views[1]
+	if got.PublicURLID != 1 || !got.ViewedAt.Equal(testNow) || got.ReferrerHost != "linkedin.com" ||
+		got.UserAgentClass != domain.UserAgentMobile || got.Country != "JP" || len(got.VisitorHash) == 0 {
+		t.Fatalf("unexpected view: %+v", got)
+	}
+	if repo.views[0].UserAgentClass != domain.UserAgentBot || repo.views[0].Country != "" {
+		t.Fatalf("unexpected preview view: %+v", repo.views[0])
+	}
+	if first := repo.stored[1].FirstViewedAt; first == nil || !first.Equal(testNow) {
+		t.Fatalf("expected the first view to be marked, got %v", first)
+	}
+}
+
+func TestViewDropsOwnReferrer(t *testing.T) {
+	repo := &mockRepository{}
+	repo.put(domain.PublicURL{ID: 1, UserID: ownerID, URLKey: "key", IsActive: true})
+	usecase := newTestUsecase(repo, nil)
+
+	for _, referrer := range []string{"https://cv.example.com/cv/key/unlock", "android-app://com.example", "not a url"} {
+		if _, err := usecase.View(context.Background(), "key", nil, Visit{UserAgent: desktopChrome, Referrer: referrer}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	for _, v := range repo.views {
+		if v.ReferrerHost != "" {
+			t.Fatalf("expected no referrer to be recorded, got %q", v.ReferrerHost)
+		}
+	}
+}
+
+func TestViewsAggregatesDailyAndRecent(t *testing.T) {
+	repo := &mockRepository{}
+	repo.put(domain.PublicURL{ID: 1, UserID: ownerID, URLKey: "key", IsActive: true})
+	usecase := newTestUsecase(repo, nil)
+
+	view := func(at time.Time, ip, userAgent string) {
+		t.Helper()
+		usecase.clock = fakeClock{now: at}
+		if _, err := usecase.View(context.Background(), "key", nil, Visit{IP: ip, UserAgent: userAgent}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	twoDaysAgo := testNow.AddDate(0, 0, -2)
+	view(twoDaysAgo, "192.0.2.1", desktopChrome)
+	view(twoDaysAgo.Add(time.Hour), "192.0.2.1", desktopChrome)
+	view(twoDaysAgo.Add(2*time.Hour), "192.0.2.2", iPhoneSafari)
+	view(testNow, "192.0.2.1", desktopChrome)
+	view(testNow.Add(time.Minute), "203.0.113.9", slackPreview)
+
+	usecase.clock = fakeClock{now: testNow.Add(time.Hour)}
+	stats, err := usecase.Views(context.Background(), ownerID, 1, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []domain.PublicURLDailyViews{
+		{Day: time.Date(2026, 9, 29, 0, 0, 0, 0, time.UTC), Views: 3, UniqueVisitors: 2},
+		{Day: time.Date(2026, 9, 30, 0, 0, 0, 0, time.UTC)},
+		{Day: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), Views: 1, UniqueVisitors: 1},
+	}
+	if len(stats.Daily) != len(want) {
+		t.Fatalf("unexpected daily views: %+v", stats.Daily)
+	}
+	for i := range want {
+		if !stats.Daily[i].Day.Equal(want[i].Day) || stats.Daily[i].Views != want[i].Views || stats.Daily[i].UniqueVisitors != want[i].UniqueVisitors {
+			t.Fatalf("daily[%d] = %+v, want %+v", i, stats.Daily[i], want[i])
+		}
+	}
+	if len(stats.Recent) != 5 || stats.Recent[0].UserAgentClass != domain.UserAgentBot || !stats.Recent[4].ViewedAt.Equal(twoDaysAgo) {
+		t.Fatalf("expected the latest views newest first, got %+v", stats.Recent)
+	}
+	assertScopedToOwner(t, repo)
+}
+
+func TestViewsValidatesRequest(t *testing.T) {
+	repo := &mockRepository{}
+	repo.put(domain.PublicURL{ID: 1, UserID: ownerID, URLKey: "key", IsActive: true})
+	usecase := newTestUsecase(repo, nil)
+
+	stats, err := usecase.Views(context.Background(), ownerID, 1, 0)
+	if err != nil || len(stats.Daily) != defaultViewDays {
+		t.Fatalf("expected the default period, got %v", err)
+	}
+
+	for _, days := range []int{-1, maxViewDays + 1} {
+		_, err := usecase.Views(context.Background(), ownerID, 1, days)
+		var appErr *domain.AppError
+		if !errors.As(err, &appErr) || appErr.Code != "public_url.invalid_view_days" {
+			t.Fatalf("Views with %d days error = %v, want invalid days", days, err)
+		}
+	}
+
+	_, err = usecase.Views(context.Background(), ownerID, 2, 7)
+	var appErr *domain.AppError
+	if !errors.As(err, &appErr) || appErr.Code != "public_url.not_found" {
+		t.Fatalf("expected unknown link to be not found, got %v", err)
+	}
+}
+
+func TestSendFirstViewNotificationsOnce(t *testing.T) {
+	repo := &mockRepository{}
+	repo.put(domain.PublicURL{ID: 1, UserID: ownerID, URLKey: "key", Label: "Company A", IsActive: true, NotifyFirstView: true})
+	repo.put(domain.PublicURL{ID: 2, UserID: ownerID, URLKey: "quiet", IsActive: true})
+	mailer := &fakeMailer{}
+	usecase := newTestUsecase(repo, mailer)
+
+	for _, key := range []string{"key", "quiet"} {
+		if _, err := usecase.View(context.Background(), key, nil, Visit{UserAgent: desktopChrome}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	usecase.clock = fakeClock{now: testNow.Add(time.Hour)}
+	if _, err := usecase.View(context.Background(), "key", nil, Visit{UserAgent: iPhoneSafari}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for range 2 {
+		if _, err := usecase.SendFirstViewNotifications(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if len(mailer.firstViews) != 1 {
+		t.Fatalf("expected one notification, got %+v", mailer.firstViews)
+	}
+	got := mailer.firstViews[0]
+	if got.email != "owner@example.com" || got.label != "Company A" || got.shareURL != "https://cv.example.com/cv/key" || !got.viewedAt.Equal(testNow) {
+		t.Fatalf("unexpected notification: %+v", got)
+	}
+}
+
+func TestSendFirstViewNotificationsReleasesClaimOnFailure(t *testing.T) {
+	viewedAt := testNow
+	repo := &mockRepository{}
+	repo.put(domain.PublicURL{ID: 4, UserID: ownerID, URLKey: "key", IsActive: true, NotifyFirstView: true, FirstViewedAt: &viewedAt})
+	usecase := newTestUsecase(repo, &fakeMailer{err: errors.New("smtp down")})
+
+	sent, err := usecase.SendFirstViewNotifications(context.Background())
+
+	var appErr *domain.AppError
+	if !errors.As(err, &appErr) || appErr.Code != "public_url.notification_failed" || sent != 0 {
+		t.Fatalf("expected notification failure, got %d, %v", sent, err)
+	}
+	if len(repo.released) != 1 || repo.released[0] != 4 || repo.notified[4] {
+		t.Fatalf("expected the claim to be released, got %+v", repo.released)
+	}
+}
+
+func TestClassifyUserAgent(t *testing.T) {
+	cases := map[string]domain.UserAgentClass{
+		"":            domain.UserAgentOther,
+		desktopChrome: domain.UserAgentDesktop,
+		"Mozilla/5.0 (Macintosh; Intel Mac OS X 14_0) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.0 Safari/605.1.15": domain.UserAgentDesktop,
+		iPhoneSafari: domain.UserAgentMobile,
+		"Mozilla/5.0 (Linux; Android 14; Pixel 8) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0 Mobile Safari/537.36": domain.UserAgentMobile,
+		"Mozilla/5.0 (Linux; Android 13; SM-X700) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0 Safari/537.36":        domain.UserAgentTablet,
+		"Mozilla/5.0 (iPad; CPU OS 17_0 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.0 Safari/604.1":  domain.UserAgentTablet,
+		slackPreview: domain.UserAgentBot,
+		"LinkedInBot/1.0 (compatible; Mozilla/5.0; Apache-HttpClient +http://www.linkedin.com)": domain.UserAgentBot,
+		"curl/8.4.0": domain.UserAgentBot,
+		"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) HeadlessChrome/120.0 Safari/537.36": domain.UserAgentBot,
+		"SomethingElse/1.0": domain.UserAgentOther,
+	}
+	for userAgent, want := range cases {
+		if got := classifyUserAgent(userAgent); got != want {
+			t.Errorf("classifyUserAgent(%q) = %s, want %s", userAgent, got, want)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+// Package skillcatalog provides use cases for browsing and curating the skill catalog.
+package skillcatalog
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sky0621/techcv/manager/backend/internal/domain"
+	"github.com/sky0621/techcv/manager/backend/internal/domain/skill"
+)
+
+const (
+	defaultSuggestLimit = 10
+	maxSuggestLimit     = 50
+	// suggestRefreshInterval bounds how long suggestions may miss changes made by other
+	// instances and imports; within it, suggestions are served from memory alone.
+	suggestRefreshInterval = 30 * time.Second
+)
+
+// MergeInput identifies the duplicate skill to fold into a canonical one.
+type MergeInput struct {
+	SourceID string
+	TargetID string
+}
+
+// SuggestInput carries a partially typed skill name. A zero Limit selects the default.
+type SuggestInput struct {
+	Query string
+	Limit int
+}
+
+// Clock provides the current time.
+type Clock interface {
+	Now() time.Time
+}
+
+// TransactionManager executes operations within a transaction boundary.
+type TransactionManager interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// Usecase exposes skill catalog operations.
+type Usecase struct {
+	repo  skill.CatalogRepository
+	usage skill.UsageRepository
+	tx    TransactionManager
+	clock Clock
+	// mu serializes load-modify-save cycles within this process; the repository lock does
+	// the same across processes.
+	mu sync.Mutex
+	// index is the suggestion index built from the catalog at some revision, with the usage
+	// that ranks it; nil until first use and after a merge.
+	index atomic.Pointer[revisionedIndex]
+}
+
+// revisionedIndex remembers which catalog revision a suggestion index was built from and
+// when the revision and usage were last read.
+type revisionedIndex struct {
+	revision  uint64
+	index     *skill.SuggestIndex
+	counts    map[string]int
+	checkedAt time.Time
+}
+
+// New constructs a new Usecase instance.
+func New(repo skill.CatalogRepository, usage skill.UsageRepository, tx TransactionManager, clock Clock) *Usecase {
+	return &Usecase{repo: repo, usage: usage, tx: tx, clock: clock}
+}
+
+// List returns every skill in the catalog ordered by canonical name.
+func (u *Usecase) List(ctx context.Context) ([]skill.Skill, error) {
+	catalog, err := u.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return catalog.Skills(), nil
+}
+
+// Suggest returns catalog entries whose name or alias starts with the query, ranked by
+// global usage.
+func (u *Usecase) Suggest(ctx context.Context, in SuggestInput) ([]skill.Suggestion, error) {
+	limit := in.Limit
+	if limit == 0 {
+		limit = defaultSuggestLimit
+	}
+	if limit < 0 || limit > maxSuggestLimit {
+		detail := domain.ErrorDetail{Field: "limit", Code: domain.ErrorCodeInvalidSuggestLimit, Message: "件数は1以上50以下で指定してください"}
+		return nil, domain.NewValidation(domain.ErrorCodeInvalidSuggestLimit, "件数の指定が正しくありません").WithDetails(detail)
+	}
+
+	cached, err := u.suggestIndex(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return cached.index.Suggest(in.Query, limit, func(id string) int { return cached.counts[id] }), nil
+}
+
+// Merge folds a duplicate skill into the target and returns the updated target.
+func (u *Usecase) Merge(ctx context.Context, in MergeInput) (skill.Skill, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	var merged skill.Skill
+	err := u.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := u.repo.Lock(ctx); err != nil {
+			return domain.NewInternal(domain.ErrorCodeSkillCatalogLoadFailed, "スキルカタログの取得に失敗しました", err)
+		}
+		catalog, err := u.load(ctx)
+		if err != nil {
+			return err
+		}
+
+		if merged, err = catalog.Merge(in.SourceID, in.TargetID); err != nil {
+			return err
+		}
+
+		// Usage moves first; it refers to the source skill that saving deletes.
+		if err := u.usage.Transfer(ctx, in.SourceID, in.TargetID); err != nil {
+			return domain.NewInternal(domain.ErrorCodeSkillUsageUpdateFailed, "スキルの利用状況の更新に失敗しました", err)
+		}
+		if err := u.repo.Save(ctx, catalog); err != nil {
+			return domain.NewInternal(domain.ErrorCodeSkillCatalogSaveFailed, "スキルカタログの保存に失敗しました", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return skill.Skill{}, err
+	}
+	u.index.Store(nil)
+	return merged, nil
+}
+
+// suggestIndex returns the suggestion index of the catalog with the usage ranking it. Once
+// suggestRefreshInterval has passed, the usage is read again and the index is rebuilt when
+// the catalog was changed, possibly by another instance, since it was built.
+func (u *Usecase) suggestIndex(ctx context.Context) (*revisionedIndex, error) {
+	now := u.clock.Now()
+	cached := u.index.Load()
+	if cached != nil && now.Sub(cached.checkedAt) < suggestRefreshInterval {
+		return cached, nil
+	}
+
+	revision, err := u.repo.Revision(ctx)
+	if err != nil {
+		return nil, domain.NewInternal(domain.ErrorCodeSkillCatalogLoadFailed, "スキルカタログの取得に失敗しました", err)
+	}
+	counts, err := u.usage.Counts(ctx)
+	if err != nil {
+		return nil, domain.NewInternal(domain.ErrorCodeSkillUsageFetchFailed, "スキルの利用状況の取得に失敗しました", err)
+	}
+
+	refreshed := &revisionedIndex{revision: revision, counts: counts, checkedAt: now}
+	if cached != nil && cached.revision == revision {
+		refreshed.index = cached.index
+	} else {
+		catalog, err := u.load(ctx)
+		if err != nil {
+			return nil, err
+		}
+		// The catalog may be newer than revision; that only costs one more rebuild later.
+		refreshed.index = skill.NewSuggestIndex(catalog)
+	}
+	u.index.Store(refreshed)
+	return refreshed, nil
+}
+
+func (u *Usecase) load(ctx context.Context) (*skill.Catalog, error) {
+	catalog, err := u.repo.Load(ctx)
+	if err != nil {
+		return nil, domain.NewInternal(domain.ErrorCodeSkillCatalogLoadFailed, "スキルカタログの取得に失敗しました", err)
+	}
+	return catalog, nil
+}
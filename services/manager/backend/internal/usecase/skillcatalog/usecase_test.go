@@ -0,0 +1,160 @@
+package skillcatalog
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/sky0621/techcv/manager/backend/internal/domain"
+	"github.com/sky0621/techcv/manager/backend/internal/domain/skill"
+	"github.com/sky0621/techcv/manager/backend/internal/infrastructure/persistence/memory"
+	"github.com/sky0621/techcv/manager/backend/internal/infrastructure/transaction"
+)
+
+// fakeClock is a clock that tests move forward by hand.
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func newClock() *fakeClock {
+	return &fakeClock{now: time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)}
+}
+
+func newUsecase(t *testing.T) (*Usecase, skill.Skill, skill.Skill) {
+	t.Helper()
+	golang, err := skill.NewSkill("Go", skill.CategoryLanguage, nil, "")
+	if err != nil {
+		t.Fatalf("NewSkill() error = %v", err)
+	}
+	duplicate, err := skill.NewSkill("Golang", skill.CategoryLanguage, []string{"ゴー"}, "")
+	if err != nil {
+		t.Fatalf("NewSkill() error = %v", err)
+	}
+	catalog, err := skill.NewCatalog([]skill.Skill{golang, duplicate})
+	if err != nil {
+		t.Fatalf("NewCatalog() error = %v", err)
+	}
+	return New(memory.NewSkillCatalogRepository(catalog), memory.NewSkillUsageRepository(), transaction.NewNoopManager(), newClock()), golang, duplicate
+}
+
+func TestMergePersists(t *testing.T) {
+	usecase, golang, duplicate := newUsecase(t)
+	ctx := context.Background()
+
+	merged, err := usecase.Merge(ctx, MergeInput{SourceID: duplicate.ID(), TargetID: golang.ID()})
+	if err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+	if merged.Name() != "Go" || len(merged.Aliases()) != 2 {
+		t.Fatalf("unexpected merged skill: %v %v", merged.Name(), merged.Aliases())
+	}
+
+	skills, err := usecase.List(ctx)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(skills) != 1 || skills[0].ID() != golang.ID() || !slices.Contains(skills[0].Aliases(), "Golang") {
+		t.Fatalf("expected duplicate to be folded into Go, got %+v", skills)
+	}
+}
+
+func TestMergeUnknownSkill(t *testing.T) {
+	usecase, golang, _ := newUsecase(t)
+
+	_, err := usecase.Merge(context.Background(), MergeInput{SourceID: "missing", TargetID: golang.ID()})
+
+	var appErr *domain.AppError
+	if !errors.As(err, &appErr) || appErr.Code != domain.ErrorCodeSkillNotFound {
+		t.Fatalf("expected skill not found, got %v", err)
+	}
+	if len(appErr.Details) != 1 || appErr.Details[0].Field != "source_id" {
+		t.Fatalf("unexpected details: %+v", appErr.Details)
+	}
+}
+
+func TestSuggestRebuildsAfterMerge(t *testing.T) {
+	usecase, golang, duplicate := newUsecase(t)
+	ctx := context.Background()
+
+	suggestions, err := usecase.Suggest(ctx, SuggestInput{Query: "go"})
+	if err != nil {
+		t.Fatalf("Suggest() error = %v", err)
+	}
+	if len(suggestions) != 2 {
+		t.Fatalf("expected both skills before merge, got %d", len(suggestions))
+	}
+
+	if _, err := usecase.Merge(ctx, MergeInput{SourceID: duplicate.ID(), TargetID: golang.ID()}); err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+
+	suggestions, err = usecase.Suggest(ctx, SuggestInput{Query: "gola"})
+	if err != nil {
+		t.Fatalf("Suggest() error = %v", err)
+	}
+	if len(suggestions) != 1 || suggestions[0].Skill.ID() != golang.ID() || suggestions[0].MatchedAlias != "Golang" {
+		t.Fatalf("expected merged alias to be suggested, got %+v", suggestions)
+	}
+}
+
+func TestSuggestSeesMergeByAnotherInstance(t *testing.T) {
+	golang, err := skill.NewSkill("Go", skill.CategoryLanguage, nil, "")
+	if err != nil {
+		t.Fatalf("NewSkill() error = %v", err)
+	}
+	duplicate, err := skill.NewSkill("Golang", skill.CategoryLanguage, nil, "")
+	if err != nil {
+		t.Fatalf("NewSkill() error = %v", err)
+	}
+	catalog, err := skill.NewCatalog([]skill.Skill{golang, duplicate})
+	if err != nil {
+		t.Fatalf("NewCatalog() error = %v", err)
+	}
+	repo := memory.NewSkillCatalogRepository(catalog)
+	usage := memory.NewSkillUsageRepository()
+	clock := newClock()
+	serving := New(repo, usage, transaction.NewNoopManager(), clock)
+	curating := New(repo, usage, transaction.NewNoopManager(), clock)
+	ctx := context.Background()
+
+	if _, err := serving.Suggest(ctx, SuggestInput{Query: "go"}); err != nil {
+		t.Fatalf("Suggest() error = %v", err)
+	}
+	if _, err := curating.Merge(ctx, MergeInput{SourceID: duplicate.ID(), TargetID: golang.ID()}); err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+
+	suggestions, err := serving.Suggest(ctx, SuggestInput{Query: "go"})
+	if err != nil {
+		t.Fatalf("Suggest() error = %v", err)
+	}
+	if len(suggestions) != 2 {
+		t.Fatalf("expected suggestions to be served from memory until the refresh, got %+v", suggestions)
+	}
+
+	clock.now = clock.now.Add(suggestRefreshInterval)
+	suggestions, err = serving.Suggest(ctx, SuggestInput{Query: "go"})
+	if err != nil {
+		t.Fatalf("Suggest() error = %v", err)
+	}
+	if len(suggestions) != 1 || suggestions[0].Skill.ID() != golang.ID() {
+		t.Fatalf("expected the stale index to be rebuilt, got %+v", suggestions)
+	}
+}
+
+func TestSuggestInvalidLimit(t *testing.T) {
+	usecase, _, _ := newUsecase(t)
+
+	_, err := usecase.Suggest(context.Background(), SuggestInput{Query: "go", Limit: 51})
+
+	var appErr *domain.AppError
+	if !errors.As(err, &appErr) || appErr.Code != domain.ErrorCodeInvalidSuggestLimit {
+		t.Fatalf("expected invalid limit, got %v", err)
+	}
+}